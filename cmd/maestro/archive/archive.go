@@ -0,0 +1,187 @@
+package archive
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	kindConsumer       = "Consumer"
+	kindResourceBundle = "ResourceBundle"
+
+	// formatNDJSON is the archive format with one JSON record per line
+	formatNDJSON = "ndjson"
+	// formatTar is the archive format with one JSON file per record
+	formatTar = "tar"
+)
+
+// record is a consumer or a resource bundle in an export archive. The consumers come before the resource bundles, so
+// the consumer of a resource bundle is imported first.
+type record struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Labels are the labels of a consumer
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Source, ConsumerName, Type, Version and Payload are the fields of a resource bundle, the payload is the
+	// manifest bundle as it is stored by the services
+	Source       string                 `json:"source,omitempty"`
+	ConsumerName string                 `json:"consumer_name,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+	Version      int32                  `json:"version,omitempty"`
+	Payload      map[string]interface{} `json:"payload,omitempty"`
+}
+
+// validate checks that the record has the fields that its kind requires
+func (r *record) validate() error {
+	switch r.Kind {
+	case kindConsumer:
+		if r.Name == "" {
+			return fmt.Errorf("consumer %q has no name", r.ID)
+		}
+	case kindResourceBundle:
+		if r.ConsumerName == "" || len(r.Payload) == 0 {
+			return fmt.Errorf("resource bundle %q has no consumer name or payload", r.ID)
+		}
+	default:
+		return fmt.Errorf("record %q has an unknown kind %q", r.ID, r.Kind)
+	}
+	return nil
+}
+
+// recordWriter writes the records to an archive
+type recordWriter interface {
+	Write(rec *record) error
+	// Close completes the archive, it does not close the underlying writer
+	Close() error
+}
+
+// recordReader reads the records of an archive, Read returns io.EOF after the last record
+type recordReader interface {
+	Read() (*record, error)
+}
+
+// newRecordWriter returns the writer of the archive format
+func newRecordWriter(w io.Writer, format string) (recordWriter, error) {
+	switch format {
+	case formatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case formatTar:
+		return &tarWriter{writer: tar.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("invalid archive format: %s (must be %s or %s)", format, formatNDJSON, formatTar)
+}
+
+// newRecordReader returns the reader of the archive format
+func newRecordReader(r io.Reader, format string) (recordReader, error) {
+	switch format {
+	case formatNDJSON:
+		return &ndjsonReader{decoder: json.NewDecoder(r)}, nil
+	case formatTar:
+		return &tarReader{reader: tar.NewReader(r)}, nil
+	}
+	return nil, fmt.Errorf("invalid archive format: %s (must be %s or %s)", format, formatNDJSON, formatTar)
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(rec *record) error {
+	return w.encoder.Encode(rec)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+type ndjsonReader struct {
+	decoder *json.Decoder
+	count   int
+}
+
+func (r *ndjsonReader) Read() (*record, error) {
+	rec := &record{}
+	if err := r.decoder.Decode(rec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to parse record %d: %w", r.count+1, err)
+	}
+	r.count++
+
+	if err := rec.validate(); err != nil {
+		return nil, fmt.Errorf("invalid record %d: %w", r.count, err)
+	}
+	return rec, nil
+}
+
+// tarWriter writes each record as the consumers/<id>.json or resourcebundles/<id>.json file
+type tarWriter struct {
+	writer *tar.Writer
+}
+
+func (w *tarWriter) Write(rec *record) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := "consumers"
+	if rec.Kind == kindResourceBundle {
+		dir = "resourcebundles"
+	}
+	header := &tar.Header{
+		Name:    path.Join(dir, rec.ID+".json"),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: rec.CreatedAt,
+	}
+	if err := w.writer.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = w.writer.Write(data)
+	return err
+}
+
+func (w *tarWriter) Close() error {
+	return w.writer.Close()
+}
+
+// tarReader reads the records from the JSON files of a tar archive in their order, the other entries are skipped
+type tarReader struct {
+	reader *tar.Reader
+}
+
+func (r *tarReader) Read() (*record, error) {
+	for {
+		header, err := r.reader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("failed to read the tar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".json") {
+			continue
+		}
+
+		rec := &record{}
+		if err := json.NewDecoder(r.reader).Decode(rec); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", header.Name, err)
+		}
+		if err := rec.validate(); err != nil {
+			return nil, fmt.Errorf("invalid record %s: %w", header.Name, err)
+		}
+		return rec, nil
+	}
+}
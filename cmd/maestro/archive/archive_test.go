@@ -0,0 +1,176 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRecords() []*record {
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	return []*record{
+		{
+			Kind:      kindConsumer,
+			ID:        "consumer-1",
+			Name:      "cluster1",
+			CreatedAt: createdAt,
+			Labels:    map[string]string{"env": "prod"},
+		},
+		{
+			Kind:         kindResourceBundle,
+			ID:           "bundle-1",
+			Name:         "nginx",
+			CreatedAt:    createdAt,
+			Source:       "maestro",
+			ConsumerName: "cluster1",
+			Version:      2,
+			Payload:      testPayload("nginx"),
+		},
+	}
+}
+
+// testPayload returns a manifest bundle payload with a config map of the name
+func testPayload(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"specversion": "1.0",
+		"data": map[string]interface{}{
+			"manifests": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+				},
+			},
+		},
+	}
+}
+
+func readAll(t *testing.T, reader recordReader) []*record {
+	t.Helper()
+
+	var records []*record
+	for {
+		rec, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		records = append(records, rec)
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	for _, format := range []string{formatNDJSON, formatTar} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer, err := newRecordWriter(&buf, format)
+			if err != nil {
+				t.Fatalf("newRecordWriter() error = %v", err)
+			}
+			for _, rec := range testRecords() {
+				if err := writer.Write(rec); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			reader, err := newRecordReader(&buf, format)
+			if err != nil {
+				t.Fatalf("newRecordReader() error = %v", err)
+			}
+			got := readAll(t, reader)
+			if !reflect.DeepEqual(got, testRecords()) {
+				t.Errorf("read records = %+v, want %+v", got, testRecords())
+			}
+		})
+	}
+}
+
+func TestTarWriter_Layout(t *testing.T) {
+	var buf bytes.Buffer
+	writer, _ := newRecordWriter(&buf, formatTar)
+	for _, rec := range testRecords() {
+		if err := writer.Write(rec); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var names []string
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		names = append(names, header.Name)
+	}
+
+	want := []string{"consumers/consumer-1.json", "resourcebundles/bundle-1.json"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("tar entries = %v, want %v", names, want)
+	}
+}
+
+func TestRecordReader_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		errContains string
+	}{
+		{
+			name:        "malformed json",
+			input:       `{"kind":"Consumer","name":"cluster1"}` + "\n" + `{"kind":`,
+			errContains: "failed to parse record 2",
+		},
+		{
+			name:        "unknown kind",
+			input:       `{"kind":"Secret","id":"secret-1"}`,
+			errContains: "unknown kind",
+		},
+		{
+			name:        "consumer without name",
+			input:       `{"kind":"Consumer","id":"consumer-1"}`,
+			errContains: "has no name",
+		},
+		{
+			name:        "resource bundle without payload",
+			input:       `{"kind":"ResourceBundle","id":"bundle-1","consumer_name":"cluster1"}`,
+			errContains: "has no consumer name or payload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, _ := newRecordReader(strings.NewReader(tt.input), formatNDJSON)
+
+			var err error
+			for err == nil {
+				_, err = reader.Read()
+			}
+			if errors.Is(err, io.EOF) || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Read() error = %v, should contain %v", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestNewRecordWriter_InvalidFormat(t *testing.T) {
+	if _, err := newRecordWriter(io.Discard, "zip"); err == nil || !strings.Contains(err.Error(), "invalid archive format") {
+		t.Errorf("newRecordWriter() error = %v, should contain %v", err, "invalid archive format")
+	}
+}
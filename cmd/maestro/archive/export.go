@@ -0,0 +1,248 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// exportPageSize is the number of the consumers or resource bundles that are read from the database at a time
+const exportPageSize = 100
+
+// NewExportCommand creates the export command
+func NewExportCommand() *cobra.Command {
+	dbConfig := config.NewDatabaseConfig()
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the consumers and resource bundles to an archive",
+		Long: `Export the consumers and resource bundles from the maestro database to an archive.
+
+The records are streamed from the database as they are read, the consumers go first so
+that an import creates the consumer of a resource bundle before the resource bundle. The
+archive is NDJSON (one JSON record per line) by default, or a tar archive with one JSON
+file per record (consumers/<id>.json and resourcebundles/<id>.json) with --format tar.
+
+The resource bundles can be filtered by a search (in the same syntax as the 'search'
+parameter of the REST API), by their source and by their consumers. With --consumer,
+only the given consumers are exported.
+
+The command connects to the database with the same flags as 'maestro migration'.
+
+Examples:
+  maestro export -f backup.ndjson
+  maestro export --format tar -f backup.tar
+  maestro export --consumer cluster1 --consumer cluster2 > clusters.ndjson
+  maestro export --source maestro-e2e --search "name like 'nginx%'" -f nginx.ndjson`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runExport(cmd, dbConfig); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	dbConfig.AddFlags(cmd.Flags())
+	cmd.Flags().StringP("file", "f", "-", "The archive file to write, - for the standard output")
+	cmd.Flags().String("format", formatNDJSON, "The archive format: ndjson or tar")
+	cmd.Flags().String("search", "", "Only export the resource bundles that match the search (e.g., \"name like 'nginx%'\")")
+	cmd.Flags().String("source", "", "Only export the resource bundles of the source")
+	cmd.Flags().StringSlice("consumer", nil, "Only export the consumers with the names and their resource bundles (can be repeated)")
+
+	return cmd
+}
+
+// exportFilter selects the consumers and resource bundles to export
+type exportFilter struct {
+	search    string
+	source    string
+	consumers []string
+}
+
+// consumerSearch returns the search of the consumers
+func (f *exportFilter) consumerSearch() string {
+	if len(f.consumers) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("name in (%s)", quoteValues(f.consumers))
+}
+
+// resourceSearch returns the search of the resource bundles, the conditions of the filter are combined with 'and'
+func (f *exportFilter) resourceSearch() string {
+	var conditions []string
+	if f.source != "" {
+		conditions = append(conditions, fmt.Sprintf("source = '%s'", f.source))
+	}
+	if len(f.consumers) > 0 {
+		conditions = append(conditions, fmt.Sprintf("consumer_name in (%s)", quoteValues(f.consumers)))
+	}
+	if f.search != "" {
+		conditions = append(conditions, fmt.Sprintf("(%s)", f.search))
+	}
+	return strings.Join(conditions, " and ")
+}
+
+func quoteValues(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("'%s'", value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func runExport(cmd *cobra.Command, dbConfig *config.DatabaseConfig) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to read --file flag: %w", err)
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to read --format flag: %w", err)
+	}
+	filter := &exportFilter{}
+	if filter.search, err = cmd.Flags().GetString("search"); err != nil {
+		return fmt.Errorf("failed to read --search flag: %w", err)
+	}
+	if filter.source, err = cmd.Flags().GetString("source"); err != nil {
+		return fmt.Errorf("failed to read --source flag: %w", err)
+	}
+	if filter.consumers, err = cmd.Flags().GetStringSlice("consumer"); err != nil {
+		return fmt.Errorf("failed to read --consumer flag: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if file != "-" {
+		f, err := os.Create(file)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", file, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	writer, err := newRecordWriter(out, format)
+	if err != nil {
+		return err
+	}
+
+	svcs, closeServices, err := newDataServices(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer closeServices()
+
+	consumers, resources, err := exportRecords(context.Background(), svcs, writer, filter)
+	if err != nil {
+		return err
+	}
+
+	// the archive may be written to the standard output, so the summary goes to the standard error
+	fmt.Fprintf(os.Stderr, "Exported %d consumers and %d resource bundles\n", consumers, resources)
+	return nil
+}
+
+// exportRecords writes the consumers and then the resource bundles that match the filter, it returns the number of
+// the exported consumers and resource bundles
+func exportRecords(ctx context.Context, svcs *dataServices, writer recordWriter, filter *exportFilter) (int, int, error) {
+	consumers, err := exportPages(ctx, filter.consumerSearch(), writer, func(args *services.ListArguments) ([]*record, *api.PagingMeta, error) {
+		var items []api.Consumer
+		paging, svcErr := svcs.generic.List(ctx, "", args, &items)
+		if svcErr != nil {
+			return nil, nil, fmt.Errorf("failed to list consumers: %s", svcErr)
+		}
+		records := make([]*record, 0, len(items))
+		for i := range items {
+			records = append(records, consumerRecord(&items[i]))
+		}
+		return records, paging, nil
+	})
+	if err != nil {
+		return consumers, 0, err
+	}
+
+	resources, err := exportPages(ctx, filter.resourceSearch(), writer, func(args *services.ListArguments) ([]*record, *api.PagingMeta, error) {
+		var items []api.Resource
+		paging, svcErr := svcs.resources.ListWithArgs(ctx, "", args, &items)
+		if svcErr != nil {
+			return nil, nil, fmt.Errorf("failed to list resource bundles: %s", svcErr)
+		}
+		records := make([]*record, 0, len(items))
+		for i := range items {
+			records = append(records, resourceRecord(&items[i]))
+		}
+		return records, paging, nil
+	})
+	if err != nil {
+		return consumers, resources, err
+	}
+
+	return consumers, resources, writer.Close()
+}
+
+// exportPages writes the records of each page that the list function returns until all the records are written, the
+// records are ordered by their creation time so that the pages are stable
+func exportPages(ctx context.Context, search string, writer recordWriter,
+	list func(args *services.ListArguments) ([]*record, *api.PagingMeta, error)) (int, error) {
+	count := 0
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		records, paging, err := list(&services.ListArguments{
+			Page:    page,
+			Size:    exportPageSize,
+			Search:  search,
+			OrderBy: []string{"created_at", "id"},
+		})
+		if err != nil {
+			return count, err
+		}
+
+		for _, rec := range records {
+			if err := writer.Write(rec); err != nil {
+				return count, fmt.Errorf("failed to write %s %q: %w", rec.Kind, rec.ID, err)
+			}
+			count++
+		}
+
+		if len(records) == 0 || int64(count) >= paging.Total {
+			return count, nil
+		}
+	}
+}
+
+func consumerRecord(consumer *api.Consumer) *record {
+	rec := &record{
+		Kind:      kindConsumer,
+		ID:        consumer.ID,
+		Name:      consumer.Name,
+		CreatedAt: consumer.CreatedAt,
+	}
+	if labels := consumer.Labels.ToMap(); labels != nil {
+		rec.Labels = *labels
+	}
+	return rec
+}
+
+func resourceRecord(resource *api.Resource) *record {
+	return &record{
+		Kind:         kindResourceBundle,
+		ID:           resource.ID,
+		Name:         resource.Name,
+		CreatedAt:    resource.CreatedAt,
+		Source:       resource.Source,
+		ConsumerName: resource.ConsumerName,
+		Type:         string(resource.Type),
+		Version:      resource.Version,
+		Payload:      resource.Payload,
+	}
+}
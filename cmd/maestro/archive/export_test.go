@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+func TestExportRecords(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	labels := db.StringMap{"env": "prod"}
+	store := &fakeStore{
+		consumers: []*api.Consumer{
+			{Meta: api.Meta{ID: "consumer-1", CreatedAt: base}, Name: "cluster1", Labels: &labels},
+			{Meta: api.Meta{ID: "consumer-2", CreatedAt: base}, Name: "cluster2"},
+		},
+	}
+	// more resources than a page, so that the export reads several pages
+	total := exportPageSize + 20
+	for i := 0; i < total; i++ {
+		store.resources = append(store.resources, &api.Resource{
+			Meta:         api.Meta{ID: fmt.Sprintf("bundle-%d", i), CreatedAt: base.Add(time.Duration(i) * time.Second)},
+			Name:         fmt.Sprintf("nginx-%d", i),
+			Source:       "maestro",
+			ConsumerName: "cluster1",
+			Version:      1,
+			Payload:      testPayload(fmt.Sprintf("nginx-%d", i)),
+		})
+	}
+
+	var buf bytes.Buffer
+	writer, _ := newRecordWriter(&buf, formatNDJSON)
+	consumers, resources, err := exportRecords(context.Background(), newFakeServices(store), writer, &exportFilter{})
+	if err != nil {
+		t.Fatalf("exportRecords() error = %v", err)
+	}
+	if consumers != 2 || resources != total {
+		t.Errorf("exportRecords() = %d consumers and %d resource bundles, want 2 and %d", consumers, resources, total)
+	}
+
+	reader, _ := newRecordReader(&buf, formatNDJSON)
+	records := readAll(t, reader)
+	if len(records) != 2+total {
+		t.Fatalf("exported %d records, want %d", len(records), 2+total)
+	}
+	if records[0].Kind != kindConsumer || records[0].Labels["env"] != "prod" || records[1].Labels != nil {
+		t.Errorf("exported consumers = %+v and %+v, want cluster1 with its labels and cluster2", records[0], records[1])
+	}
+	last := records[len(records)-1]
+	if last.Kind != kindResourceBundle || last.ID != fmt.Sprintf("bundle-%d", total-1) || last.Source != "maestro" {
+		t.Errorf("last exported record = %+v, want the last resource bundle", last)
+	}
+}
+
+func TestExportFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		filter         exportFilter
+		consumerSearch string
+		resourceSearch string
+	}{
+		{
+			name: "no filter",
+		},
+		{
+			name:           "consumers",
+			filter:         exportFilter{consumers: []string{"cluster1", "cluster2"}},
+			consumerSearch: "name in ('cluster1', 'cluster2')",
+			resourceSearch: "consumer_name in ('cluster1', 'cluster2')",
+		},
+		{
+			name:           "source and search",
+			filter:         exportFilter{source: "maestro", search: "name like 'nginx%' or version = 1"},
+			resourceSearch: "source = 'maestro' and (name like 'nginx%' or version = 1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.consumerSearch(); got != tt.consumerSearch {
+				t.Errorf("consumerSearch() = %q, want %q", got, tt.consumerSearch)
+			}
+			if got := tt.filter.resourceSearch(); got != tt.resourceSearch {
+				t.Errorf("resourceSearch() = %q, want %q", got, tt.resourceSearch)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package archive
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// fakeStore keeps the consumers and resources of the fake services in memory, the services only implement the
+// methods that the export and import commands call
+type fakeStore struct {
+	consumers []*api.Consumer
+	resources []*api.Resource
+}
+
+func newFakeServices(store *fakeStore) *dataServices {
+	return &dataServices{
+		consumers: &fakeConsumerService{store: store},
+		resources: &fakeResourceService{store: store},
+		generic:   &fakeGenericService{store: store},
+	}
+}
+
+// page returns the items of the page and the paging meta of the list arguments
+func page[T any](items []T, args *services.ListArguments) ([]T, *api.PagingMeta) {
+	start := int64(args.Page-1) * args.Size
+	end := start + args.Size
+	if start > int64(len(items)) {
+		start = int64(len(items))
+	}
+	if end > int64(len(items)) {
+		end = int64(len(items))
+	}
+	return items[start:end], &api.PagingMeta{Page: args.Page, Size: end - start, Total: int64(len(items))}
+}
+
+type fakeConsumerService struct {
+	services.ConsumerService
+	store *fakeStore
+}
+
+func (s *fakeConsumerService) Get(_ context.Context, id string) (*api.Consumer, *errors.ServiceError) {
+	for _, consumer := range s.store.consumers {
+		if consumer.ID == id {
+			return consumer, nil
+		}
+	}
+	return nil, errors.NotFound("Consumer with id='%s' not found", id)
+}
+
+func (s *fakeConsumerService) FindByNames(_ context.Context, names []string) (api.ConsumerList, *errors.ServiceError) {
+	var found api.ConsumerList
+	for _, consumer := range s.store.consumers {
+		for _, name := range names {
+			if consumer.Name == name {
+				found = append(found, consumer)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (s *fakeConsumerService) Create(_ context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError) {
+	_ = consumer.BeforeCreate(nil)
+	s.store.consumers = append(s.store.consumers, consumer)
+	return consumer, nil
+}
+
+func (s *fakeConsumerService) Replace(_ context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError) {
+	return consumer, nil
+}
+
+type fakeResourceService struct {
+	services.ResourceService
+	store *fakeStore
+}
+
+func (s *fakeResourceService) Create(_ context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	_ = resource.BeforeCreate(nil)
+	s.store.resources = append(s.store.resources, resource)
+	return resource, nil
+}
+
+func (s *fakeResourceService) Update(_ context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	for _, found := range s.store.resources {
+		if found.ID != resource.ID {
+			continue
+		}
+		if found.Version != resource.Version {
+			return nil, errors.Conflict("the resource version is not the latest, the latest version: %d", found.Version)
+		}
+		if !reflect.DeepEqual(found.Payload, resource.Payload) {
+			found.Version++
+			found.Payload = resource.Payload
+		}
+		return found, nil
+	}
+	return nil, errors.NotFound("Resource with id='%s' not found", resource.ID)
+}
+
+func (s *fakeResourceService) DryRunCreate(_ context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	for _, consumer := range s.store.consumers {
+		if consumer.Name == resource.ConsumerName {
+			return resource, nil
+		}
+	}
+	return nil, errors.NotFound("Consumer with name='%s' not found", resource.ConsumerName)
+}
+
+func (s *fakeResourceService) DryRunUpdate(_ context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	return resource, nil
+}
+
+// ListWithArgs supports the name = '<name>' search of the import, the other searches list all the resources
+func (s *fakeResourceService) ListWithArgs(_ context.Context, _ string, args *services.ListArguments, resources *[]api.Resource) (*api.PagingMeta, *errors.ServiceError) {
+	var items []api.Resource
+	for _, resource := range s.store.resources {
+		if name, ok := strings.CutPrefix(args.Search, "name = "); ok && strings.Trim(name, "'") != resource.Name {
+			continue
+		}
+		items = append(items, *resource)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt.Before(items[j].CreatedAt) })
+
+	var paging *api.PagingMeta
+	*resources, paging = page(items, args)
+	return paging, nil
+}
+
+type fakeGenericService struct {
+	store *fakeStore
+}
+
+func (s *fakeGenericService) List(_ context.Context, _ string, args *services.ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
+	var items []api.Consumer
+	for _, consumer := range s.store.consumers {
+		items = append(items, *consumer)
+	}
+
+	var paging *api.PagingMeta
+	*resourceList.(*[]api.Consumer), paging = page(items, args)
+	return paging, nil
+}
@@ -0,0 +1,414 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+const (
+	// conflictSkip keeps the existing consumer or resource bundle
+	conflictSkip = "skip"
+	// conflictOverwrite replaces the labels of the existing consumer or the manifests of the existing resource bundle
+	conflictOverwrite = "overwrite"
+
+	actionCreated   = "created"
+	actionUpdated   = "updated"
+	actionUnchanged = "unchanged"
+	actionSkipped   = "skipped"
+	actionFailed    = "failed"
+)
+
+// NewImportCommand creates the import command
+func NewImportCommand() *cobra.Command {
+	dbConfig := config.NewDatabaseConfig()
+
+	cmd := &cobra.Command{
+		Use:   "import -f <file>",
+		Short: "Import the consumers and resource bundles of an archive",
+		Long: `Import the consumers and resource bundles of an archive that is written by 'maestro export'.
+
+The records are recreated through the maestro services in the order of the archive, so
+the events of the resource bundles are published to their agents and the audit records
+are written, the same as the records that are created through the API. The consumers are
+matched by their name and the resource bundles by their name.
+
+By default new IDs are generated, with --preserve-ids the IDs and versions of the archive
+are kept. --source-map replaces the source of the resource bundles, e.g. to move them to
+another source client. When a consumer or resource bundle already exists, it is skipped
+by default, with --on-conflict overwrite the labels of the consumer or the manifests of
+the resource bundle are replaced.
+
+With --dry-run, nothing is written, each record is checked with the validation of the
+services and the report shows what the import would do. A dry run checks all the records
+and fails at the end if any of them cannot be imported, an import stops at the first
+failure.
+
+The command connects to the database with the same flags as 'maestro migration'.
+
+Examples:
+  maestro import -f backup.ndjson --dry-run
+  maestro import -f backup.tar --format tar --preserve-ids
+  maestro import -f backup.ndjson --on-conflict overwrite --source-map maestro=maestro-dr
+  cat backup.ndjson | maestro import -f -`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runImport(cmd, dbConfig); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	dbConfig.AddFlags(cmd.Flags())
+	cmd.Flags().StringP("file", "f", "", "The archive file to read, - for the standard input")
+	cmd.Flags().String("format", formatNDJSON, "The archive format: ndjson or tar")
+	cmd.Flags().Bool("preserve-ids", false, "Keep the IDs and versions of the archive instead of generating new ones")
+	cmd.Flags().StringToString("source-map", nil, "Replace the source of the resource bundles, as old=new pairs (can be repeated)")
+	cmd.Flags().String("on-conflict", conflictSkip, "What to do with the existing consumers and resource bundles: skip or overwrite")
+	cmd.Flags().Bool("dry-run", false, "Check the records and print the report without importing them")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// importOptions control how the records are imported
+type importOptions struct {
+	preserveIDs bool
+	// sourceMap maps the sources of the archive to the sources of the imported resource bundles
+	sourceMap  map[string]string
+	onConflict string
+	dryRun     bool
+}
+
+// importResult is the action that the import takes, or would take in a dry run, on a record
+type importResult struct {
+	kind    string
+	name    string
+	id      string
+	action  string
+	message string
+}
+
+func runImport(cmd *cobra.Command, dbConfig *config.DatabaseConfig) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to read --file flag: %w", err)
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to read --format flag: %w", err)
+	}
+	opts := &importOptions{}
+	if opts.preserveIDs, err = cmd.Flags().GetBool("preserve-ids"); err != nil {
+		return fmt.Errorf("failed to read --preserve-ids flag: %w", err)
+	}
+	if opts.sourceMap, err = cmd.Flags().GetStringToString("source-map"); err != nil {
+		return fmt.Errorf("failed to read --source-map flag: %w", err)
+	}
+	if opts.onConflict, err = cmd.Flags().GetString("on-conflict"); err != nil {
+		return fmt.Errorf("failed to read --on-conflict flag: %w", err)
+	}
+	if opts.onConflict != conflictSkip && opts.onConflict != conflictOverwrite {
+		return fmt.Errorf("invalid --on-conflict: %s (must be %s or %s)", opts.onConflict, conflictSkip, conflictOverwrite)
+	}
+	if opts.dryRun, err = cmd.Flags().GetBool("dry-run"); err != nil {
+		return fmt.Errorf("failed to read --dry-run flag: %w", err)
+	}
+
+	var in io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", file, err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	reader, err := newRecordReader(in, format)
+	if err != nil {
+		return err
+	}
+
+	svcs, closeServices, err := newDataServices(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer closeServices()
+
+	// the report of the records before a failure is printed too, so that it shows what was imported
+	results, importErr := importRecords(context.Background(), svcs, reader, opts)
+	if err := printImportReport(os.Stdout, results, opts.dryRun); err != nil {
+		return err
+	}
+	return importErr
+}
+
+// importRecords imports the records of the reader, a dry run checks all the records, otherwise the import stops at
+// the first record that fails
+func importRecords(ctx context.Context, svcs *dataServices, reader recordReader, opts *importOptions) ([]importResult, error) {
+	imp := &importer{svcs: svcs, opts: opts, newConsumers: map[string]bool{}}
+
+	var results []importResult
+	failed := 0
+	for {
+		rec, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return results, err
+		}
+
+		var result *importResult
+		if rec.Kind == kindConsumer {
+			result, err = imp.importConsumer(ctx, rec)
+		} else {
+			result, err = imp.importResource(ctx, rec)
+		}
+		if err != nil {
+			result.action = actionFailed
+			result.message = err.Error()
+			results = append(results, *result)
+			if !opts.dryRun {
+				return results, err
+			}
+			failed++
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d records cannot be imported", failed, len(results))
+	}
+	return results, nil
+}
+
+// importer imports the records through the services
+type importer struct {
+	svcs *dataServices
+	opts *importOptions
+	// newConsumers are the names of the consumers that a dry run would create, the resource bundles of them cannot
+	// be checked by the services since the consumers do not exist, so only their name and manifests are validated
+	newConsumers map[string]bool
+}
+
+func (imp *importer) importConsumer(ctx context.Context, rec *record) (*importResult, error) {
+	result := &importResult{kind: rec.Kind, name: rec.Name, id: rec.ID}
+	labels := db.EmptyMapToNilStringMap(&rec.Labels)
+
+	found, svcErr := imp.svcs.consumers.FindByNames(ctx, []string{rec.Name})
+	if svcErr != nil {
+		return result, fmt.Errorf("failed to find consumer %q: %s", rec.Name, svcErr)
+	}
+	if len(found) > 0 {
+		existing := found[0]
+		result.id = existing.ID
+		if imp.opts.onConflict == conflictSkip {
+			result.action = actionSkipped
+			return result, nil
+		}
+		if sameLabels(existing.Labels, labels) {
+			result.action = actionUnchanged
+			return result, nil
+		}
+
+		result.action = actionUpdated
+		if imp.opts.dryRun {
+			return result, nil
+		}
+		existing.Labels = labels
+		if _, svcErr := imp.svcs.consumers.Replace(ctx, existing); svcErr != nil {
+			return result, fmt.Errorf("failed to update consumer %q: %s", rec.Name, svcErr)
+		}
+		return result, nil
+	}
+
+	consumer := &api.Consumer{Name: rec.Name, Labels: labels}
+	if imp.opts.preserveIDs {
+		// the name of a consumer cannot be updated, so a consumer with the ID and another name is a conflict
+		if existing, svcErr := imp.svcs.consumers.Get(ctx, rec.ID); svcErr == nil {
+			return result, fmt.Errorf("consumer ID %q is used by consumer %q", rec.ID, existing.Name)
+		} else if !svcErr.Is404() {
+			return result, fmt.Errorf("failed to get consumer %q: %s", rec.ID, svcErr)
+		}
+		consumer.ID = rec.ID
+	} else {
+		result.id = ""
+	}
+
+	result.action = actionCreated
+	if imp.opts.dryRun {
+		if err := services.ValidateConsumer(consumer); err != nil {
+			return result, fmt.Errorf("invalid consumer %q: %w", rec.Name, err)
+		}
+		imp.newConsumers[rec.Name] = true
+		return result, nil
+	}
+
+	created, svcErr := imp.svcs.consumers.Create(ctx, consumer)
+	if svcErr != nil {
+		return result, fmt.Errorf("failed to create consumer %q: %s", rec.Name, svcErr)
+	}
+	result.id = created.ID
+	return result, nil
+}
+
+func (imp *importer) importResource(ctx context.Context, rec *record) (*importResult, error) {
+	result := &importResult{kind: rec.Kind, name: rec.Name, id: rec.ID}
+
+	source := rec.Source
+	if mapped, ok := imp.opts.sourceMap[source]; ok {
+		source = mapped
+	}
+	resource := &api.Resource{
+		Source:       source,
+		ConsumerName: rec.ConsumerName,
+		Type:         api.ResourceType(rec.Type),
+		Name:         rec.Name,
+		Payload:      datatypes.JSONMap(rec.Payload),
+	}
+	if imp.opts.preserveIDs {
+		resource.ID = rec.ID
+		resource.Version = rec.Version
+	} else {
+		result.id = ""
+	}
+
+	existing, err := imp.findResource(ctx, rec.Name)
+	if err != nil {
+		return result, err
+	}
+	if existing != nil {
+		result.id = existing.ID
+		if imp.opts.preserveIDs && existing.ID != rec.ID {
+			return result, fmt.Errorf("resource bundle name %q is used by resource bundle %q", rec.Name, existing.ID)
+		}
+		if imp.opts.onConflict == conflictSkip {
+			result.action = actionSkipped
+			return result, nil
+		}
+		// the consumer of a resource bundle cannot be updated
+		if existing.ConsumerName != resource.ConsumerName {
+			return result, fmt.Errorf("resource bundle %q exists on consumer %q", rec.Name, existing.ConsumerName)
+		}
+		if reflect.DeepEqual(existing.Payload, resource.Payload) {
+			result.action = actionUnchanged
+			return result, nil
+		}
+
+		result.action = actionUpdated
+		update := &api.Resource{Meta: api.Meta{ID: existing.ID}, Version: existing.Version, Payload: resource.Payload}
+		updateResource := imp.svcs.resources.Update
+		if imp.opts.dryRun {
+			updateResource = imp.svcs.resources.DryRunUpdate
+		}
+		if _, svcErr := updateResource(ctx, update); svcErr != nil {
+			return result, fmt.Errorf("failed to update resource bundle %q: %s", rec.Name, svcErr)
+		}
+		return result, nil
+	}
+
+	result.action = actionCreated
+	if imp.opts.dryRun {
+		if imp.newConsumers[resource.ConsumerName] {
+			if err := validateResource(resource); err != nil {
+				return result, fmt.Errorf("invalid resource bundle %q: %w", rec.Name, err)
+			}
+		} else if _, svcErr := imp.svcs.resources.DryRunCreate(ctx, resource); svcErr != nil {
+			return result, fmt.Errorf("failed to create resource bundle %q: %s", rec.Name, svcErr)
+		}
+		return result, nil
+	}
+
+	created, svcErr := imp.svcs.resources.Create(ctx, resource)
+	if svcErr != nil {
+		return result, fmt.Errorf("failed to create resource bundle %q: %s", rec.Name, svcErr)
+	}
+	result.id = created.ID
+	return result, nil
+}
+
+// findResource returns the resource bundle with the name, nil if it is not found
+func (imp *importer) findResource(ctx context.Context, name string) (*api.Resource, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	var resources []api.Resource
+	args := &services.ListArguments{Page: 1, Size: 1, Search: fmt.Sprintf("name = '%s'", name)}
+	if _, svcErr := imp.svcs.resources.ListWithArgs(ctx, "", args, &resources); svcErr != nil {
+		return nil, fmt.Errorf("failed to find resource bundle %q: %s", name, svcErr)
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	return &resources[0], nil
+}
+
+// validateResource runs the validation of the services on a resource bundle without the database checks
+func validateResource(resource *api.Resource) error {
+	if resource.Name != "" {
+		if err := services.ValidateResourceName(resource); err != nil {
+			return err
+		}
+	}
+	return services.ValidateManifestBundle(resource.Payload)
+}
+
+// sameLabels returns true if the labels are equal, nil and empty labels are equal
+func sameLabels(a, b *db.StringMap) bool {
+	var x, y db.StringMap
+	if a != nil {
+		x = *a
+	}
+	if b != nil {
+		y = *b
+	}
+	if len(x) == 0 && len(y) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// printImportReport prints the action of each record and the number of the records of each action
+func printImportReport(w io.Writer, results []importResult, dryRun bool) error {
+	report := &output.Table{
+		Columns: []output.TableColumn{{Header: "KIND"}, {Header: "NAME"}, {Header: "ID"}, {Header: "ACTION"}, {Header: "MESSAGE"}},
+	}
+	counts := map[string]int{}
+	for _, result := range results {
+		id := result.id
+		if id == "" {
+			id = "<generated>"
+		}
+		report.Rows = append(report.Rows, []string{result.kind, result.name, id, result.action, result.message})
+		counts[result.action]++
+	}
+	if len(results) > 0 {
+		if err := report.Print(w, false, false); err != nil {
+			return err
+		}
+	}
+
+	prefix := "Imported"
+	if dryRun {
+		prefix = "Dry run"
+	}
+	_, err := fmt.Fprintf(w, "%s: %d created, %d updated, %d unchanged, %d skipped, %d failed\n", prefix,
+		counts[actionCreated], counts[actionUpdated], counts[actionUnchanged], counts[actionSkipped], counts[actionFailed])
+	return err
+}
@@ -0,0 +1,215 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/datatypes"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+// sliceReader reads the records of a slice
+type sliceReader struct {
+	records []*record
+}
+
+func (r *sliceReader) Read() (*record, error) {
+	if len(r.records) == 0 {
+		return nil, io.EOF
+	}
+	rec := r.records[0]
+	r.records = r.records[1:]
+	return rec, nil
+}
+
+func actions(results []importResult) []string {
+	var got []string
+	for _, result := range results {
+		got = append(got, result.action)
+	}
+	return got
+}
+
+func TestImportRecords(t *testing.T) {
+	store := &fakeStore{}
+	opts := &importOptions{sourceMap: map[string]string{"maestro": "maestro-dr"}, onConflict: conflictSkip}
+
+	results, err := importRecords(context.Background(), newFakeServices(store), &sliceReader{records: testRecords()}, opts)
+	if err != nil {
+		t.Fatalf("importRecords() error = %v", err)
+	}
+	if got := actions(results); !reflect.DeepEqual(got, []string{actionCreated, actionCreated}) {
+		t.Errorf("importRecords() actions = %v, want created and created", got)
+	}
+
+	if len(store.consumers) != 1 || len(store.resources) != 1 {
+		t.Fatalf("imported %d consumers and %d resources, want 1 and 1", len(store.consumers), len(store.resources))
+	}
+	consumer, resource := store.consumers[0], store.resources[0]
+	if consumer.ID == "consumer-1" || consumer.Name != "cluster1" || (*consumer.Labels)["env"] != "prod" {
+		t.Errorf("imported consumer = %+v, want cluster1 with a new ID", consumer)
+	}
+	if resource.ID == "bundle-1" || resource.Version != 1 || resource.Source != "maestro-dr" || resource.ConsumerName != "cluster1" {
+		t.Errorf("imported resource = %+v, want nginx with a new ID, version 1 and the mapped source", resource)
+	}
+	if results[1].id != resource.ID {
+		t.Errorf("importRecords() reported ID = %s, want %s", results[1].id, resource.ID)
+	}
+}
+
+func TestImportRecords_PreserveIDs(t *testing.T) {
+	store := &fakeStore{}
+	opts := &importOptions{preserveIDs: true, onConflict: conflictSkip}
+
+	if _, err := importRecords(context.Background(), newFakeServices(store), &sliceReader{records: testRecords()}, opts); err != nil {
+		t.Fatalf("importRecords() error = %v", err)
+	}
+
+	if store.consumers[0].ID != "consumer-1" {
+		t.Errorf("imported consumer ID = %s, want consumer-1", store.consumers[0].ID)
+	}
+	if resource := store.resources[0]; resource.ID != "bundle-1" || resource.Version != 2 || resource.Source != "maestro" {
+		t.Errorf("imported resource = %+v, want bundle-1 with version 2", resource)
+	}
+}
+
+func TestImportRecords_Conflicts(t *testing.T) {
+	oldLabels := db.StringMap{"env": "dev"}
+	newStore := func() *fakeStore {
+		return &fakeStore{
+			consumers: []*api.Consumer{{Meta: api.Meta{ID: "existing-consumer"}, Name: "cluster1", Labels: &oldLabels}},
+			resources: []*api.Resource{{
+				Meta:         api.Meta{ID: "existing-bundle"},
+				Name:         "nginx",
+				ConsumerName: "cluster1",
+				Version:      3,
+				Payload:      datatypes.JSONMap(testPayload("old")),
+			}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		onConflict  string
+		records     []*record
+		wantActions []string
+		wantVersion int32
+	}{
+		{
+			name:        "skip",
+			onConflict:  conflictSkip,
+			records:     testRecords(),
+			wantActions: []string{actionSkipped, actionSkipped},
+			wantVersion: 3,
+		},
+		{
+			name:        "overwrite",
+			onConflict:  conflictOverwrite,
+			records:     testRecords(),
+			wantActions: []string{actionUpdated, actionUpdated},
+			wantVersion: 4,
+		},
+		{
+			name:       "overwrite unchanged",
+			onConflict: conflictOverwrite,
+			records: func() []*record {
+				records := testRecords()
+				records[0].Labels = map[string]string{"env": "dev"}
+				records[1].Payload = testPayload("old")
+				return records
+			}(),
+			wantActions: []string{actionUnchanged, actionUnchanged},
+			wantVersion: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore()
+			opts := &importOptions{onConflict: tt.onConflict}
+
+			results, err := importRecords(context.Background(), newFakeServices(store), &sliceReader{records: tt.records}, opts)
+			if err != nil {
+				t.Fatalf("importRecords() error = %v", err)
+			}
+			if got := actions(results); !reflect.DeepEqual(got, tt.wantActions) {
+				t.Errorf("importRecords() actions = %v, want %v", got, tt.wantActions)
+			}
+			if results[1].id != "existing-bundle" {
+				t.Errorf("importRecords() reported ID = %s, want existing-bundle", results[1].id)
+			}
+			if len(store.resources) != 1 || store.resources[0].Version != tt.wantVersion {
+				t.Errorf("resources = %+v, want existing-bundle with version %d", store.resources, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestImportRecords_DryRun(t *testing.T) {
+	records := append(testRecords(), &record{
+		Kind:         kindResourceBundle,
+		ID:           "bundle-2",
+		Name:         "orphan",
+		ConsumerName: "cluster2",
+		Payload:      testPayload("orphan"),
+	}, &record{
+		Kind:         kindResourceBundle,
+		ID:           "bundle-3",
+		Name:         "Invalid_Name",
+		ConsumerName: "cluster1",
+		Payload:      testPayload("invalid"),
+	})
+
+	store := &fakeStore{}
+	opts := &importOptions{onConflict: conflictSkip, dryRun: true}
+	results, err := importRecords(context.Background(), newFakeServices(store), &sliceReader{records: records}, opts)
+	if err == nil || !strings.Contains(err.Error(), "2 of 4 records cannot be imported") {
+		t.Errorf("importRecords() error = %v, should contain %v", err, "2 of 4 records cannot be imported")
+	}
+
+	// the resource bundle of the consumer that the dry run would create is validated, the other one does not have
+	// a consumer
+	want := []string{actionCreated, actionCreated, actionFailed, actionFailed}
+	if got := actions(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("importRecords() actions = %v, want %v", got, want)
+	}
+	if !strings.Contains(results[2].message, "cluster2") {
+		t.Errorf("importRecords() message = %s, should mention the missing consumer", results[2].message)
+	}
+	if len(store.consumers) != 0 || len(store.resources) != 0 {
+		t.Errorf("dry run imported %d consumers and %d resources, want none", len(store.consumers), len(store.resources))
+	}
+
+	var buf bytes.Buffer
+	if err := printImportReport(&buf, results, true); err != nil {
+		t.Fatalf("printImportReport() error = %v", err)
+	}
+	for _, want := range []string{"ACTION", "<generated>", "Dry run: 2 created, 0 updated, 0 unchanged, 0 skipped, 2 failed"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("printImportReport() output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestImportRecords_StopOnFailure(t *testing.T) {
+	// the consumer ID of the archive is used by another consumer, so the import stops before the resource bundle
+	store := &fakeStore{consumers: []*api.Consumer{{Meta: api.Meta{ID: "consumer-1"}, Name: "cluster3"}}}
+	opts := &importOptions{preserveIDs: true, onConflict: conflictSkip}
+
+	results, err := importRecords(context.Background(), newFakeServices(store), &sliceReader{records: testRecords()}, opts)
+	if err == nil || !strings.Contains(err.Error(), `consumer ID "consumer-1" is used by consumer "cluster3"`) {
+		t.Errorf("importRecords() error = %v, should report the ID conflict", err)
+	}
+	if got := actions(results); !reflect.DeepEqual(got, []string{actionFailed}) {
+		t.Errorf("importRecords() actions = %v, want only the failed record", got)
+	}
+	if len(store.resources) != 0 {
+		t.Errorf("imported %d resources, want none", len(store.resources))
+	}
+}
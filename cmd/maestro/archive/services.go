@@ -0,0 +1,45 @@
+package archive
+
+import (
+	"fmt"
+
+	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/db/db_session"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// dataServices are the services that the export and import commands read and write the consumers and resource
+// bundles with, the import goes through them so that it records the events and audit records of the server
+type dataServices struct {
+	consumers services.ConsumerService
+	resources services.ResourceService
+	generic   services.GenericService
+}
+
+// newDataServices connects to the database of the config, the returned function closes the connection
+func newDataServices(dbConfig *config.DatabaseConfig) (*dataServices, func(), error) {
+	if err := dbConfig.ReadFiles(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read the database config: %w", err)
+	}
+
+	var sessionFactory db.SessionFactory = db_session.NewProdFactory(dbConfig)
+
+	consumerDao := dao.NewConsumerDao(&sessionFactory)
+	generic := services.NewGenericService(dao.NewGenericDao(&sessionFactory))
+	auditRecords := services.NewAuditRecordService(dao.NewAuditRecordDao(&sessionFactory), nil)
+	svcs := &dataServices{
+		consumers: services.NewConsumerService(consumerDao, auditRecords),
+		resources: services.NewResourceService(
+			db.NewAdvisoryLockFactory(sessionFactory),
+			dao.NewResourceDao(&sessionFactory),
+			consumerDao,
+			services.NewEventService(dao.NewEventDao(&sessionFactory)),
+			generic,
+			auditRecords,
+		),
+		generic: generic,
+	}
+	return svcs, func() { _ = sessionFactory.Close() }, nil
+}
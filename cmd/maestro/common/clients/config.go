@@ -65,6 +65,7 @@ func AddRESTClientFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String(FlagRESTURL, "https://127.0.0.1:30080", "Maestro REST API base URL (env: MAESTRO_REST_URL)")
 	cmd.PersistentFlags().Bool(FlagInsecureSkipVerify, false, "Skip TLS certificate verification for REST API (env: MAESTRO_REST_INSECURE_SKIP_VERIFY)")
 	cmd.PersistentFlags().Duration(FlagTimeout, 30*time.Second, "HTTP client timeout for REST API (env: MAESTRO_REST_TIMEOUT)")
+	addContextFlag(cmd)
 }
 
 // AddGRPCClientFlags adds gRPC client flags to a command
@@ -75,6 +76,7 @@ func AddGRPCClientFlags(cmd *cobra.Command, defaultSourceID string) {
 	cmd.PersistentFlags().String(FlagGRPCTokenFile, "", "Path to token file for gRPC authentication (env: MAESTRO_GRPC_TOKEN_FILE)")
 	cmd.PersistentFlags().String(FlagGRPCClientCert, "", "Path to client certificate file for mutual TLS (env: MAESTRO_GRPC_CLIENT_CERT_FILE)")
 	cmd.PersistentFlags().String(FlagGRPCClientKey, "", "Path to client private key file for mutual TLS (env: MAESTRO_GRPC_CLIENT_KEY_FILE)")
+	addContextFlag(cmd)
 }
 
 // AddClientFlags adds both REST and gRPC client flags to a command
@@ -83,8 +85,14 @@ func AddClientFlags(cmd *cobra.Command, defaultSourceID string) {
 	AddGRPCClientFlags(cmd, defaultSourceID)
 }
 
-// LoadRESTConfigFromFlags loads REST client configuration from command flags with environment variable fallback
+// LoadRESTConfigFromFlags loads REST client configuration from command flags with environment variable and CLI
+// config context fallback
 func LoadRESTConfigFromFlags(cmd *cobra.Command) (*RESTConfig, error) {
+	namedContext, err := loadContextFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	restURL, err := cmd.Flags().GetString(FlagRESTURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read --%s: %w", FlagRESTURL, err)
@@ -92,6 +100,8 @@ func LoadRESTConfigFromFlags(cmd *cobra.Command) (*RESTConfig, error) {
 	if !cmd.Flags().Changed(FlagRESTURL) {
 		if v := os.Getenv(EnvRESTURL); v != "" {
 			restURL = v
+		} else if namedContext.RESTURL != "" {
+			restURL = namedContext.RESTURL
 		}
 	}
 
@@ -110,6 +120,8 @@ func LoadRESTConfigFromFlags(cmd *cobra.Command) (*RESTConfig, error) {
 				return nil, fmt.Errorf("invalid %s: %w", EnvInsecureSkipVerify, err)
 			}
 			insecureSkipVerify = parsed
+		} else if namedContext.InsecureSkipVerify != nil {
+			insecureSkipVerify = *namedContext.InsecureSkipVerify
 		}
 	}
 
@@ -124,6 +136,12 @@ func LoadRESTConfigFromFlags(cmd *cobra.Command) (*RESTConfig, error) {
 				return nil, fmt.Errorf("invalid %s: %w", EnvTimeout, err)
 			}
 			timeout = parsed
+		} else if namedContext.Timeout != "" {
+			parsed, err := time.ParseDuration(namedContext.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timeout of context %q: %w", namedContext.Name, err)
+			}
+			timeout = parsed
 		}
 	}
 
@@ -138,8 +156,14 @@ func LoadRESTConfigFromFlags(cmd *cobra.Command) (*RESTConfig, error) {
 	}, nil
 }
 
-// LoadGRPCConfigFromFlags loads gRPC client configuration from command flags with environment variable fallback
+// LoadGRPCConfigFromFlags loads gRPC client configuration from command flags with environment variable and CLI
+// config context fallback
 func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
+	namedContext, err := loadContextFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	grpcServerAddress, err := cmd.Flags().GetString(FlagGRPCServerAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read --%s: %w", FlagGRPCServerAddress, err)
@@ -147,6 +171,8 @@ func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
 	if !cmd.Flags().Changed(FlagGRPCServerAddress) {
 		if v := os.Getenv(EnvGRPCServerAddress); v != "" {
 			grpcServerAddress = v
+		} else if namedContext.GRPCServerAddress != "" {
+			grpcServerAddress = namedContext.GRPCServerAddress
 		}
 	}
 
@@ -161,6 +187,8 @@ func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
 	if !cmd.Flags().Changed(FlagGRPCSourceID) {
 		if v := os.Getenv(EnvGRPCSourceID); v != "" {
 			grpcSourceID = v
+		} else if namedContext.GRPCSourceID != "" {
+			grpcSourceID = namedContext.GRPCSourceID
 		}
 	}
 
@@ -175,6 +203,8 @@ func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
 	if !cmd.Flags().Changed(FlagGRPCCAFile) {
 		if v := os.Getenv(EnvGRPCCAFile); v != "" {
 			grpcCAFile = v
+		} else if namedContext.GRPCCAFile != "" {
+			grpcCAFile = namedContext.GRPCCAFile
 		}
 	}
 
@@ -185,6 +215,8 @@ func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
 	if !cmd.Flags().Changed(FlagGRPCTokenFile) {
 		if v := os.Getenv(EnvGRPCTokenFile); v != "" {
 			grpcTokenFile = v
+		} else if namedContext.GRPCTokenFile != "" {
+			grpcTokenFile = namedContext.GRPCTokenFile
 		}
 	}
 
@@ -195,6 +227,8 @@ func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
 	if !cmd.Flags().Changed(FlagGRPCClientCert) {
 		if v := os.Getenv(EnvGRPCClientCert); v != "" {
 			grpcClientCert = v
+		} else if namedContext.GRPCClientCert != "" {
+			grpcClientCert = namedContext.GRPCClientCert
 		}
 	}
 
@@ -205,6 +239,8 @@ func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
 	if !cmd.Flags().Changed(FlagGRPCClientKey) {
 		if v := os.Getenv(EnvGRPCClientKey); v != "" {
 			grpcClientKey = v
+		} else if namedContext.GRPCClientKey != "" {
+			grpcClientKey = namedContext.GRPCClientKey
 		}
 	}
 
@@ -218,7 +254,8 @@ func LoadGRPCConfigFromFlags(cmd *cobra.Command) (*GRPCConfig, error) {
 	}, nil
 }
 
-// LoadConfigFromFlags loads both REST and gRPC client configuration from command flags with environment variable fallback
+// LoadConfigFromFlags loads both REST and gRPC client configuration from command flags with environment variable and
+// CLI config context fallback
 func LoadConfigFromFlags(cmd *cobra.Command) (*Config, error) {
 	// Try to load REST configuration
 	restConfig, err := LoadRESTConfigFromFlags(cmd)
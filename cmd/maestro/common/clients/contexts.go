@@ -0,0 +1,164 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// FlagContext is the flag name of the context to use from the CLI config file
+	FlagContext = "context"
+
+	// EnvContext is the environment variable name of the context to use from the CLI config file
+	EnvContext = "MAESTRO_CONTEXT"
+	// EnvConfigFile is the environment variable name of the CLI config file path
+	EnvConfigFile = "MAESTRO_CONFIG"
+)
+
+// CLIConfig is the CLI config file, it has the named contexts of the maestro environments
+type CLIConfig struct {
+	CurrentContext string    `json:"current-context,omitempty"`
+	Contexts       []Context `json:"contexts,omitempty"`
+}
+
+// Context holds the endpoints and the credentials of a maestro environment, the empty fields are not set by the
+// context
+type Context struct {
+	Name               string `json:"name"`
+	RESTURL            string `json:"rest-url,omitempty"`
+	InsecureSkipVerify *bool  `json:"insecure-skip-verify,omitempty"`
+	Timeout            string `json:"timeout,omitempty"`
+	GRPCServerAddress  string `json:"grpc-server-address,omitempty"`
+	GRPCCAFile         string `json:"grpc-ca-file,omitempty"`
+	GRPCTokenFile      string `json:"grpc-token-file,omitempty"`
+	GRPCClientCert     string `json:"grpc-client-cert-file,omitempty"`
+	GRPCClientKey      string `json:"grpc-client-key-file,omitempty"`
+	GRPCSourceID       string `json:"grpc-source-id,omitempty"`
+}
+
+// GetContext returns the context with the given name, nil if it is not found
+func (c *CLIConfig) GetContext(name string) *Context {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i]
+		}
+	}
+	return nil
+}
+
+// SetContext adds the context, or replaces the context with the same name
+func (c *CLIConfig) SetContext(namedContext Context) {
+	if existing := c.GetContext(namedContext.Name); existing != nil {
+		*existing = namedContext
+		return
+	}
+	c.Contexts = append(c.Contexts, namedContext)
+}
+
+// ConfigFilePath returns the path of the CLI config file, it is $MAESTRO_CONFIG if set, otherwise
+// $XDG_CONFIG_HOME/maestro/config.yaml, which defaults to ~/.config/maestro/config.yaml
+func ConfigFilePath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find the home directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "maestro", "config.yaml"), nil
+}
+
+// LoadCLIConfig loads the CLI config file, an empty config is returned if the file does not exist
+func LoadCLIConfig() (*CLIConfig, error) {
+	path, err := ConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &CLIConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	config := &CLIConfig{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// SaveCLIConfig saves the CLI config file, it is only readable by the user
+func SaveCLIConfig(config *CLIConfig) error {
+	path, err := ConfigFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// addContextFlag adds the context flag to a command if it is not added yet
+func addContextFlag(cmd *cobra.Command) {
+	if cmd.PersistentFlags().Lookup(FlagContext) != nil {
+		return
+	}
+	cmd.PersistentFlags().String(FlagContext, "", "The context to use from the CLI config file (env: MAESTRO_CONTEXT)")
+}
+
+// loadContextFromFlags returns the context selected by the context flag, the MAESTRO_CONTEXT env var or the current
+// context of the CLI config file, in that order. It returns an empty context if no context is selected.
+func loadContextFromFlags(cmd *cobra.Command) (*Context, error) {
+	name := ""
+	if cmd.Flags().Lookup(FlagContext) != nil {
+		flagName, err := cmd.Flags().GetString(FlagContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --%s: %w", FlagContext, err)
+		}
+		name = flagName
+	}
+	if name == "" {
+		name = os.Getenv(EnvContext)
+	}
+
+	config, err := LoadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		if config.CurrentContext == "" {
+			return &Context{}, nil
+		}
+		name = config.CurrentContext
+	}
+
+	namedContext := config.GetContext(name)
+	if namedContext == nil {
+		return nil, fmt.Errorf("context %q is not found in the CLI config file", name)
+	}
+	return namedContext, nil
+}
@@ -0,0 +1,164 @@
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const testCLIConfig = `current-context: dev
+contexts:
+- name: dev
+  rest-url: https://dev.example.com
+  insecure-skip-verify: true
+  grpc-server-address: dev.example.com:8090
+  grpc-source-id: dev-source
+- name: prod
+  rest-url: https://prod.example.com
+  timeout: 1m
+  grpc-server-address: prod.example.com:443
+  grpc-ca-file: /etc/maestro/ca.crt
+  grpc-token-file: /etc/maestro/token
+  grpc-source-id: prod-source
+`
+
+func TestLoadConfigFromContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		env         map[string]string
+		wantErr     bool
+		errContains string
+		validate    func(*testing.T, *Config)
+	}{
+		{
+			name: "current context",
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.RESTConfig.BaseURL != "https://dev.example.com" || !cfg.RESTConfig.InsecureSkipVerify {
+					t.Errorf("RESTConfig = %+v, want the dev context", cfg.RESTConfig)
+				}
+				if cfg.GRPCConfig.ServerAddress != "dev.example.com:8090" || cfg.GRPCConfig.SourceID != "dev-source" {
+					t.Errorf("GRPCConfig = %+v, want the dev context", cfg.GRPCConfig)
+				}
+			},
+		},
+		{
+			name: "context flag",
+			args: []string{"--context", "prod"},
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.RESTConfig.BaseURL != "https://prod.example.com" || cfg.RESTConfig.Timeout != time.Minute {
+					t.Errorf("RESTConfig = %+v, want the prod context", cfg.RESTConfig)
+				}
+				if cfg.GRPCConfig.CAFile != "/etc/maestro/ca.crt" || cfg.GRPCConfig.TokenFile != "/etc/maestro/token" {
+					t.Errorf("GRPCConfig = %+v, want the prod context", cfg.GRPCConfig)
+				}
+			},
+		},
+		{
+			name: "context env var",
+			env:  map[string]string{EnvContext: "prod"},
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.GRPCConfig.SourceID != "prod-source" {
+					t.Errorf("SourceID = %v, want prod-source", cfg.GRPCConfig.SourceID)
+				}
+			},
+		},
+		{
+			name: "flags and env vars override the context",
+			args: []string{"--rest-url", "https://flag.example.com"},
+			env:  map[string]string{EnvGRPCSourceID: "env-source", EnvInsecureSkipVerify: "false"},
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.RESTConfig.BaseURL != "https://flag.example.com" {
+					t.Errorf("BaseURL = %v, want the flag value", cfg.RESTConfig.BaseURL)
+				}
+				if cfg.RESTConfig.InsecureSkipVerify {
+					t.Error("InsecureSkipVerify = true, want the env var value false")
+				}
+				if cfg.GRPCConfig.SourceID != "env-source" {
+					t.Errorf("SourceID = %v, want the env var value", cfg.GRPCConfig.SourceID)
+				}
+				if cfg.GRPCConfig.ServerAddress != "dev.example.com:8090" {
+					t.Errorf("ServerAddress = %v, want the context value", cfg.GRPCConfig.ServerAddress)
+				}
+			},
+		},
+		{
+			name:        "unknown context",
+			args:        []string{"--context", "stage"},
+			wantErr:     true,
+			errContains: `context "stage" is not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte(testCLIConfig), 0600); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			t.Setenv(EnvConfigFile, configFile)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cmd := &cobra.Command{}
+			AddClientFlags(cmd, "default-source")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+
+			cfg, err := LoadConfigFromFlags(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfigFromFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("LoadConfigFromFlags() error = %v, should contain %v", err, tt.errContains)
+				}
+				return
+			}
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func TestSaveCLIConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "maestro", "config.yaml")
+	t.Setenv(EnvConfigFile, configFile)
+
+	// no config file
+	config, err := LoadCLIConfig()
+	if err != nil {
+		t.Fatalf("LoadCLIConfig() error = %v", err)
+	}
+	if config.CurrentContext != "" || len(config.Contexts) != 0 {
+		t.Errorf("LoadCLIConfig() = %+v, want an empty config", config)
+	}
+
+	config.SetContext(Context{Name: "dev", RESTURL: "https://dev.example.com"})
+	config.SetContext(Context{Name: "dev", RESTURL: "https://dev2.example.com"})
+	config.CurrentContext = "dev"
+	if err := SaveCLIConfig(config); err != nil {
+		t.Fatalf("SaveCLIConfig() error = %v", err)
+	}
+
+	info, err := os.Stat(configFile)
+	if err != nil {
+		t.Fatalf("Failed to stat config file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("config file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	loaded, err := LoadCLIConfig()
+	if err != nil {
+		t.Fatalf("LoadCLIConfig() error = %v", err)
+	}
+	if loaded.CurrentContext != "dev" || len(loaded.Contexts) != 1 || loaded.Contexts[0].RESTURL != "https://dev2.example.com" {
+		t.Errorf("LoadCLIConfig() = %+v, want the saved config", loaded)
+	}
+}
@@ -6,24 +6,33 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/credentials/oauth"
+	"google.golang.org/grpc/metadata"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
+	workv1 "open-cluster-management.io/api/work/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/common"
 	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
 	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 
 	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/constants"
 )
 
 // GRPCClient handles gRPC CloudEvents communication
@@ -171,7 +180,7 @@ func NewGRPCClient(cfg *Config) (*GRPCClient, error) {
 }
 
 // publish a CloudEvent to the gRPC server
-func (c *GRPCClient) publish(ctx context.Context, evt *cloudevents.Event) error {
+func (c *GRPCClient) publish(ctx context.Context, evt *cloudevents.Event, opts ...grpc.CallOption) error {
 	// Convert CloudEvent to protobuf format
 	pbEvt := &pbv1.CloudEvent{}
 	if err := grpcprotocol.WritePBMessage(ctx, binding.ToMessage(evt), pbEvt); err != nil {
@@ -179,7 +188,7 @@ func (c *GRPCClient) publish(ctx context.Context, evt *cloudevents.Event) error
 	}
 
 	// Publish the event
-	_, err := c.client.Publish(ctx, &pbv1.PublishRequest{Event: pbEvt})
+	_, err := c.client.Publish(ctx, &pbv1.PublishRequest{Event: pbEvt}, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to publish CloudEvent: %w", err)
 	}
@@ -198,21 +207,61 @@ func (c *GRPCClient) Close() error {
 
 // Apply creates or updates a resource bundle via CloudEvent
 func (c *GRPCClient) Apply(ctx context.Context, bundle *openapi.ResourceBundle, action cetypes.EventAction) error {
+	evt, err := c.newApplyEvent(bundle, action)
+	if err != nil {
+		return err
+	}
+
+	// Publish the CloudEvent
+	if err := c.publish(ctx, evt); err != nil {
+		return fmt.Errorf("failed to publish CloudEvent: %w", err)
+	}
+
+	return nil
+}
+
+// DryRunApply checks the creation or update of a resource bundle on the server without persisting it,
+// and returns the would-be resource bundle.
+func (c *GRPCClient) DryRunApply(ctx context.Context, bundle *openapi.ResourceBundle, action cetypes.EventAction) (*openapi.ResourceBundle, error) {
+	evt, err := c.newApplyEvent(bundle, action)
+	if err != nil {
+		return nil, err
+	}
+	evt.SetExtension(constants.ExtensionDryRun, true)
+
+	var header metadata.MD
+	if err := c.publish(ctx, evt, grpc.Header(&header)); err != nil {
+		return nil, fmt.Errorf("failed to publish CloudEvent: %w", err)
+	}
+
+	results := header.Get(constants.DryRunResultMetadataKey)
+	if len(results) == 0 {
+		return nil, fmt.Errorf("the server does not return the dry-run result, it may not support the dry-run")
+	}
+	result := &openapi.ResourceBundle{}
+	if err := json.Unmarshal([]byte(results[0]), result); err != nil {
+		return nil, fmt.Errorf("failed to parse the dry-run result: %w", err)
+	}
+	return result, nil
+}
+
+// newApplyEvent builds the CloudEvent that creates or updates the resource bundle
+func (c *GRPCClient) newApplyEvent(bundle *openapi.ResourceBundle, action cetypes.EventAction) (*cloudevents.Event, error) {
 	// Validate required fields
 	if bundle == nil {
-		return fmt.Errorf("resource bundle is required")
+		return nil, fmt.Errorf("resource bundle is required")
 	}
 	if bundle.Id == nil || *bundle.Id == "" {
-		return fmt.Errorf("resource bundle ID is required")
+		return nil, fmt.Errorf("resource bundle ID is required")
 	}
 	if bundle.Version == nil {
-		return fmt.Errorf("resource bundle version is required")
+		return nil, fmt.Errorf("resource bundle version is required")
 	}
 	if bundle.ConsumerName == nil || *bundle.ConsumerName == "" {
-		return fmt.Errorf("consumer name is required")
+		return nil, fmt.Errorf("consumer name is required")
 	}
 	if len(bundle.Manifests) == 0 {
-		return fmt.Errorf("manifest must specify at least one item in 'manifests'")
+		return nil, fmt.Errorf("manifest must specify at least one item in 'manifests'")
 	}
 
 	resourceID := *bundle.Id
@@ -234,7 +283,7 @@ func (c *GRPCClient) Apply(ctx context.Context, bundle *openapi.ResourceBundle,
 	case cetypes.CreateRequestAction, cetypes.UpdateRequestAction:
 		// supported
 	default:
-		return fmt.Errorf("unsupported action for Apply: %s", action)
+		return nil, fmt.Errorf("unsupported action for Apply: %s", action)
 	}
 
 	// Create CloudEvent
@@ -260,22 +309,17 @@ func (c *GRPCClient) Apply(ctx context.Context, bundle *openapi.ResourceBundle,
 	if bundle.Metadata != nil {
 		metadataBytes, err := json.Marshal(bundle.Metadata)
 		if err != nil {
-			return fmt.Errorf("failed to marshal metadata: %w", err)
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 		}
 		evt.SetExtension(cetypes.ExtensionWorkMeta, string(metadataBytes))
 	}
 
 	// Set data
 	if err := evt.SetData(cloudevents.ApplicationJSON, data); err != nil {
-		return fmt.Errorf("failed to set CloudEvent data: %w", err)
-	}
-
-	// Publish the CloudEvent
-	if err := c.publish(ctx, &evt); err != nil {
-		return fmt.Errorf("failed to publish CloudEvent: %w", err)
+		return nil, fmt.Errorf("failed to set CloudEvent data: %w", err)
 	}
 
-	return nil
+	return &evt, nil
 }
 
 // Delete deletes a resource bundle via CloudEvent
@@ -315,3 +359,110 @@ func (c *GRPCClient) Delete(ctx context.Context, resourceID, consumerName string
 
 	return nil
 }
+
+// ResourceBundleStatusEvent is a status change of a resource bundle received from the status subscription
+type ResourceBundleStatusEvent struct {
+	Time            time.Time                  `json:"time"`
+	ID              string                     `json:"id"`
+	ConsumerName    string                     `json:"consumer_name,omitempty"`
+	ObservedVersion int64                      `json:"observed_version"`
+	Deleted         bool                       `json:"deleted"`
+	Conditions      []metav1.Condition         `json:"conditions,omitempty"`
+	Manifests       []workv1.ManifestCondition `json:"manifests,omitempty"`
+}
+
+// WatchOptions selects the resource bundles whose status changes are watched, the server filters the status
+// changes by them.
+type WatchOptions struct {
+	// ConsumerName selects the resource bundles of the consumer
+	ConsumerName string
+	// LabelSelector selects the resource bundles whose labels match it, e.g. "app=nginx,tier!=db"
+	LabelSelector string
+}
+
+// WatchStatus subscribes to the status changes of the resource bundles of the source that match the options and
+// calls the handler for each of them, until the context is done or the subscription fails.
+func (c *GRPCClient) WatchStatus(ctx context.Context, opts WatchOptions, handler func(*ResourceBundleStatusEvent) error) error {
+	if opts.LabelSelector != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, constants.LabelSelectorMetadataKey, opts.LabelSelector)
+	}
+	stream, err := c.client.Subscribe(ctx, &pbv1.SubscriptionRequest{
+		Source:      c.sourceID,
+		ClusterName: opts.ConsumerName,
+		DataType:    workpayload.ManifestBundleEventDataType.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to the resource bundle status: %w", err)
+	}
+
+	for {
+		pbEvt, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil || err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to receive the resource bundle status: %w", err)
+		}
+
+		evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pbEvt))
+		if err != nil {
+			return fmt.Errorf("failed to convert protobuf to CloudEvent: %w", err)
+		}
+
+		// the server sends heartbeats to keep the subscription alive
+		if evt.Type() == cetypes.HeartbeatCloudEventsType {
+			continue
+		}
+
+		statusEvent, err := toResourceBundleStatusEvent(evt)
+		if err != nil {
+			klog.V(4).Infof("Skipping CloudEvent %s: %v", evt.ID(), err)
+			continue
+		}
+
+		if err := handler(statusEvent); err != nil {
+			return err
+		}
+	}
+}
+
+// toResourceBundleStatusEvent converts a status CloudEvent to a resource bundle status event
+func toResourceBundleStatusEvent(evt *cloudevents.Event) (*ResourceBundleStatusEvent, error) {
+	eventType, err := cetypes.ParseCloudEventsType(evt.Type())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CloudEvent type: %w", err)
+	}
+	if eventType.CloudEventsDataType != workpayload.ManifestBundleEventDataType {
+		return nil, fmt.Errorf("unsupported CloudEvent data type %s", eventType.CloudEventsDataType)
+	}
+
+	extensions := evt.Extensions()
+	resourceID, err := cloudeventstypes.ToString(extensions[cetypes.ExtensionResourceID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource ID: %w", err)
+	}
+
+	statusEvent := &ResourceBundleStatusEvent{
+		Time: evt.Time(),
+		ID:   resourceID,
+	}
+	if statusEvent.Time.IsZero() {
+		statusEvent.Time = time.Now()
+	}
+	if consumerName, err := cloudeventstypes.ToString(extensions[cetypes.ExtensionClusterName]); err == nil {
+		statusEvent.ConsumerName = consumerName
+	}
+	if version, err := cloudeventstypes.ToInteger(extensions[cetypes.ExtensionResourceVersion]); err == nil {
+		statusEvent.ObservedVersion = int64(version)
+	}
+
+	manifestBundleStatus := &workpayload.ManifestBundleStatus{}
+	if err := evt.DataAs(manifestBundleStatus); err != nil {
+		return nil, fmt.Errorf("failed to decode the resource bundle status: %w", err)
+	}
+	statusEvent.Conditions = manifestBundleStatus.Conditions
+	statusEvent.Manifests = manifestBundleStatus.ResourceStatus
+	statusEvent.Deleted = meta.IsStatusConditionTrue(manifestBundleStatus.Conditions, common.ResourceDeleted)
+
+	return statusEvent, nil
+}
@@ -2,15 +2,19 @@ package mock
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+
+	"github.com/openshift-online/maestro/pkg/constants"
 )
 
 // GRPCServer is a mock gRPC CloudEvent server for testing
@@ -19,9 +23,17 @@ type GRPCServer struct {
 	server          *grpc.Server
 	listener        net.Listener
 	publishedEvents []*pbv1.CloudEvent
+	statusEvents    []*pbv1.CloudEvent
 	mu              sync.RWMutex
 	shouldFail      bool
 	failureCode     codes.Code
+	subscription    Subscription
+}
+
+// Subscription is the filter of the last status subscription
+type Subscription struct {
+	ClusterName   string
+	LabelSelector string
 }
 
 // NewGRPCServer creates a new mock gRPC server
@@ -69,18 +81,64 @@ func (s *GRPCServer) Publish(ctx context.Context, req *pbv1.PublishRequest) (*em
 		return nil, status.Errorf(s.failureCode, "mock publish failure")
 	}
 
+	// a dry-run request is not recorded, the would-be resource bundle is returned in the header
+	if dryRun := req.Event.Attributes["ce-dryrun"]; dryRun.GetCeBoolean() {
+		result, err := json.Marshal(map[string]interface{}{
+			"id":            req.Event.Attributes["ce-resourceid"].GetCeString(),
+			"consumer_name": req.Event.Attributes["ce-clustername"].GetCeString(),
+			"version":       req.Event.Attributes["ce-resourceversion"].GetCeInteger() + 1,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal dry-run result: %v", err)
+		}
+		if err := grpc.SetHeader(ctx, metadata.Pairs(constants.DryRunResultMetadataKey, string(result))); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+
 	s.publishedEvents = append(s.publishedEvents, req.Event)
 	return &emptypb.Empty{}, nil
 }
 
 // Subscribe implements the CloudEventService Subscribe RPC
 func (s *GRPCServer) Subscribe(req *pbv1.SubscriptionRequest, stream pbv1.CloudEventService_SubscribeServer) error {
-	// For testing, we don't need to implement actual subscription
-	// Just keep the stream open until cancelled
+	// For testing, send the configured status events, then keep the stream open until cancelled
+	s.mu.Lock()
+	s.subscription = Subscription{ClusterName: req.ClusterName}
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		if values := md.Get(constants.LabelSelectorMetadataKey); len(values) > 0 {
+			s.subscription.LabelSelector = values[0]
+		}
+	}
+	statusEvents := make([]*pbv1.CloudEvent, len(s.statusEvents))
+	copy(statusEvents, s.statusEvents)
+	s.mu.Unlock()
+
+	for _, evt := range statusEvents {
+		if err := stream.Send(evt); err != nil {
+			return err
+		}
+	}
+
 	<-stream.Context().Done()
 	return nil
 }
 
+// AddStatusEvent adds a status event that is sent to the subscribers
+func (s *GRPCServer) AddStatusEvent(evt *pbv1.CloudEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusEvents = append(s.statusEvents, evt)
+}
+
+// GetSubscription returns the filter of the last status subscription
+func (s *GRPCServer) GetSubscription() Subscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.subscription
+}
+
 // GetPublishedEvents returns all published events
 func (s *GRPCServer) GetPublishedEvents() []*pbv1.CloudEvent {
 	s.mu.RLock()
@@ -3,12 +3,16 @@ package clients
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 )
 
+// ErrResourceBundleNotFound is returned when the resource bundle does not exist
+var ErrResourceBundleNotFound = errors.New("resource bundle not found")
+
 // RESTClient wraps the Maestro OpenAPI client
 type RESTClient struct {
 	client *openapi.APIClient
@@ -75,7 +79,7 @@ func (c *RESTClient) ListResourceBundles(ctx context.Context, page, size int, se
 		}
 		return result, nil
 	case http.StatusNotFound:
-		return nil, fmt.Errorf("resource bundle not found")
+		return nil, ErrResourceBundleNotFound
 	case http.StatusUnauthorized:
 		return nil, fmt.Errorf("authentication failed")
 	case http.StatusForbidden:
@@ -101,7 +105,7 @@ func (c *RESTClient) GetResourceBundle(ctx context.Context, id string) (*openapi
 		}
 		return result, nil
 	case http.StatusNotFound:
-		return nil, fmt.Errorf("resource bundle not found")
+		return nil, ErrResourceBundleNotFound
 	case http.StatusUnauthorized:
 		return nil, fmt.Errorf("authentication failed")
 	case http.StatusForbidden:
@@ -124,7 +128,7 @@ func (c *RESTClient) DeleteResourceBundle(ctx context.Context, id string) error
 	case http.StatusNoContent:
 		return nil
 	case http.StatusNotFound:
-		return fmt.Errorf("resource bundle not found")
+		return ErrResourceBundleNotFound
 	case http.StatusUnauthorized:
 		return fmt.Errorf("authentication failed")
 	case http.StatusForbidden:
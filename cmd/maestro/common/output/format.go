@@ -4,26 +4,48 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 )
 
 const (
-	FlagOutput = "output"
+	FlagOutput    = "output"
+	FlagNoHeaders = "no-headers"
+	FlagSortBy    = "sort-by"
 )
 
 // Format represents the output format
 type Format string
 
-// TODO support yaml format
 const (
-	FormatJSON  Format = "json"
-	FormatTable Format = "table"
+	FormatJSON          Format = "json"
+	FormatTable         Format = "table"
+	FormatYAML          Format = "yaml"
+	FormatWide          Format = "wide"
+	FormatName          Format = "name"
+	FormatJSONPath      Format = "jsonpath"
+	FormatCustomColumns Format = "custom-columns"
 )
 
-// AddFormatFlag adds the --output flag to a command
+// Options holds the output options of a command
+type Options struct {
+	Format Format
+	// Template is the template of the jsonpath format or the column spec of the custom-columns format
+	Template string
+	// NoHeaders omits the headers of the table, wide and custom-columns formats
+	NoHeaders bool
+	// SortBy is the JSONPath expression that the items of a list are sorted by
+	SortBy string
+}
+
+// AddFormatFlag adds the --output, --no-headers and --sort-by flags to a command
 func AddFormatFlag(cmd *cobra.Command) {
-	cmd.Flags().StringP(FlagOutput, "o", "table", "Output format: json or table")
+	cmd.Flags().StringP(FlagOutput, "o", "table",
+		"Output format: table, wide, json, yaml, name, jsonpath=<template> or custom-columns=<HEADER>:<path>[,<HEADER>:<path>...]")
+	cmd.Flags().Bool(FlagNoHeaders, false, "Omit the headers in the table, wide and custom-columns output formats")
+	cmd.Flags().String(FlagSortBy, "", "Sort the listed items by a JSONPath expression (e.g., '{.name}' or '.created_at')")
 }
 
 // GetFormat parses the output format from command flags
@@ -33,14 +55,54 @@ func GetFormat(cmd *cobra.Command) (Format, error) {
 		return "", err
 	}
 
-	switch formatStr {
-	case "json":
-		return FormatJSON, nil
-	case "table":
-		return FormatTable, nil
-	default:
-		return "", fmt.Errorf("invalid output format: %s (must be json or table)", formatStr)
+	format, _, err := parseFormat(formatStr)
+	return format, err
+}
+
+// GetOptions parses the output options from command flags
+func GetOptions(cmd *cobra.Command) (*Options, error) {
+	formatStr, err := cmd.Flags().GetString(FlagOutput)
+	if err != nil {
+		return nil, err
+	}
+	format, template, err := parseFormat(formatStr)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &Options{Format: format, Template: template}
+
+	// the scripting flags are optional so that the commands with their own output can use the output flag alone
+	if cmd.Flags().Lookup(FlagNoHeaders) != nil {
+		if opts.NoHeaders, err = cmd.Flags().GetBool(FlagNoHeaders); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Flags().Lookup(FlagSortBy) != nil {
+		if opts.SortBy, err = cmd.Flags().GetString(FlagSortBy); err != nil {
+			return nil, err
+		}
+	}
+
+	return opts, nil
+}
+
+func parseFormat(formatStr string) (Format, string, error) {
+	name, template, hasTemplate := strings.Cut(formatStr, "=")
+
+	switch Format(name) {
+	case FormatJSON, FormatTable, FormatYAML, FormatWide, FormatName:
+		if !hasTemplate {
+			return Format(name), "", nil
+		}
+	case FormatJSONPath, FormatCustomColumns:
+		if template != "" {
+			return Format(name), template, nil
+		}
+		return "", "", fmt.Errorf("invalid output format: %s (the %s format requires a template, e.g. -o %s=...)", formatStr, name, name)
 	}
+
+	return "", "", fmt.Errorf("invalid output format: %s (must be table, wide, json, yaml, name, jsonpath=... or custom-columns=...)", formatStr)
 }
 
 // PrintJSON outputs data as JSON
@@ -49,3 +111,13 @@ func PrintJSON(w io.Writer, data interface{}) error {
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
+
+// PrintYAML outputs data as YAML, the fields are named as in the JSON output
+func PrintYAML(w io.Writer, data interface{}) error {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert to YAML: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
@@ -46,9 +46,51 @@ func TestGetFormat(t *testing.T) {
 			want:      FormatTable,
 			wantErr:   false,
 		},
+		{
+			name:      "yaml format",
+			flagValue: "yaml",
+			want:      FormatYAML,
+			wantErr:   false,
+		},
+		{
+			name:      "wide format",
+			flagValue: "wide",
+			want:      FormatWide,
+			wantErr:   false,
+		},
+		{
+			name:      "name format",
+			flagValue: "name",
+			want:      FormatName,
+			wantErr:   false,
+		},
+		{
+			name:      "jsonpath format",
+			flagValue: "jsonpath={.id}",
+			want:      FormatJSONPath,
+			wantErr:   false,
+		},
+		{
+			name:      "custom-columns format",
+			flagValue: "custom-columns=ID:.id",
+			want:      FormatCustomColumns,
+			wantErr:   false,
+		},
+		{
+			name:        "jsonpath format without template",
+			flagValue:   "jsonpath",
+			wantErr:     true,
+			errContains: "requires a template",
+		},
+		{
+			name:        "yaml format with template",
+			flagValue:   "yaml={.id}",
+			wantErr:     true,
+			errContains: "invalid output format",
+		},
 		{
 			name:        "invalid format",
-			flagValue:   "yaml",
+			flagValue:   "xml",
 			wantErr:     true,
 			errContains: "invalid output format",
 		},
@@ -86,6 +128,54 @@ func TestGetFormat(t *testing.T) {
 	}
 }
 
+func TestGetOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	AddFormatFlag(cmd)
+	cmd.Flags().Set(FlagOutput, "custom-columns=ID:.id,NAME:.name")
+	cmd.Flags().Set(FlagNoHeaders, "true")
+	cmd.Flags().Set(FlagSortBy, ".name")
+
+	opts, err := GetOptions(cmd)
+	if err != nil {
+		t.Fatalf("GetOptions() error = %v", err)
+	}
+
+	want := Options{Format: FormatCustomColumns, Template: "ID:.id,NAME:.name", NoHeaders: true, SortBy: ".name"}
+	if *opts != want {
+		t.Errorf("GetOptions() = %+v, want %+v", *opts, want)
+	}
+}
+
+func TestGetOptions_OutputFlagOnly(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP(FlagOutput, "o", "table", "Output format")
+
+	opts, err := GetOptions(cmd)
+	if err != nil {
+		t.Fatalf("GetOptions() error = %v", err)
+	}
+
+	if opts.Format != FormatTable || opts.NoHeaders || opts.SortBy != "" {
+		t.Errorf("GetOptions() = %+v, want the table format only", *opts)
+	}
+}
+
+func TestPrintYAML(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintYAML(&buf, map[string]interface{}{
+		"id":       "123",
+		"metadata": map[string]interface{}{"key": "value"},
+	})
+	if err != nil {
+		t.Fatalf("PrintYAML() error = %v", err)
+	}
+
+	want := "id: \"123\"\nmetadata:\n  key: value\n"
+	if buf.String() != want {
+		t.Errorf("PrintYAML() output mismatch:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
 func TestPrintJSON(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,302 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// noneValue is printed in a custom column if the path is not found in an item
+const noneValue = "<none>"
+
+// Table is a table of items, the wide columns are only printed in the wide format
+type Table struct {
+	Columns []TableColumn
+	// Rows have a value for each column, including the wide columns
+	Rows [][]string
+}
+
+// TableColumn is a column of a table
+type TableColumn struct {
+	Header string
+	Wide   bool
+}
+
+// Print prints the table, with the wide columns if wide is true
+func (t *Table) Print(w io.Writer, wide, noHeaders bool) (err error) {
+	printer := NewTablePrinter(w)
+	defer func() {
+		if flushErr := printer.Flush(); err == nil && flushErr != nil {
+			err = flushErr
+		}
+	}()
+
+	printRow := func(values []string) {
+		var cells []string
+		for i, column := range t.Columns {
+			if column.Wide && !wide {
+				continue
+			}
+			cells = append(cells, values[i])
+		}
+		fmt.Fprintln(printer.writer, strings.Join(cells, "\t"))
+	}
+
+	if !noHeaders {
+		headers := make([]string, 0, len(t.Columns))
+		for _, column := range t.Columns {
+			headers = append(headers, column.Header)
+		}
+		printRow(headers)
+	}
+	for _, row := range t.Rows {
+		printRow(row)
+	}
+
+	return nil
+}
+
+// Object is a single object to print in the output formats
+type Object struct {
+	// Name is the object as <kind>/<id> in the name format
+	Name string
+	// Data is printed in the json, yaml, jsonpath and custom-columns formats
+	Data interface{}
+	// Detail prints the object in the table format
+	Detail func(w io.Writer) error
+	// Table is the object as a table of one row in the wide format
+	Table *Table
+}
+
+// PrintObject prints a single object in the output format
+func PrintObject(w io.Writer, opts *Options, obj Object) error {
+	switch opts.Format {
+	case FormatJSON:
+		return PrintJSON(w, obj.Data)
+	case FormatYAML:
+		return PrintYAML(w, obj.Data)
+	case FormatName:
+		_, err := fmt.Fprintln(w, obj.Name)
+		return err
+	case FormatJSONPath:
+		return printJSONPath(w, opts.Template, obj.Data)
+	case FormatCustomColumns:
+		return printCustomColumns(w, opts, []interface{}{obj.Data})
+	case FormatTable:
+		if obj.Detail != nil {
+			return obj.Detail(w)
+		}
+	case FormatWide:
+		if obj.Table != nil {
+			return obj.Table.Print(w, true, opts.NoHeaders)
+		}
+	}
+	return fmt.Errorf("output format %s is not supported", opts.Format)
+}
+
+// PrintList prints a list in the output format. The items are sorted in place by the sort-by option first, so the
+// list has the sorted items too. The list is printed as a whole in the json, yaml and jsonpath formats, the items are
+// printed in the other formats.
+func PrintList[T any](w io.Writer, opts *Options, list interface{}, items []T, name func(T) string, table func([]T) *Table) error {
+	if opts.SortBy != "" {
+		if err := sortItems(items, opts.SortBy); err != nil {
+			return err
+		}
+	}
+
+	switch opts.Format {
+	case FormatJSON:
+		return PrintJSON(w, list)
+	case FormatYAML:
+		return PrintYAML(w, list)
+	case FormatJSONPath:
+		return printJSONPath(w, opts.Template, list)
+	case FormatName:
+		for _, item := range items {
+			if _, err := fmt.Fprintln(w, name(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCustomColumns:
+		data := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			data = append(data, item)
+		}
+		return printCustomColumns(w, opts, data)
+	case FormatTable, FormatWide:
+		return table(items).Print(w, opts.Format == FormatWide, opts.NoHeaders)
+	}
+	return fmt.Errorf("output format %s is not supported", opts.Format)
+}
+
+// relaxedJSONPath accepts the JSONPath expressions without the braces or the leading dot, e.g. name, .name and
+// {.name} are the same
+func relaxedJSONPath(path string) string {
+	if strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}") {
+		return path
+	}
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+func parseJSONPath(name, template string, allowMissingKeys bool) (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New(name).AllowMissingKeys(allowMissingKeys)
+	if err := jp.Parse(template); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %s: %w", template, err)
+	}
+	return jp, nil
+}
+
+// toJSONData converts the object to the generic JSON value that the JSONPath expressions are evaluated on
+func toJSONData(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func printJSONPath(w io.Writer, template string, obj interface{}) error {
+	jp, err := parseJSONPath("output", template, true)
+	if err != nil {
+		return err
+	}
+
+	data, err := toJSONData(obj)
+	if err != nil {
+		return err
+	}
+	return jp.Execute(w, data)
+}
+
+// printCustomColumns prints the items as a table of the columns in the spec, <HEADER>:<path>[,<HEADER>:<path>...]
+func printCustomColumns(w io.Writer, opts *Options, items []interface{}) error {
+	table := &Table{}
+	var paths []*jsonpath.JSONPath
+	for _, spec := range strings.Split(opts.Template, ",") {
+		header, path, ok := strings.Cut(spec, ":")
+		if !ok || header == "" || path == "" {
+			return fmt.Errorf("invalid custom column %q, it must be <HEADER>:<path>", spec)
+		}
+
+		jp, err := parseJSONPath(header, relaxedJSONPath(path), true)
+		if err != nil {
+			return err
+		}
+		table.Columns = append(table.Columns, TableColumn{Header: header})
+		paths = append(paths, jp)
+	}
+
+	for _, item := range items {
+		data, err := toJSONData(item)
+		if err != nil {
+			return err
+		}
+
+		row := make([]string, 0, len(paths))
+		for _, jp := range paths {
+			results, err := jp.FindResults(data)
+			if err != nil {
+				return err
+			}
+
+			var values []string
+			for _, result := range results {
+				for _, value := range result {
+					values = append(values, formatValue(value.Interface()))
+				}
+			}
+			if len(values) == 0 {
+				values = []string{noneValue}
+			}
+			row = append(row, strings.Join(values, ","))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table.Print(w, false, opts.NoHeaders)
+}
+
+// sortItems sorts the items by the value of the JSONPath expression, the numbers are compared by their values and
+// the other values by their text, the items without the value go first
+func sortItems[T any](items []T, sortBy string) error {
+	jp, err := parseJSONPath("sort-by", relaxedJSONPath(sortBy), true)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]interface{}, len(items))
+	for i, item := range items {
+		data, err := toJSONData(item)
+		if err != nil {
+			return err
+		}
+		results, err := jp.FindResults(data)
+		if err != nil {
+			return fmt.Errorf("failed to sort by %s: %w", sortBy, err)
+		}
+		if len(results) > 0 && len(results[0]) > 0 {
+			keys[i] = results[0][0].Interface()
+		}
+	}
+
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return lessValue(keys[indexes[i]], keys[indexes[j]])
+	})
+
+	sorted := make([]T, len(items))
+	for i, index := range indexes {
+		sorted[i] = items[index]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+func lessValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+
+	aNumber, aIsNumber := a.(float64)
+	bNumber, bIsNumber := b.(float64)
+	if aIsNumber && bIsNumber {
+		return aNumber < bNumber
+	}
+	return formatValue(a) < formatValue(b)
+}
+
+// formatValue formats a JSON value, the objects and the arrays are printed as JSON
+func formatValue(value interface{}) string {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(data)
+	case reflect.Invalid:
+		return noneValue
+	case reflect.Float64:
+		// the JSON numbers are float64, print the integers without the exponent
+		return strconv.FormatFloat(value.(float64), 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,246 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func testResourceBundleList() *openapi.ResourceBundleList {
+	return &openapi.ResourceBundleList{
+		Kind: "ResourceBundleList",
+		Items: []openapi.ResourceBundle{
+			{
+				Id:           openapi.PtrString("bundle-2"),
+				Name:         openapi.PtrString("web"),
+				ConsumerName: openapi.PtrString("cluster2"),
+				Version:      openapi.PtrInt32(10),
+				Manifests: []map[string]interface{}{
+					{"kind": "ConfigMap"},
+					{"kind": "Deployment"},
+				},
+				Status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Applied", "status": "True"},
+						map[string]interface{}{"type": "Available", "status": "False"},
+					},
+				},
+			},
+			{
+				Id:           openapi.PtrString("bundle-1"),
+				Name:         openapi.PtrString("api"),
+				ConsumerName: openapi.PtrString("cluster1"),
+				Version:      openapi.PtrInt32(9),
+			},
+		},
+	}
+}
+
+func printResourceBundleList(t *testing.T, opts *Options) string {
+	t.Helper()
+
+	list := testResourceBundleList()
+	var buf bytes.Buffer
+	if err := PrintList(&buf, opts, list, list.Items, ResourceBundleName, ResourceBundleTable); err != nil {
+		t.Fatalf("PrintList() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestPrintList(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *Options
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "table format",
+			opts:    &Options{Format: FormatTable},
+			want:    []string{"ID", "CONSUMER", "bundle-2", "bundle-1"},
+			notWant: []string{"MANIFESTS", "UPDATED"},
+		},
+		{
+			name: "wide format",
+			opts: &Options{Format: FormatWide},
+			want: []string{"MANIFESTS", "CONDITIONS", "UPDATED", "Applied=True,Available=False"},
+		},
+		{
+			name:    "no headers",
+			opts:    &Options{Format: FormatTable, NoHeaders: true},
+			want:    []string{"bundle-2", "bundle-1"},
+			notWant: []string{"ID", "CONSUMER"},
+		},
+		{
+			name: "yaml format",
+			opts: &Options{Format: FormatYAML},
+			want: []string{"kind: ResourceBundleList\n", "- consumer_name: cluster2\n"},
+		},
+		{
+			name: "name format",
+			opts: &Options{Format: FormatName},
+			want: []string{"resourcebundle/bundle-2\nresourcebundle/bundle-1\n"},
+		},
+		{
+			name: "jsonpath format",
+			opts: &Options{Format: FormatJSONPath, Template: "{.items[*].name}"},
+			want: []string{"web api"},
+		},
+		{
+			name: "custom-columns format",
+			opts: &Options{Format: FormatCustomColumns, Template: "NAME:.name,MANIFESTS:.manifests[*].kind,STATUS:.status.conditions[0].type"},
+			want: []string{"NAME", "web", "ConfigMap,Deployment", "Applied", "api", "<none>"},
+		},
+		{
+			name: "sort by name",
+			opts: &Options{Format: FormatName, SortBy: "{.name}"},
+			want: []string{"resourcebundle/bundle-1\nresourcebundle/bundle-2\n"},
+		},
+		{
+			name: "sort by version",
+			opts: &Options{Format: FormatName, SortBy: ".version"},
+			want: []string{"resourcebundle/bundle-1\nresourcebundle/bundle-2\n"},
+		},
+		{
+			name: "sort by a missing field",
+			opts: &Options{Format: FormatName, SortBy: ".status.conditions[0].type"},
+			want: []string{"resourcebundle/bundle-1\nresourcebundle/bundle-2\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := printResourceBundleList(t, tt.opts)
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("PrintList() output missing %q:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("PrintList() output should not contain %q:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintList_InvalidTemplate(t *testing.T) {
+	list := testResourceBundleList()
+	tests := []struct {
+		name        string
+		opts        *Options
+		errContains string
+	}{
+		{
+			name:        "invalid jsonpath",
+			opts:        &Options{Format: FormatJSONPath, Template: "{.items[}"},
+			errContains: "invalid JSONPath",
+		},
+		{
+			name:        "custom column without path",
+			opts:        &Options{Format: FormatCustomColumns, Template: "NAME"},
+			errContains: "invalid custom column",
+		},
+		{
+			name:        "invalid sort-by",
+			opts:        &Options{Format: FormatTable, SortBy: "{.name"},
+			errContains: "invalid JSONPath",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := PrintList(&buf, tt.opts, list, list.Items, ResourceBundleName, ResourceBundleTable)
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("PrintList() error = %v, should contain %v", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestPrintObject(t *testing.T) {
+	consumer := &openapi.Consumer{
+		Id:     openapi.PtrString("consumer-1"),
+		Name:   openapi.PtrString("cluster1"),
+		Labels: &map[string]string{"env": "prod"},
+	}
+
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{
+			name: "table format",
+			opts: &Options{Format: FormatTable},
+			want: "FIELD",
+		},
+		{
+			name: "wide format",
+			opts: &Options{Format: FormatWide},
+			want: "UPDATED",
+		},
+		{
+			name: "yaml format",
+			opts: &Options{Format: FormatYAML},
+			want: "name: cluster1\n",
+		},
+		{
+			name: "name format",
+			opts: &Options{Format: FormatName},
+			want: "consumer/consumer-1\n",
+		},
+		{
+			name: "jsonpath format",
+			opts: &Options{Format: FormatJSONPath, Template: "{.labels.env}"},
+			want: "prod",
+		},
+		{
+			name: "custom-columns format without headers",
+			opts: &Options{Format: FormatCustomColumns, Template: "NAME:.name", NoHeaders: true},
+			want: "cluster1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintObject(&buf, tt.opts, ConsumerObject(consumer)); err != nil {
+				t.Fatalf("PrintObject() error = %v", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("PrintObject() output missing %q:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "string", value: "text", want: "text"},
+		{name: "integer", value: float64(1700000000), want: "1700000000"},
+		{name: "float", value: 1.5, want: "1.5"},
+		{name: "bool", value: true, want: "true"},
+		{name: "nil", value: nil, want: "<none>"},
+		{name: "map", value: map[string]interface{}{"a": "b"}, want: `{"a":"b"}`},
+		{name: "slice", value: []interface{}{"a", float64(1)}, want: `["a",1]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatValue(tt.value); got != tt.want {
+				t.Errorf("formatValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
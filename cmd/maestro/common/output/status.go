@@ -0,0 +1,121 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StatusCondition is a condition in the status of a resource bundle or of one of its manifests
+type StatusCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime string
+}
+
+// ManifestStatus is the status of a manifest in a resource bundle
+type ManifestStatus struct {
+	Kind       string
+	Namespace  string
+	Name       string
+	Conditions []StatusCondition
+}
+
+// Key returns the manifest as kind/namespace/name, or kind/name for a cluster scoped manifest, the kind is lower case
+func (m ManifestStatus) Key() string {
+	if m.Namespace == "" {
+		return fmt.Sprintf("%s/%s", strings.ToLower(m.Kind), m.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(m.Kind), m.Namespace, m.Name)
+}
+
+// GetStatusConditions returns the conditions in the status of a resource bundle, the malformed conditions are skipped
+func GetStatusConditions(status map[string]interface{}) []StatusCondition {
+	return parseConditions(status["conditions"])
+}
+
+// GetManifestStatuses returns the status of each manifest in the status of a resource bundle
+func GetManifestStatuses(status map[string]interface{}) []ManifestStatus {
+	resourceStatus, ok := status["resourceStatus"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var manifests []ManifestStatus
+	for _, manifestInterface := range resourceStatus {
+		manifest, ok := manifestInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		meta, _ := manifest["resourceMeta"].(map[string]interface{})
+		kind, _ := meta["kind"].(string)
+		namespace, _ := meta["namespace"].(string)
+		name, _ := meta["name"].(string)
+		manifests = append(manifests, ManifestStatus{
+			Kind:       kind,
+			Namespace:  namespace,
+			Name:       name,
+			Conditions: parseConditions(manifest["conditions"]),
+		})
+	}
+	return manifests
+}
+
+// FindStatusCondition returns the condition with the given type, nil if it is not found
+func FindStatusCondition(conditions []StatusCondition, condType string) *StatusCondition {
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsFailedCondition returns true if the condition reports a failure, that is the Applied or Available condition
+// is False or the Degraded condition is True, the other conditions (e.g. Progressing=False) are not failures
+func IsFailedCondition(cond StatusCondition) bool {
+	switch cond.Type {
+	case "Applied", "Available":
+		return cond.Status == "False"
+	case "Degraded":
+		return cond.Status == "True"
+	}
+	return false
+}
+
+// FormatConditions formats the conditions as Type=Status pairs ordered by the type
+func FormatConditions(conditions []StatusCondition) string {
+	parts := make([]string, 0, len(conditions))
+	for _, cond := range conditions {
+		parts = append(parts, fmt.Sprintf("%s=%s", cond.Type, cond.Status))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func parseConditions(conditionsInterface interface{}) []StatusCondition {
+	conditions, ok := conditionsInterface.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var result []StatusCondition
+	for _, condInterface := range conditions {
+		cond, ok := condInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condition := StatusCondition{}
+		condition.Type, _ = cond["type"].(string)
+		condition.Status, _ = cond["status"].(string)
+		condition.Reason, _ = cond["reason"].(string)
+		condition.Message, _ = cond["message"].(string)
+		condition.LastTransitionTime, _ = cond["lastTransitionTime"].(string)
+		result = append(result, condition)
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestGetManifestStatuses(t *testing.T) {
+	status := map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Applied", "status": "True"},
+			"malformed",
+		},
+		"resourceStatus": []interface{}{
+			map[string]interface{}{
+				"resourceMeta": map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web"},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Available", "status": "False", "reason": "MinimumReplicasUnavailable"},
+				},
+			},
+			map[string]interface{}{
+				"resourceMeta": map[string]interface{}{"kind": "ClusterRole", "name": "web"},
+			},
+		},
+	}
+
+	conditions := GetStatusConditions(status)
+	if len(conditions) != 1 || conditions[0].Type != "Applied" || conditions[0].Status != "True" {
+		t.Errorf("GetStatusConditions() = %+v, want the Applied condition", conditions)
+	}
+
+	manifests := GetManifestStatuses(status)
+	if len(manifests) != 2 {
+		t.Fatalf("GetManifestStatuses() returned %d manifests, want 2", len(manifests))
+	}
+	if key := manifests[0].Key(); key != "deployment/default/web" {
+		t.Errorf("Key() = %s, want deployment/default/web", key)
+	}
+	if key := manifests[1].Key(); key != "clusterrole/web" {
+		t.Errorf("Key() = %s, want clusterrole/web", key)
+	}
+
+	available := FindStatusCondition(manifests[0].Conditions, "Available")
+	if available == nil || available.Status != "False" || available.Reason != "MinimumReplicasUnavailable" {
+		t.Errorf("FindStatusCondition() = %+v, want the Available condition", available)
+	}
+	if cond := FindStatusCondition(manifests[1].Conditions, "Available"); cond != nil {
+		t.Errorf("FindStatusCondition() = %+v, want nil", cond)
+	}
+}
+
+func TestIsFailedCondition(t *testing.T) {
+	tests := []struct {
+		condType string
+		status   string
+		want     bool
+	}{
+		{condType: "Applied", status: "False", want: true},
+		{condType: "Applied", status: "True", want: false},
+		{condType: "Available", status: "False", want: true},
+		{condType: "Available", status: "Unknown", want: false},
+		{condType: "Degraded", status: "True", want: true},
+		{condType: "Degraded", status: "False", want: false},
+		{condType: "Progressing", status: "False", want: false},
+		{condType: "StatusFeedbackSynced", status: "False", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condType+"="+tt.status, func(t *testing.T) {
+			if got := IsFailedCondition(StatusCondition{Type: tt.condType, Status: tt.status}); got != tt.want {
+				t.Errorf("IsFailedCondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
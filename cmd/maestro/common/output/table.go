@@ -7,6 +7,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 )
 
@@ -28,31 +29,50 @@ func (p *TablePrinter) Flush() error {
 }
 
 // PrintResourceBundleList prints a list of resource bundles as a table
-func PrintResourceBundleList(w io.Writer, bundles []openapi.ResourceBundle) (err error) {
-	printer := NewTablePrinter(w)
-	defer func() {
-		if flushErr := printer.Flush(); err == nil && flushErr != nil {
-			err = flushErr
-		}
-	}()
+func PrintResourceBundleList(w io.Writer, bundles []openapi.ResourceBundle) error {
+	return ResourceBundleTable(bundles).Print(w, false, false)
+}
 
-	// Print header
-	fmt.Fprintln(printer.writer, "ID\tNAME\tCONSUMER\tVERSION\tCREATED\tSTATUS")
+// ResourceBundleTable returns the table of the resource bundles, the wide columns are the number of manifests, the
+// conditions and the update time
+func ResourceBundleTable(bundles []openapi.ResourceBundle) *Table {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "ID"}, {Header: "NAME"}, {Header: "CONSUMER"}, {Header: "VERSION"}, {Header: "CREATED"}, {Header: "STATUS"},
+			{Header: "MANIFESTS", Wide: true}, {Header: "CONDITIONS", Wide: true}, {Header: "UPDATED", Wide: true},
+		},
+	}
 
-	// Print rows
 	for _, bundle := range bundles {
-		id := getStringPtr(bundle.Id)
-		name := getStringPtr(bundle.Name)
-		consumer := getStringPtr(bundle.ConsumerName)
-		version := fmt.Sprintf("%d", getInt32Ptr(bundle.Version))
-		created := formatTime(bundle.CreatedAt)
-		status := getStatusFromMap(bundle.Status)
-
-		fmt.Fprintf(printer.writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
-			id, name, consumer, version, created, status)
+		table.Rows = append(table.Rows, []string{
+			getStringPtr(bundle.Id),
+			getStringPtr(bundle.Name),
+			getStringPtr(bundle.ConsumerName),
+			fmt.Sprintf("%d", getInt32Ptr(bundle.Version)),
+			formatTime(bundle.CreatedAt),
+			getStatusFromMap(bundle.Status),
+			fmt.Sprintf("%d", len(bundle.Manifests)),
+			FormatConditions(GetStatusConditions(bundle.Status)),
+			formatTime(bundle.UpdatedAt),
+		})
 	}
 
-	return nil
+	return table
+}
+
+// ResourceBundleName returns the resource bundle in the name format
+func ResourceBundleName(bundle openapi.ResourceBundle) string {
+	return "resourcebundle/" + getStringPtr(bundle.Id)
+}
+
+// ResourceBundleObject returns the resource bundle to print in the output formats
+func ResourceBundleObject(bundle *openapi.ResourceBundle) Object {
+	return Object{
+		Name:   ResourceBundleName(*bundle),
+		Data:   bundle,
+		Detail: func(w io.Writer) error { return PrintResourceBundle(w, bundle) },
+		Table:  ResourceBundleTable([]openapi.ResourceBundle{*bundle}),
+	}
 }
 
 // PrintResourceBundle prints a single resource bundle as a table
@@ -81,29 +101,45 @@ func PrintResourceBundle(w io.Writer, bundle *openapi.ResourceBundle) (err error
 }
 
 // PrintConsumerList prints a list of consumers as a table
-func PrintConsumerList(w io.Writer, consumers []openapi.Consumer) (err error) {
-	printer := NewTablePrinter(w)
-	defer func() {
-		if flushErr := printer.Flush(); err == nil && flushErr != nil {
-			err = flushErr
-		}
-	}()
+func PrintConsumerList(w io.Writer, consumers []openapi.Consumer) error {
+	return ConsumerTable(consumers).Print(w, false, false)
+}
 
-	// Print header
-	fmt.Fprintln(printer.writer, "ID\tNAME\tLABELS\tCREATED")
+// ConsumerTable returns the table of the consumers, the wide column is the update time
+func ConsumerTable(consumers []openapi.Consumer) *Table {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "ID"}, {Header: "NAME"}, {Header: "LABELS"}, {Header: "CREATED"},
+			{Header: "UPDATED", Wide: true},
+		},
+	}
 
-	// Print rows
 	for _, consumer := range consumers {
-		id := getStringPtr(consumer.Id)
-		name := getStringPtr(consumer.Name)
-		labels := formatLabels(consumer.Labels)
-		created := formatTime(consumer.CreatedAt)
-
-		fmt.Fprintf(printer.writer, "%s\t%s\t%s\t%s\n",
-			id, name, labels, created)
+		table.Rows = append(table.Rows, []string{
+			getStringPtr(consumer.Id),
+			getStringPtr(consumer.Name),
+			formatLabels(consumer.Labels),
+			formatTime(consumer.CreatedAt),
+			formatTime(consumer.UpdatedAt),
+		})
 	}
 
-	return nil
+	return table
+}
+
+// ConsumerName returns the consumer in the name format
+func ConsumerName(consumer openapi.Consumer) string {
+	return "consumer/" + getStringPtr(consumer.Id)
+}
+
+// ConsumerObject returns the consumer to print in the output formats
+func ConsumerObject(consumer *openapi.Consumer) Object {
+	return Object{
+		Name:   ConsumerName(*consumer),
+		Data:   consumer,
+		Detail: func(w io.Writer) error { return PrintConsumer(w, consumer) },
+		Table:  ConsumerTable([]openapi.Consumer{*consumer}),
+	}
 }
 
 // PrintConsumer prints a single consumer as a table
@@ -131,6 +167,45 @@ func PrintConsumer(w io.Writer, consumer *openapi.Consumer) (err error) {
 
 // Helper functions
 
+// PrintContextList prints the contexts of the CLI config file as a table, the current context is marked with '*'
+func PrintContextList(w io.Writer, currentContext string, contexts []clients.Context) error {
+	return ContextTable(currentContext, contexts).Print(w, false, false)
+}
+
+// ContextTable returns the table of the contexts, the current context is marked with '*', the wide columns are the
+// REST API and gRPC TLS settings
+func ContextTable(currentContext string, contexts []clients.Context) *Table {
+	table := &Table{
+		Columns: []TableColumn{
+			{Header: "CURRENT"}, {Header: "NAME"}, {Header: "REST URL"}, {Header: "GRPC SERVER"}, {Header: "SOURCE ID"},
+			{Header: "INSECURE", Wide: true}, {Header: "TIMEOUT", Wide: true}, {Header: "GRPC CA FILE", Wide: true},
+		},
+	}
+
+	for _, c := range contexts {
+		current := ""
+		if c.Name == currentContext {
+			current = "*"
+		}
+		insecure := ""
+		if c.InsecureSkipVerify != nil {
+			insecure = fmt.Sprintf("%t", *c.InsecureSkipVerify)
+		}
+
+		table.Rows = append(table.Rows, []string{
+			current, c.Name, c.RESTURL, c.GRPCServerAddress, c.GRPCSourceID,
+			insecure, c.Timeout, c.GRPCCAFile,
+		})
+	}
+
+	return table
+}
+
+// ContextName returns the context in the name format
+func ContextName(c clients.Context) string {
+	return "context/" + c.Name
+}
+
 func getStringPtr(ptr *string) string {
 	if ptr == nil {
 		return ""
@@ -178,75 +253,58 @@ func PrintResourceBundleStatus(w io.Writer, bundleID string, status map[string]i
 	fmt.Fprintf(printer.writer, "Status\t%s\n", getStatusFromMap(status))
 
 	// Print conditions if available
-	conditionsInterface, ok := status["conditions"]
-	if ok {
-		conditions, ok := conditionsInterface.([]interface{})
-		if ok && len(conditions) > 0 {
-			fmt.Fprintln(printer.writer, "")
-			fmt.Fprintln(printer.writer, "Conditions:")
-			for _, condInterface := range conditions {
-				cond, ok := condInterface.(map[string]interface{})
-				if !ok {
-					continue
-				}
-
-				condType, _ := cond["type"].(string)
-				condStatus, _ := cond["status"].(string)
-				reason, _ := cond["reason"].(string)
-				message, _ := cond["message"].(string)
-				lastTransitionTime, _ := cond["lastTransitionTime"].(string)
-
-				fmt.Fprintf(printer.writer, "  Type\t%s\n", condType)
-				fmt.Fprintf(printer.writer, "  Status\t%s\n", condStatus)
-				if reason != "" {
-					fmt.Fprintf(printer.writer, "  Reason\t%s\n", reason)
-				}
-				if message != "" {
-					fmt.Fprintf(printer.writer, "  Message\t%s\n", message)
-				}
-				if lastTransitionTime != "" {
-					fmt.Fprintf(printer.writer, "  LastTransitionTime\t%s\n", lastTransitionTime)
-				}
-				fmt.Fprintln(printer.writer, "")
+	conditions := GetStatusConditions(status)
+	if len(conditions) > 0 {
+		fmt.Fprintln(printer.writer, "")
+		fmt.Fprintln(printer.writer, "Conditions:")
+		for _, cond := range conditions {
+			fmt.Fprintf(printer.writer, "  Type\t%s\n", cond.Type)
+			fmt.Fprintf(printer.writer, "  Status\t%s\n", cond.Status)
+			if cond.Reason != "" {
+				fmt.Fprintf(printer.writer, "  Reason\t%s\n", cond.Reason)
+			}
+			if cond.Message != "" {
+				fmt.Fprintf(printer.writer, "  Message\t%s\n", cond.Message)
 			}
+			if cond.LastTransitionTime != "" {
+				fmt.Fprintf(printer.writer, "  LastTransitionTime\t%s\n", cond.LastTransitionTime)
+			}
+			fmt.Fprintln(printer.writer, "")
 		}
 	}
 
 	return nil
 }
 
-func getStatusFromMap(status map[string]interface{}) string {
-	if len(status) == 0 {
-		return "Unknown"
+// ResourceBundleStatusObject returns the status of a resource bundle to print in the output formats, the wide column
+// of its table is the conditions of each manifest
+func ResourceBundleStatusObject(bundleID string, status map[string]interface{}) Object {
+	var manifests []string
+	for _, manifest := range GetManifestStatuses(status) {
+		manifests = append(manifests, fmt.Sprintf("%s(%s)", manifest.Key(), FormatConditions(manifest.Conditions)))
 	}
 
-	// Extract conditions from the status map
-	conditionsInterface, ok := status["conditions"]
-	if !ok {
-		return "Unknown"
+	return Object{
+		Name:   "resourcebundle/" + bundleID,
+		Data:   status,
+		Detail: func(w io.Writer) error { return PrintResourceBundleStatus(w, bundleID, status) },
+		Table: &Table{
+			Columns: []TableColumn{{Header: "ID"}, {Header: "STATUS"}, {Header: "CONDITIONS"}, {Header: "MANIFESTS", Wide: true}},
+			Rows: [][]string{{
+				bundleID, getStatusFromMap(status), FormatConditions(GetStatusConditions(status)), strings.Join(manifests, " "),
+			}},
+		},
 	}
+}
 
-	conditions, ok := conditionsInterface.([]interface{})
-	if !ok || len(conditions) == 0 {
+func getStatusFromMap(status map[string]interface{}) string {
+	// Find the Applied condition
+	applied := FindStatusCondition(GetStatusConditions(status), "Applied")
+	if applied == nil {
 		return "Unknown"
 	}
-
-	// Find the Applied condition
-	for _, condInterface := range conditions {
-		cond, ok := condInterface.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		condType, _ := cond["type"].(string)
-		if condType == "Applied" {
-			condStatus, _ := cond["status"].(string)
-			if condStatus == "True" {
-				return "Applied"
-			}
-			return "Pending"
-		}
+	if applied.Status == "True" {
+		return "Applied"
 	}
-
-	return "Unknown"
+	return "Pending"
 }
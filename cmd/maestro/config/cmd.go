@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCommand creates the config subcommand
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the contexts of the CLI config file",
+		Long: `Manage the named contexts of the maestro CLI config file.
+
+A context holds the REST API URL, the gRPC server address, the TLS and authentication
+files and the source ID of a maestro environment. The client commands use the current
+context, or the context given by --context or MAESTRO_CONTEXT. The flags and the
+environment variables override the settings of the context.
+
+The config file is ~/.config/maestro/config.yaml, or $XDG_CONFIG_HOME/maestro/config.yaml
+if XDG_CONFIG_HOME is set. Set MAESTRO_CONFIG to use another file.
+
+Commands:
+  get-contexts - List the contexts
+  set-context  - Create or update a context
+  use-context  - Set the current context`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(
+		newGetContextsCommand(),
+		newSetContextCommand(),
+		newUseContextCommand(),
+	)
+
+	return cmd
+}
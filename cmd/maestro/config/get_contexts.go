@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+)
+
+func newGetContextsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the contexts",
+		Long: `List the contexts of the CLI config file, the current context is marked with '*'.
+
+Examples:
+  maestro config get-contexts
+  maestro config get-contexts --output json`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runGetContexts(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	output.AddFormatFlag(cmd)
+
+	return cmd
+}
+
+func runGetContexts(cmd *cobra.Command, _ []string) error {
+	config, err := clients.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	// Output the result
+	opts, err := output.GetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	contextTable := func(contexts []clients.Context) *output.Table {
+		return output.ContextTable(config.CurrentContext, contexts)
+	}
+	return output.PrintList(cmd.OutOrStdout(), opts, config, config.Contexts, output.ContextName, contextTable)
+}
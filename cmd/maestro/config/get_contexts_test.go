@@ -0,0 +1,62 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+)
+
+func TestRunGetContexts(t *testing.T) {
+	setupTestConfig(t)
+
+	setContext(t, "dev", "--rest-url", "https://dev.example.com", "--grpc-source-id", "dev-source")
+	setContext(t, "prod", "--rest-url", "https://prod.example.com")
+
+	tests := []struct {
+		name         string
+		output       string
+		wantContains []string
+	}{
+		{
+			name:   "table format",
+			output: "table",
+			wantContains: []string{
+				"CURRENT",
+				"*         dev    https://dev.example.com",
+				"prod",
+				"dev-source",
+			},
+		},
+		{
+			name:   "json format",
+			output: "json",
+			wantContains: []string{
+				`"current-context": "dev"`,
+				`"rest-url": "https://prod.example.com"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newGetContextsCommand()
+			if err := cmd.ParseFlags([]string{"--" + output.FlagOutput, tt.output}); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+
+			if err := runGetContexts(cmd, []string{}); err != nil {
+				t.Fatalf("runGetContexts() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("runGetContexts() output = %s, should contain %s", out.String(), want)
+				}
+			}
+		})
+	}
+}
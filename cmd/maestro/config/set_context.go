@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+)
+
+func newSetContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-context <name> [flags]",
+		Short: "Create or update a context",
+		Long: `Create or update a context of the CLI config file.
+
+Only the settings given by the flags are changed when the context exists. The first
+context of the config file becomes the current context.
+
+Examples:
+  maestro config set-context dev --rest-url https://127.0.0.1:30080 --insecure-skip-verify \
+    --grpc-server-address 127.0.0.1:30090
+  maestro config set-context prod --rest-url https://maestro.example.com \
+    --grpc-server-address maestro-grpc.example.com:443 --grpc-ca-file ca.crt \
+    --grpc-token-file token --grpc-source-id prod-source`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runSetContext(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String(clients.FlagRESTURL, "", "Maestro REST API base URL")
+	cmd.Flags().Bool(clients.FlagInsecureSkipVerify, false, "Skip TLS certificate verification for REST API")
+	cmd.Flags().Duration(clients.FlagTimeout, 0, "HTTP client timeout for REST API")
+	cmd.Flags().String(clients.FlagGRPCServerAddress, "", "gRPC server address")
+	cmd.Flags().String(clients.FlagGRPCSourceID, "", "Source ID for gRPC client")
+	cmd.Flags().String(clients.FlagGRPCCAFile, "", "Path to CA certificate file for gRPC TLS")
+	cmd.Flags().String(clients.FlagGRPCTokenFile, "", "Path to token file for gRPC authentication")
+	cmd.Flags().String(clients.FlagGRPCClientCert, "", "Path to client certificate file for mutual TLS")
+	cmd.Flags().String(clients.FlagGRPCClientKey, "", "Path to client private key file for mutual TLS")
+
+	return cmd
+}
+
+func runSetContext(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	config, err := clients.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	namedContext := clients.Context{Name: name}
+	if existing := config.GetContext(name); existing != nil {
+		namedContext = *existing
+	}
+
+	// Only update the settings that are given by the flags
+	stringFields := map[string]*string{
+		clients.FlagRESTURL:           &namedContext.RESTURL,
+		clients.FlagGRPCServerAddress: &namedContext.GRPCServerAddress,
+		clients.FlagGRPCSourceID:      &namedContext.GRPCSourceID,
+		clients.FlagGRPCCAFile:        &namedContext.GRPCCAFile,
+		clients.FlagGRPCTokenFile:     &namedContext.GRPCTokenFile,
+		clients.FlagGRPCClientCert:    &namedContext.GRPCClientCert,
+		clients.FlagGRPCClientKey:     &namedContext.GRPCClientKey,
+	}
+	for flag, field := range stringFields {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+		value, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return fmt.Errorf("failed to read --%s: %w", flag, err)
+		}
+		*field = value
+	}
+
+	if cmd.Flags().Changed(clients.FlagInsecureSkipVerify) {
+		insecureSkipVerify, err := cmd.Flags().GetBool(clients.FlagInsecureSkipVerify)
+		if err != nil {
+			return fmt.Errorf("failed to read --%s: %w", clients.FlagInsecureSkipVerify, err)
+		}
+		namedContext.InsecureSkipVerify = &insecureSkipVerify
+	}
+
+	if cmd.Flags().Changed(clients.FlagTimeout) {
+		timeout, err := cmd.Flags().GetDuration(clients.FlagTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to read --%s: %w", clients.FlagTimeout, err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("--%s must be greater than 0", clients.FlagTimeout)
+		}
+		namedContext.Timeout = timeout.String()
+	}
+
+	config.SetContext(namedContext)
+	if config.CurrentContext == "" {
+		config.CurrentContext = name
+	}
+	if err := clients.SaveCLIConfig(config); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Context %q set\n", name)
+	return nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+)
+
+func setupTestConfig(t *testing.T) {
+	t.Setenv(clients.EnvConfigFile, filepath.Join(t.TempDir(), "config.yaml"))
+}
+
+func setContext(t *testing.T, args ...string) {
+	cmd := newSetContextCommand()
+	if err := cmd.ParseFlags(args[1:]); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runSetContext(cmd, args[:1]); err != nil {
+		t.Fatalf("runSetContext() error = %v", err)
+	}
+}
+
+func TestRunSetContext(t *testing.T) {
+	setupTestConfig(t)
+
+	setContext(t, "dev", "--rest-url", "https://dev.example.com", "--grpc-server-address", "dev.example.com:8090")
+	setContext(t, "prod", "--rest-url", "https://prod.example.com", "--insecure-skip-verify=false", "--timeout", "1m")
+	// only the given settings are updated
+	setContext(t, "dev", "--grpc-source-id", "dev-source")
+
+	config, err := clients.LoadCLIConfig()
+	if err != nil {
+		t.Fatalf("LoadCLIConfig() error = %v", err)
+	}
+
+	// the first context is the current context
+	if config.CurrentContext != "dev" {
+		t.Errorf("CurrentContext = %v, want dev", config.CurrentContext)
+	}
+
+	dev := config.GetContext("dev")
+	if dev == nil || dev.RESTURL != "https://dev.example.com" || dev.GRPCServerAddress != "dev.example.com:8090" ||
+		dev.GRPCSourceID != "dev-source" || dev.InsecureSkipVerify != nil {
+		t.Errorf("dev context = %+v", dev)
+	}
+
+	prod := config.GetContext("prod")
+	if prod == nil || prod.InsecureSkipVerify == nil || *prod.InsecureSkipVerify || prod.Timeout != time.Minute.String() {
+		t.Errorf("prod context = %+v", prod)
+	}
+}
+
+func TestRunSetContext_InvalidTimeout(t *testing.T) {
+	setupTestConfig(t)
+
+	cmd := newSetContextCommand()
+	if err := cmd.ParseFlags([]string{"--timeout", "0s"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	if err := runSetContext(cmd, []string{"dev"}); err == nil {
+		t.Error("runSetContext() should error for a zero timeout")
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+)
+
+func newUseContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use-context <name>",
+		Short: "Set the current context",
+		Long: `Set the current context of the CLI config file.
+
+Example:
+  maestro config use-context prod`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runUseContext(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func runUseContext(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	config, err := clients.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	if config.GetContext(name) == nil {
+		return fmt.Errorf("context %q is not found", name)
+	}
+
+	config.CurrentContext = name
+	if err := clients.SaveCLIConfig(config); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %q\n", name)
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+)
+
+func TestRunUseContext(t *testing.T) {
+	setupTestConfig(t)
+
+	setContext(t, "dev", "--rest-url", "https://dev.example.com")
+	setContext(t, "prod", "--rest-url", "https://prod.example.com")
+
+	cmd := newUseContextCommand()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	if err := runUseContext(cmd, []string{"prod"}); err != nil {
+		t.Fatalf("runUseContext() error = %v", err)
+	}
+	if !strings.Contains(out.String(), `Switched to context "prod"`) {
+		t.Errorf("runUseContext() output = %s", out.String())
+	}
+
+	config, err := clients.LoadCLIConfig()
+	if err != nil {
+		t.Fatalf("LoadCLIConfig() error = %v", err)
+	}
+	if config.CurrentContext != "prod" {
+		t.Errorf("CurrentContext = %v, want prod", config.CurrentContext)
+	}
+
+	err = runUseContext(cmd, []string{"stage"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("runUseContext() error = %v, should contain not found", err)
+	}
+}
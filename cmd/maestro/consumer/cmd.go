@@ -16,7 +16,8 @@ func NewConsumerCommand() *cobra.Command {
 		Long: `Manage Maestro consumers.
 
 Consumers represent target clusters that receive resource bundles from Maestro.
-This command provides full CRUD operations (create, get, list, update, delete) via the Maestro REST API.`,
+This command provides full CRUD operations (create, get, list, update, delete) via the Maestro REST API,
+and describe summarizes the resource bundles that target a consumer.`,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Suppress verbose logs by default for CLI commands
 			// Only suppress if user hasn't set -v flag
@@ -33,6 +34,7 @@ This command provides full CRUD operations (create, get, list, update, delete) v
 	// Add subcommands
 	cmd.AddCommand(
 		newGetCommand(),
+		newDescribeCommand(),
 		newListCommand(),
 		newCreateCommand(),
 		newUpdateCommand(),
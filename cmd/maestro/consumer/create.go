@@ -91,14 +91,10 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output the result
-	format, err := output.GetFormat(cmd)
+	opts, err := output.GetOptions(cmd)
 	if err != nil {
 		return err
 	}
 
-	if format == output.FormatTable {
-		return output.PrintConsumer(os.Stdout, created)
-	}
-
-	return output.PrintJSON(os.Stdout, created)
+	return output.PrintObject(os.Stdout, opts, output.ConsumerObject(created))
 }
@@ -0,0 +1,302 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+const (
+	// recentErrorLimit is the number of the most recent errors in the description of a consumer
+	recentErrorLimit = 10
+	// noConditions is the condition state of the resource bundles without a status
+	noConditions = "<none>"
+)
+
+func newDescribeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe <id>",
+		Short: "Describe a consumer and the resource bundles that target it",
+		Long: `Describe a consumer and summarize the resource bundles that target it.
+
+The resource bundles of the consumer are grouped by their condition state. The summary
+also counts the resource bundles that are pending deletion and the ones that are not
+delivered yet (they have no status), and shows the last time the agent updated a status
+and the most recent conditions with the False status.
+
+Example:
+  maestro consumer describe <consumer-id>
+  maestro consumer describe <consumer-id> --output json`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runDescribe(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	output.AddFormatFlag(cmd)
+
+	return cmd
+}
+
+// consumerDescription is a consumer with the summary of the resource bundles that target it
+type consumerDescription struct {
+	Consumer *openapi.Consumer `json:"consumer"`
+	Bundles  bundleSummary     `json:"bundles"`
+}
+
+// bundleSummary is the summary of the resource bundles of a consumer
+type bundleSummary struct {
+	Total           int              `json:"total"`
+	PendingDeletion int              `json:"pending_deletion"`
+	Undelivered     int              `json:"undelivered"`
+	ConditionStates []conditionState `json:"condition_states"`
+	// LastStatusUpdate is the latest condition transition time of the resource bundles and their manifests
+	LastStatusUpdate *time.Time `json:"last_status_update,omitempty"`
+	// Errors is the number of all the conditions with the False status, RecentErrors keeps only the most recent ones
+	Errors       int           `json:"errors"`
+	RecentErrors []bundleError `json:"recent_errors"`
+}
+
+// conditionState is the number of the resource bundles that have the same conditions
+type conditionState struct {
+	Conditions string `json:"conditions"`
+	Count      int    `json:"count"`
+}
+
+// bundleError is a condition with the False status of a resource bundle or of one of its manifests
+type bundleError struct {
+	Time     *time.Time `json:"time,omitempty"`
+	BundleID string     `json:"bundle_id"`
+	// Manifest is empty for a condition of the resource bundle
+	Manifest string `json:"manifest,omitempty"`
+	Type     string `json:"type"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+func runDescribe(cmd *cobra.Command, args []string) error {
+	consumerID := args[0]
+
+	opts, err := output.GetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Load REST client configuration
+	cfg, err := clients.LoadRESTConfigFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Create REST client
+	restClient, err := clients.NewRESTClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	// Get the consumer and its resource bundles
+	ctx := context.Background()
+	consumer, err := restClient.GetConsumer(ctx, consumerID)
+	if err != nil {
+		return err
+	}
+
+	bundles, err := listConsumerBundles(ctx, restClient, consumer.GetName())
+	if err != nil {
+		return err
+	}
+
+	description := describeConsumer(consumer, bundles)
+	return output.PrintObject(os.Stdout, opts, output.Object{
+		Name:   output.ConsumerName(*consumer),
+		Data:   description,
+		Detail: func(w io.Writer) error { return printConsumerDescription(w, description) },
+		Table:  description.table(),
+	})
+}
+
+// listConsumerBundles returns all the resource bundles of the consumer
+func listConsumerBundles(ctx context.Context, restClient *clients.RESTClient, consumerName string) ([]openapi.ResourceBundle, error) {
+	search := fmt.Sprintf("consumer_name='%s'", consumerName)
+
+	var bundles []openapi.ResourceBundle
+	for page := 1; ; page++ {
+		result, err := restClient.ListResourceBundles(ctx, page, 100, search)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource bundles of consumer %q: %w", consumerName, err)
+		}
+		bundles = append(bundles, result.GetItems()...)
+		if len(result.GetItems()) == 0 || len(bundles) >= int(result.GetTotal()) {
+			return bundles, nil
+		}
+	}
+}
+
+// describeConsumer summarizes the resource bundles of the consumer
+func describeConsumer(consumer *openapi.Consumer, bundles []openapi.ResourceBundle) *consumerDescription {
+	summary := bundleSummary{Total: len(bundles), ConditionStates: []conditionState{}, RecentErrors: []bundleError{}}
+
+	states := map[string]int{}
+	for _, bundle := range bundles {
+		if bundle.DeletedAt != nil {
+			summary.PendingDeletion++
+		}
+		if len(bundle.Status) == 0 {
+			summary.Undelivered++
+		}
+
+		conditions := output.GetStatusConditions(bundle.Status)
+		state := output.FormatConditions(conditions)
+		if state == "" {
+			state = noConditions
+		}
+		states[state]++
+
+		summary.addConditions(bundle.GetId(), "", conditions)
+		for _, manifest := range output.GetManifestStatuses(bundle.Status) {
+			summary.addConditions(bundle.GetId(), manifest.Key(), manifest.Conditions)
+		}
+	}
+
+	for conditions, count := range states {
+		summary.ConditionStates = append(summary.ConditionStates, conditionState{Conditions: conditions, Count: count})
+	}
+	// the most common states go first
+	sort.Slice(summary.ConditionStates, func(i, j int) bool {
+		a, b := summary.ConditionStates[i], summary.ConditionStates[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Conditions < b.Conditions
+	})
+
+	// the most recent errors go first, the errors without a time go last
+	sort.SliceStable(summary.RecentErrors, func(i, j int) bool {
+		a, b := summary.RecentErrors[i].Time, summary.RecentErrors[j].Time
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.After(*b)
+	})
+	if len(summary.RecentErrors) > recentErrorLimit {
+		summary.RecentErrors = summary.RecentErrors[:recentErrorLimit]
+	}
+
+	return &consumerDescription{Consumer: consumer, Bundles: summary}
+}
+
+// addConditions tracks the last status update of the conditions, counts the failed conditions and adds them to
+// the errors
+func (s *bundleSummary) addConditions(bundleID, manifest string, conditions []output.StatusCondition) {
+	for _, cond := range conditions {
+		var transitionTime *time.Time
+		if t, err := time.Parse(time.RFC3339, cond.LastTransitionTime); err == nil {
+			transitionTime = &t
+			if s.LastStatusUpdate == nil || t.After(*s.LastStatusUpdate) {
+				s.LastStatusUpdate = transitionTime
+			}
+		}
+
+		if !output.IsFailedCondition(cond) {
+			continue
+		}
+		s.Errors++
+		s.RecentErrors = append(s.RecentErrors, bundleError{
+			Time:     transitionTime,
+			BundleID: bundleID,
+			Manifest: manifest,
+			Type:     cond.Type,
+			Reason:   cond.Reason,
+			Message:  cond.Message,
+		})
+	}
+}
+
+// table returns the description as a table of one row
+func (d *consumerDescription) table() *output.Table {
+	return &output.Table{
+		Columns: []output.TableColumn{
+			{Header: "ID"}, {Header: "NAME"}, {Header: "BUNDLES"}, {Header: "PENDING DELETION"}, {Header: "UNDELIVERED"},
+			{Header: "ERRORS"}, {Header: "LAST STATUS UPDATE"},
+		},
+		Rows: [][]string{{
+			d.Consumer.GetId(),
+			d.Consumer.GetName(),
+			fmt.Sprintf("%d", d.Bundles.Total),
+			fmt.Sprintf("%d", d.Bundles.PendingDeletion),
+			fmt.Sprintf("%d", d.Bundles.Undelivered),
+			fmt.Sprintf("%d", d.Bundles.Errors),
+			formatTime(d.Bundles.LastStatusUpdate),
+		}},
+	}
+}
+
+func printConsumerDescription(w io.Writer, d *consumerDescription) error {
+	if err := output.PrintConsumer(w, d.Consumer); err != nil {
+		return err
+	}
+
+	summary := &output.Table{
+		Columns: []output.TableColumn{{Header: "FIELD"}, {Header: "VALUE"}},
+		Rows: [][]string{
+			{"Bundles", fmt.Sprintf("%d", d.Bundles.Total)},
+			{"Pending Deletion", fmt.Sprintf("%d", d.Bundles.PendingDeletion)},
+			{"Undelivered", fmt.Sprintf("%d", d.Bundles.Undelivered)},
+			{"Errors", fmt.Sprintf("%d", d.Bundles.Errors)},
+			{"Last Status Update", formatTime(d.Bundles.LastStatusUpdate)},
+		},
+	}
+	fmt.Fprintln(w, "\nResource Bundles:")
+	if err := summary.Print(w, false, true); err != nil {
+		return err
+	}
+
+	if len(d.Bundles.ConditionStates) > 0 {
+		states := &output.Table{Columns: []output.TableColumn{{Header: "CONDITIONS"}, {Header: "BUNDLES"}}}
+		for _, state := range d.Bundles.ConditionStates {
+			states.Rows = append(states.Rows, []string{state.Conditions, fmt.Sprintf("%d", state.Count)})
+		}
+		fmt.Fprintln(w, "\nCondition States:")
+		if err := states.Print(w, false, false); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w, "\nRecent Errors:")
+	if len(d.Bundles.RecentErrors) == 0 {
+		fmt.Fprintln(w, "<none>")
+		return nil
+	}
+	errorTable := &output.Table{
+		Columns: []output.TableColumn{
+			{Header: "TIME"}, {Header: "BUNDLE"}, {Header: "MANIFEST"}, {Header: "CONDITION"}, {Header: "REASON"}, {Header: "MESSAGE"},
+		},
+	}
+	for _, e := range d.Bundles.RecentErrors {
+		manifest := e.Manifest
+		if manifest == "" {
+			manifest = "-"
+		}
+		errorTable.Rows = append(errorTable.Rows, []string{formatTime(e.Time), e.BundleID, manifest, e.Type, e.Reason, e.Message})
+	}
+	return errorTable.Print(w, false, false)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
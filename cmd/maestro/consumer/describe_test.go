@@ -0,0 +1,269 @@
+package consumer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients/mock"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func TestRunDescribe(t *testing.T) {
+	server := mock.NewMaestroServer()
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		args        []string
+		output      string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:    "successful describe with table format",
+			args:    []string{"consumer-1"},
+			output:  "table",
+			wantErr: false,
+		},
+		{
+			name:    "successful describe with yaml format",
+			args:    []string{"consumer-1"},
+			output:  "yaml",
+			wantErr: false,
+		},
+		{
+			name:    "successful describe with wide format",
+			args:    []string{"consumer-1"},
+			output:  "wide",
+			wantErr: false,
+		},
+		{
+			name:        "consumer not found",
+			args:        []string{"not-found"},
+			output:      "table",
+			wantErr:     true,
+			errContains: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupTestEnv(t, server)
+			defer cleanup()
+
+			cmd := &cobra.Command{}
+			clients.AddRESTClientFlags(cmd)
+			output.AddFormatFlag(cmd)
+
+			// Parse flags to initialize them
+			if err := cmd.ParseFlags([]string{}); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+
+			cmd.Flags().Set(output.FlagOutput, tt.output)
+
+			err := runDescribe(cmd, tt.args)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runDescribe() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("runDescribe() error = %v, should contain %v", err, tt.errContains)
+				}
+			}
+		})
+	}
+}
+
+func testCondition(condType, status, reason string, transitionTime time.Time) interface{} {
+	return map[string]interface{}{
+		"type":               condType,
+		"status":             status,
+		"reason":             reason,
+		"lastTransitionTime": transitionTime.Format(time.RFC3339),
+	}
+}
+
+func TestDescribeConsumer(t *testing.T) {
+	consumer := &openapi.Consumer{Id: openapi.PtrString("consumer-1"), Name: openapi.PtrString("cluster1")}
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	deletedAt := base
+
+	bundles := []openapi.ResourceBundle{
+		{
+			Id: openapi.PtrString("applied-1"),
+			Status: map[string]interface{}{
+				"conditions": []interface{}{
+					testCondition("Applied", "True", "AppliedManifestWorkComplete", base),
+					testCondition("Available", "True", "ResourcesAvailable", base.Add(time.Minute)),
+				},
+			},
+		},
+		{
+			Id: openapi.PtrString("applied-2"),
+			Status: map[string]interface{}{
+				"conditions": []interface{}{
+					testCondition("Available", "True", "ResourcesAvailable", base),
+					testCondition("Applied", "True", "AppliedManifestWorkComplete", base),
+				},
+			},
+		},
+		{
+			Id: openapi.PtrString("failed"),
+			Status: map[string]interface{}{
+				"conditions": []interface{}{
+					testCondition("Applied", "False", "AppliedManifestWorkFailed", base.Add(2*time.Minute)),
+				},
+				"resourceStatus": []interface{}{
+					map[string]interface{}{
+						"resourceMeta": map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "nginx"},
+						"conditions": []interface{}{
+							testCondition("Applied", "False", "AppliedManifestFailed", base.Add(3*time.Minute)),
+						},
+					},
+				},
+			},
+		},
+		{
+			Id: openapi.PtrString("undelivered"),
+		},
+		{
+			Id:        openapi.PtrString("deleting"),
+			DeletedAt: &deletedAt,
+			Status: map[string]interface{}{
+				"conditions": []interface{}{
+					testCondition("Applied", "True", "AppliedManifestWorkComplete", base),
+					testCondition("Available", "True", "ResourcesAvailable", base),
+				},
+			},
+		},
+	}
+
+	description := describeConsumer(consumer, bundles)
+	summary := description.Bundles
+
+	if summary.Total != 5 || summary.PendingDeletion != 1 || summary.Undelivered != 1 {
+		t.Errorf("describeConsumer() total = %d, pending deletion = %d, undelivered = %d, want 5, 1 and 1",
+			summary.Total, summary.PendingDeletion, summary.Undelivered)
+	}
+
+	wantStates := []conditionState{
+		{Conditions: "Applied=True,Available=True", Count: 3},
+		{Conditions: "<none>", Count: 1},
+		{Conditions: "Applied=False", Count: 1},
+	}
+	if fmt.Sprint(summary.ConditionStates) != fmt.Sprint(wantStates) {
+		t.Errorf("describeConsumer() condition states = %v, want %v", summary.ConditionStates, wantStates)
+	}
+
+	if summary.LastStatusUpdate == nil || !summary.LastStatusUpdate.Equal(base.Add(3*time.Minute)) {
+		t.Errorf("describeConsumer() last status update = %v, want %v", summary.LastStatusUpdate, base.Add(3*time.Minute))
+	}
+
+	if summary.Errors != 2 || len(summary.RecentErrors) != 2 {
+		t.Fatalf("describeConsumer() errors = %d, recent errors = %v, want 2 errors", summary.Errors, summary.RecentErrors)
+	}
+	if summary.RecentErrors[0].Manifest != "deployment/default/nginx" || summary.RecentErrors[0].Reason != "AppliedManifestFailed" {
+		t.Errorf("describeConsumer() most recent error = %+v, want the error of deployment/default/nginx", summary.RecentErrors[0])
+	}
+	if summary.RecentErrors[1].BundleID != "failed" || summary.RecentErrors[1].Manifest != "" {
+		t.Errorf("describeConsumer() second error = %+v, want the error of the failed resource bundle", summary.RecentErrors[1])
+	}
+
+	var buf bytes.Buffer
+	if err := printConsumerDescription(&buf, description); err != nil {
+		t.Fatalf("printConsumerDescription() error = %v", err)
+	}
+	for _, want := range []string{"Pending Deletion", "Condition States:", "Applied=True,Available=True", "AppliedManifestFailed"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("printConsumerDescription() output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestDescribeConsumer_HealthyConditions(t *testing.T) {
+	consumer := &openapi.Consumer{Id: openapi.PtrString("consumer-1"), Name: openapi.PtrString("cluster1")}
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	bundles := []openapi.ResourceBundle{
+		{
+			Id: openapi.PtrString("healthy"),
+			Status: map[string]interface{}{
+				"conditions": []interface{}{
+					testCondition("Applied", "True", "AppliedManifestWorkComplete", base),
+					testCondition("Available", "True", "ResourcesAvailable", base),
+					testCondition("Progressing", "False", "Completed", base),
+					testCondition("Degraded", "False", "AsExpected", base),
+				},
+			},
+		},
+		{
+			Id: openapi.PtrString("degraded"),
+			Status: map[string]interface{}{
+				"conditions": []interface{}{
+					testCondition("Applied", "True", "AppliedManifestWorkComplete", base),
+					testCondition("Available", "True", "ResourcesAvailable", base),
+					testCondition("Degraded", "True", "ProbeFailed", base.Add(time.Minute)),
+				},
+			},
+		},
+	}
+
+	summary := describeConsumer(consumer, bundles).Bundles
+	if summary.Errors != 1 || len(summary.RecentErrors) != 1 {
+		t.Fatalf("describeConsumer() errors = %d, recent errors = %v, want 1 error", summary.Errors, summary.RecentErrors)
+	}
+	if got := summary.RecentErrors[0]; got.BundleID != "degraded" || got.Type != "Degraded" {
+		t.Errorf("describeConsumer() error = %+v, want the Degraded condition of the degraded resource bundle", got)
+	}
+}
+
+func TestDescribeConsumer_RecentErrorLimit(t *testing.T) {
+	consumer := &openapi.Consumer{Id: openapi.PtrString("consumer-1"), Name: openapi.PtrString("cluster1")}
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var bundles []openapi.ResourceBundle
+	for i := 0; i < recentErrorLimit+5; i++ {
+		bundles = append(bundles, openapi.ResourceBundle{
+			Id: openapi.PtrString(fmt.Sprintf("bundle-%d", i)),
+			Status: map[string]interface{}{
+				"conditions": []interface{}{
+					testCondition("Applied", "False", "AppliedManifestWorkFailed", base.Add(time.Duration(i)*time.Minute)),
+				},
+			},
+		})
+	}
+
+	description := describeConsumer(consumer, bundles)
+	summary := description.Bundles
+	if len(summary.RecentErrors) != recentErrorLimit {
+		t.Fatalf("describeConsumer() recent errors = %d, want %d", len(summary.RecentErrors), recentErrorLimit)
+	}
+	if last := fmt.Sprintf("bundle-%d", recentErrorLimit+4); summary.RecentErrors[0].BundleID != last {
+		t.Errorf("describeConsumer() most recent error = %s, want %s", summary.RecentErrors[0].BundleID, last)
+	}
+	if summary.Errors != recentErrorLimit+5 {
+		t.Errorf("describeConsumer() errors = %d, want %d", summary.Errors, recentErrorLimit+5)
+	}
+
+	// the ERRORS column counts all the errors, not only the recent ones
+	table := description.table()
+	for i, column := range table.Columns {
+		if column.Header != "ERRORS" {
+			continue
+		}
+		if got, want := table.Rows[0][i], fmt.Sprintf("%d", recentErrorLimit+5); got != want {
+			t.Errorf("table() ERRORS = %s, want %s", got, want)
+		}
+	}
+}
@@ -57,14 +57,10 @@ func runGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output the result
-	format, err := output.GetFormat(cmd)
+	opts, err := output.GetOptions(cmd)
 	if err != nil {
 		return err
 	}
 
-	if format == output.FormatTable {
-		return output.PrintConsumer(os.Stdout, consumer)
-	}
-
-	return output.PrintJSON(os.Stdout, consumer)
+	return output.PrintObject(os.Stdout, opts, output.ConsumerObject(consumer))
 }
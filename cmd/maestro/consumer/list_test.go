@@ -45,6 +45,48 @@ func TestRunList(t *testing.T) {
 			search:  "name like 'test%'",
 			wantErr: false,
 		},
+		{
+			name:    "successful list with yaml format",
+			output:  "yaml",
+			page:    1,
+			size:    10,
+			wantErr: false,
+		},
+		{
+			name:    "successful list with wide format",
+			output:  "wide",
+			page:    1,
+			size:    10,
+			wantErr: false,
+		},
+		{
+			name:    "successful list with name format",
+			output:  "name",
+			page:    1,
+			size:    10,
+			wantErr: false,
+		},
+		{
+			name:    "successful list with jsonpath format",
+			output:  "jsonpath={.items[*].id}",
+			page:    1,
+			size:    10,
+			wantErr: false,
+		},
+		{
+			name:    "successful list with custom-columns format",
+			output:  "custom-columns=ID:.id,NAME:.name",
+			page:    1,
+			size:    10,
+			wantErr: false,
+		},
+		{
+			name:    "list with jsonpath format without template",
+			output:  "jsonpath",
+			page:    1,
+			size:    10,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,14 +119,10 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output the result
-	format, err := output.GetFormat(cmd)
+	opts, err := output.GetOptions(cmd)
 	if err != nil {
 		return err
 	}
 
-	if format == output.FormatTable {
-		return output.PrintConsumer(os.Stdout, updated)
-	}
-
-	return output.PrintJSON(os.Stdout, updated)
+	return output.PrintObject(os.Stdout, opts, output.ConsumerObject(updated))
 }
@@ -14,6 +14,7 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/builder"
 
 	envtypes "github.com/openshift-online/maestro/cmd/maestro/environments/types"
+	"github.com/openshift-online/maestro/pkg/audit"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
 	"github.com/openshift-online/maestro/pkg/config"
@@ -131,9 +132,20 @@ func (e *Env) LoadServices() {
 	e.Services.Events = NewEventServiceLocator(e)
 	e.Services.StatusEvents = NewStatusEventServiceLocator(e)
 	e.Services.Consumers = NewConsumerServiceLocator(e)
+	e.Services.AuditRecords = NewAuditRecordServiceLocator(e)
+	e.Services.Idempotency = NewIdempotencyServiceLocator(e)
 }
 
 func (e *Env) LoadClients() error {
+	// Create the audit log sink first, the services used by the other clients record their mutations to it
+	if e.Config.Audit.LogFile != "" {
+		sink, err := audit.NewFileSink(e.Config.Audit.LogFile)
+		if err != nil {
+			return fmt.Errorf("Unable to create audit log sink: %v", err)
+		}
+		e.Clients.AuditSink = sink
+	}
+
 	// Create CloudEvents Source client
 	if e.Config.MessageBroker.EnableMock {
 		klog.V(4).Info("Using Mock CloudEvents Source Client")
@@ -161,11 +173,24 @@ func (e *Env) LoadClients() error {
 		}
 	}
 
-	// Create GRPC authorizer based on configuration
-	if e.Config.GRPCServer.EnableGRPCServer {
-		if e.Config.GRPCServer.GRPCAuthNType == "mock" {
+	// Create GRPC authorizer based on configuration, it is shared by the gRPC server and the gRPC broker
+	grpcBrokerEnabled := e.Config.MessageBroker.MessageBrokerType == "grpc"
+	if e.Config.GRPCServer.EnableGRPCServer || grpcBrokerEnabled {
+		mockServerAuthN := !e.Config.GRPCServer.EnableGRPCServer || e.Config.GRPCServer.GRPCAuthNType == "mock"
+		mockBrokerAuthN := !grpcBrokerEnabled || e.Config.GRPCServer.BrokerGRPCAuthNType == "mock"
+		mockAuthN := mockServerAuthN && mockBrokerAuthN
+		if mockAuthN {
 			klog.V(4).Info("Using Mock GRPC Authorizer")
 			e.Clients.GRPCAuthorizer = grpcauthorizer.NewMockGRPCAuthorizer()
+		} else if isPolicy, err := isPolicyFile(e.Config.GRPCServer.GRPCAuthorizerConfig); err != nil {
+			return fmt.Errorf("Unable to read gRPC authorizer config %s: %v", e.Config.GRPCServer.GRPCAuthorizerConfig, err)
+		} else if isPolicy {
+			klog.V(4).Infof("Using File GRPC Authorizer with policy file %s", e.Config.GRPCServer.GRPCAuthorizerConfig)
+			fileAuthorizer, err := grpcauthorizer.NewFileGRPCAuthorizer(e.Config.GRPCServer.GRPCAuthorizerConfig)
+			if err != nil {
+				return fmt.Errorf("Unable to create file GRPC authorizer: %v", err)
+			}
+			e.Clients.GRPCAuthorizer = fileAuthorizer
 		} else {
 			kubeConfig, err := clientcmd.BuildConfigFromFlags("", e.Config.GRPCServer.GRPCAuthorizerConfig)
 			if err != nil {
@@ -181,11 +206,42 @@ func (e *Env) LoadClients() error {
 			}
 			e.Clients.GRPCAuthorizer = grpcauthorizer.NewKubeGRPCAuthorizer(kubeClient)
 		}
+
+		if !mockAuthN && e.Config.GRPCServer.GRPCOIDCConfig != "" {
+			oidcConfig, err := grpcauthorizer.LoadOIDCConfig(e.Config.GRPCServer.GRPCOIDCConfig)
+			if err != nil {
+				return fmt.Errorf("Unable to load OIDC config: %v", err)
+			}
+			klog.V(4).Infof("Using OIDC token authentication with %d issuers", len(oidcConfig.Issuers))
+			e.Clients.GRPCAuthorizer, err = grpcauthorizer.NewOIDCGRPCAuthorizer(oidcConfig, e.Clients.GRPCAuthorizer)
+			if err != nil {
+				return fmt.Errorf("Unable to create OIDC GRPC authorizer: %v", err)
+			}
+		}
+
+		cacheConfig := grpcauthorizer.CacheConfig{
+			AllowTTL: e.Config.GRPCServer.AuthorizerCacheAllowTTL,
+			DenyTTL:  e.Config.GRPCServer.AuthorizerCacheDenyTTL,
+			MaxSize:  e.Config.GRPCServer.AuthorizerCacheSize,
+		}
+		if !mockAuthN && cacheConfig.Enabled() {
+			klog.V(4).Infof("Caching GRPC authorizer decisions, allow ttl %s, deny ttl %s, size %d",
+				cacheConfig.AllowTTL, cacheConfig.DenyTTL, cacheConfig.MaxSize)
+			e.Clients.GRPCAuthorizer = grpcauthorizer.NewCachingGRPCAuthorizer(e.Clients.GRPCAuthorizer, cacheConfig)
+		}
 	}
 
 	return nil
 }
 
+// isPolicyFile returns true if the gRPC authorizer config is a policy file rather than a kubeconfig.
+func isPolicyFile(authorizerConfig string) (bool, error) {
+	if authorizerConfig == "" {
+		return false, nil
+	}
+	return grpcauthorizer.IsPolicyFile(authorizerConfig)
+}
+
 func (e *Env) Teardown() {
 	if e.Name != envtypes.TestingEnv {
 		if err := e.Database.SessionFactory.Close(); err != nil {
@@ -13,8 +13,10 @@ func NewResourceServiceLocator(env *Env) ResourceServiceLocator {
 		return services.NewResourceService(
 			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
 			dao.NewResourceDao(&env.Database.SessionFactory),
+			dao.NewConsumerDao(&env.Database.SessionFactory),
 			env.Services.Events(),
 			env.Services.Generic(),
+			env.Services.AuditRecords(),
 		)
 	}
 }
@@ -49,6 +51,29 @@ func NewConsumerServiceLocator(env *Env) ConsumerServiceLocator {
 	return func() services.ConsumerService {
 		return services.NewConsumerService(
 			dao.NewConsumerDao(&env.Database.SessionFactory),
+			env.Services.AuditRecords(),
+		)
+	}
+}
+
+type AuditRecordServiceLocator func() services.AuditRecordService
+
+func NewAuditRecordServiceLocator(env *Env) AuditRecordServiceLocator {
+	return func() services.AuditRecordService {
+		return services.NewAuditRecordService(
+			dao.NewAuditRecordDao(&env.Database.SessionFactory),
+			env.Clients.AuditSink,
+		)
+	}
+}
+
+type IdempotencyServiceLocator func() services.IdempotencyService
+
+func NewIdempotencyServiceLocator(env *Env) IdempotencyServiceLocator {
+	return func() services.IdempotencyService {
+		return services.NewIdempotencyService(
+			dao.NewIdempotencyRecordDao(&env.Database.SessionFactory),
+			env.Config.Idempotency.KeyTTL,
 		)
 	}
 }
@@ -3,6 +3,7 @@ package environments
 import (
 	"sync"
 
+	"github.com/openshift-online/maestro/pkg/audit"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
 	"github.com/openshift-online/maestro/pkg/config"
@@ -42,11 +43,14 @@ type Services struct {
 	Events       EventServiceLocator
 	StatusEvents StatusEventServiceLocator
 	Consumers    ConsumerServiceLocator
+	AuditRecords AuditRecordServiceLocator
+	Idempotency  IdempotencyServiceLocator
 }
 
 type Clients struct {
 	GRPCAuthorizer    grpcauthorizer.GRPCAuthorizer
 	CloudEventsSource cloudevents.SourceClient
+	AuditSink         audit.Sink
 }
 
 type ConfigDefaults struct {
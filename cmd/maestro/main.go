@@ -9,10 +9,13 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/openshift-online/maestro/cmd/maestro/agent"
+	"github.com/openshift-online/maestro/cmd/maestro/archive"
+	"github.com/openshift-online/maestro/cmd/maestro/config"
 	"github.com/openshift-online/maestro/cmd/maestro/consumer"
 	"github.com/openshift-online/maestro/cmd/maestro/migrate"
 	"github.com/openshift-online/maestro/cmd/maestro/resourcebundle"
 	"github.com/openshift-online/maestro/cmd/maestro/servecmd"
+	"github.com/openshift-online/maestro/cmd/maestro/top"
 )
 
 // nolint
@@ -43,9 +46,13 @@ func main() {
 	agentCmd := agent.NewAgentCommand()
 	consumerCmd := consumer.NewConsumerCommand()
 	resourceBundleCmd := resourcebundle.NewResourceBundleCommand()
+	configCmd := config.NewConfigCommand()
+	exportCmd := archive.NewExportCommand()
+	importCmd := archive.NewImportCommand()
+	topCmd := top.NewTopCommand()
 
 	// Add subcommand(s)
-	rootCmd.AddCommand(migrateCmd, serveCmd, agentCmd, consumerCmd, resourceBundleCmd)
+	rootCmd.AddCommand(migrateCmd, serveCmd, agentCmd, consumerCmd, resourceBundleCmd, configCmd, exportCmd, importCmd, topCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatalf("error running command: %v", err)
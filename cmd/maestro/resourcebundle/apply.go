@@ -2,7 +2,6 @@ package resourcebundle
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 
@@ -11,22 +10,27 @@ import (
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 
 	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 )
 
 func newApplyCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "apply -f <file>",
+		Use:   "apply -f <file|dir>",
 		Short: "Create or update a resource bundle",
-		Long: `Create or update a resource bundle from a manifest file (JSON format).
+		Long: `Create or update resource bundles from manifest files (JSON or YAML format).
 
-This command reads a JSON manifest file and publishes it via gRPC:
+This command reads the manifest files and publishes each resource bundle via gRPC:
 - If 'id' is not specified in the manifest, a new resource bundle will be created
   with a generated UUID
 - If 'id' is specified, the existing resource bundle will be updated (errors if
   the resource bundle doesn't exist)
 
-The manifest file should contain:
+The -f flag accepts a file or a directory. A YAML file may have multiple documents
+separated by '---', each one is a resource bundle. For a directory, all the .json,
+.yaml and .yml files are read in lexical order, use -R to read the subdirectories.
+
+Each manifest file should contain:
 - id: Resource bundle ID (optional - if not provided, a UUID will be generated)
 - name: User-friendly external identifier, must be globally unique (optional - if not
   provided, defaults to the same value as 'id')
@@ -38,8 +42,19 @@ The manifest file should contain:
 - manifest_configs: Optional manifest configurations
 - delete_option: Optional delete options
 
+With --consumer and --name, the files contain raw Kubernetes manifests instead, they
+are applied as one resource bundle with that name. The resource bundle is created if
+no resource bundle has the name, otherwise it is updated.
+
+With --dry-run, the server runs all validation, version and consumer checks and prints the
+would-be resource bundle without persisting it.
+
 Examples:
   maestro resourcebundle apply -f bundle.json
+  maestro resourcebundle apply -f bundles.yaml
+  maestro resourcebundle apply -f bundles/ -R
+  maestro resourcebundle apply -f manifests/ --consumer cluster1 --name nginx
+  maestro resourcebundle apply -f bundle.json --dry-run
   maestro resourcebundle apply -f bundle.json --grpc-server-address localhost:8090`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := runApply(cmd, args); err != nil {
@@ -49,27 +64,16 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringP("file", "f", "", "Path to the manifest file (required)")
-	cmd.MarkFlagRequired("file")
+	addInputFlags(cmd)
+	cmd.Flags().Bool("dry-run", false, "Check the resource bundle on the server and print the would-be result without applying it")
 
 	return cmd
 }
 
 func runApply(cmd *cobra.Command, _ []string) error {
-	// Read and parse the manifest file
-	filePath, err := cmd.Flags().GetString("file")
+	dryRun, err := cmd.Flags().GetBool("dry-run")
 	if err != nil {
-		return fmt.Errorf("failed to read --file flag: %w", err)
-	}
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to read manifest file: %w", err)
-	}
-
-	// Parse manifest file (JSON format only)
-	var bundle openapi.ResourceBundle
-	if err := json.Unmarshal(data, &bundle); err != nil {
-		return fmt.Errorf("failed to parse manifest file: %w", err)
+		return fmt.Errorf("failed to read --dry-run flag: %w", err)
 	}
 
 	// Load client configuration
@@ -84,6 +88,14 @@ func runApply(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to create REST client: %w", err)
 	}
 
+	ctx := context.Background()
+
+	// Read and parse the manifest files
+	bundles, err := loadResourceBundles(ctx, cmd, restClient)
+	if err != nil {
+		return err
+	}
+
 	// Create gRPC client
 	grpcClient, err := clients.NewGRPCClient(cfg)
 	if err != nil {
@@ -91,8 +103,17 @@ func runApply(cmd *cobra.Command, _ []string) error {
 	}
 	defer grpcClient.Close()
 
-	ctx := context.Background()
+	for i := range bundles {
+		if err := applyResourceBundle(ctx, cmd, restClient, grpcClient, &bundles[i], dryRun); err != nil {
+			return err
+		}
+	}
 
+	return nil
+}
+
+func applyResourceBundle(ctx context.Context, cmd *cobra.Command, restClient *clients.RESTClient, grpcClient *clients.GRPCClient,
+	bundle *openapi.ResourceBundle, dryRun bool) error {
 	// Determine action based on whether ID was provided
 	var action cetypes.EventAction
 
@@ -122,8 +143,16 @@ func runApply(cmd *cobra.Command, _ []string) error {
 		action = cetypes.CreateRequestAction
 	}
 
+	if dryRun {
+		result, err := grpcClient.DryRunApply(ctx, bundle, action)
+		if err != nil {
+			return fmt.Errorf("dry run of resource bundle failed: %w", err)
+		}
+		return output.PrintJSON(cmd.OutOrStdout(), result)
+	}
+
 	// Apply the resource bundle via gRPC
-	if err := grpcClient.Apply(ctx, &bundle, action); err != nil {
+	if err := grpcClient.Apply(ctx, bundle, action); err != nil {
 		return fmt.Errorf("failed to apply resource bundle: %w", err)
 	}
 
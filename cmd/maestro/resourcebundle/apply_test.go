@@ -1,6 +1,7 @@
 package resourcebundle
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -111,6 +112,10 @@ func TestRunApply(t *testing.T) {
 			clients.AddRESTClientFlags(cmd)
 			clients.AddGRPCClientFlags(cmd, "test-source")
 			cmd.Flags().StringP("file", "f", "", "Path to the manifest file")
+			cmd.Flags().Bool("dry-run", false, "Dry run")
+			cmd.Flags().BoolP("recursive", "R", false, "Recursive")
+			cmd.Flags().String("consumer", "", "Consumer name")
+			cmd.Flags().String("name", "", "Resource bundle name")
 
 			// Parse flags to initialize them
 			if err := cmd.ParseFlags([]string{}); err != nil {
@@ -152,6 +157,10 @@ func TestRunApply_FileNotFound(t *testing.T) {
 	clients.AddRESTClientFlags(cmd)
 	clients.AddGRPCClientFlags(cmd, "test-source")
 	cmd.Flags().StringP("file", "f", "", "Path to the manifest file")
+	cmd.Flags().Bool("dry-run", false, "Dry run")
+	cmd.Flags().BoolP("recursive", "R", false, "Recursive")
+	cmd.Flags().String("consumer", "", "Consumer name")
+	cmd.Flags().String("name", "", "Resource bundle name")
 
 	// Parse flags to initialize them
 	if err := cmd.ParseFlags([]string{}); err != nil {
@@ -171,3 +180,212 @@ func TestRunApply_FileNotFound(t *testing.T) {
 		t.Errorf("runApply() error = %v, should contain 'failed to read manifest file'", err)
 	}
 }
+
+func TestRunApply_DryRun(t *testing.T) {
+	server := mock.NewMaestroServer()
+	defer server.Close()
+
+	grpcServer, err := mock.NewGRPCServer()
+	if err != nil {
+		t.Fatalf("Failed to create gRPC server: %v", err)
+	}
+	defer grpcServer.Stop()
+
+	cleanup := setupTestEnv(t, server, grpcServer)
+	defer cleanup()
+
+	manifestFile := filepath.Join(t.TempDir(), "manifest.json")
+	manifest := `{
+		"id": "bundle-1",
+		"consumer_name": "test-consumer",
+		"version": 1,
+		"manifests": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test-cm"}}]
+	}`
+	if err := os.WriteFile(manifestFile, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to create manifest file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	clients.AddRESTClientFlags(cmd)
+	clients.AddGRPCClientFlags(cmd, "test-source")
+	cmd.Flags().StringP("file", "f", "", "Path to the manifest file")
+	cmd.Flags().Bool("dry-run", false, "Dry run")
+	cmd.Flags().BoolP("recursive", "R", false, "Recursive")
+	cmd.Flags().String("consumer", "", "Consumer name")
+	cmd.Flags().String("name", "", "Resource bundle name")
+	if err := cmd.ParseFlags([]string{"--file", manifestFile, "--dry-run"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+
+	if err := runApply(cmd, []string{}); err != nil {
+		t.Fatalf("runApply() error = %v", err)
+	}
+
+	if events := grpcServer.GetPublishedEvents(); len(events) != 0 {
+		t.Errorf("expected no published events, but got %d", len(events))
+	}
+	if !strings.Contains(out.String(), `"id": "bundle-1"`) || !strings.Contains(out.String(), `"version": 2`) {
+		t.Errorf("unexpected dry-run output: %s", out.String())
+	}
+}
+
+func TestRunApply_Input(t *testing.T) {
+	server := mock.NewMaestroServer()
+	defer server.Close()
+
+	grpcServer, err := mock.NewGRPCServer()
+	if err != nil {
+		t.Fatalf("Failed to create gRPC server: %v", err)
+	}
+	defer grpcServer.Stop()
+
+	bundleYAML := `consumer_name: test-consumer
+manifests:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: test-cm
+`
+	configMapYAML := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-cm
+  namespace: default
+`
+	deploymentYAML := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deploy
+  namespace: default
+`
+
+	tests := []struct {
+		name          string
+		files         map[string]string
+		file          string
+		flags         []string
+		wantPublished int
+		wantErr       bool
+		errContains   string
+	}{
+		{
+			name:          "yaml file",
+			files:         map[string]string{"bundle.yaml": bundleYAML},
+			file:          "bundle.yaml",
+			wantPublished: 1,
+		},
+		{
+			name:          "multi-document yaml file",
+			files:         map[string]string{"bundles.yaml": bundleYAML + "---\n" + bundleYAML + "---\n"},
+			file:          "bundles.yaml",
+			wantPublished: 2,
+		},
+		{
+			name: "directory",
+			files: map[string]string{
+				"a.yaml":       bundleYAML,
+				"b.json":       `{"consumer_name": "test-consumer", "manifests": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test-cm"}}]}`,
+				"README.md":    "# bundles",
+				"sub/c.yml":    bundleYAML,
+				"sub/d/e.yaml": bundleYAML,
+			},
+			file:          ".",
+			wantPublished: 2,
+		},
+		{
+			name: "recursive directory",
+			files: map[string]string{
+				"a.yaml":       bundleYAML,
+				"sub/c.yml":    bundleYAML,
+				"sub/d/e.yaml": bundleYAML,
+			},
+			file:          ".",
+			flags:         []string{"-R"},
+			wantPublished: 3,
+		},
+		{
+			name: "kubernetes manifests",
+			files: map[string]string{
+				"cm.yaml":     configMapYAML,
+				"deploy.yaml": deploymentYAML,
+			},
+			file:          ".",
+			flags:         []string{"--consumer", "test-consumer", "--name", "test-app"},
+			wantPublished: 1,
+		},
+		{
+			name:        "kubernetes manifests without consumer",
+			files:       map[string]string{"cm.yaml": configMapYAML},
+			file:        "cm.yaml",
+			wantErr:     true,
+			errContains: "use --consumer and --name",
+		},
+		{
+			name:        "resource bundle with consumer",
+			files:       map[string]string{"bundle.yaml": bundleYAML},
+			file:        "bundle.yaml",
+			flags:       []string{"--consumer", "test-consumer", "--name", "test-app"},
+			wantErr:     true,
+			errContains: "is not a Kubernetes manifest",
+		},
+		{
+			name:        "consumer without name",
+			files:       map[string]string{"cm.yaml": configMapYAML},
+			file:        "cm.yaml",
+			flags:       []string{"--consumer", "test-consumer"},
+			wantErr:     true,
+			errContains: "--consumer and --name must be set together",
+		},
+		{
+			name:        "empty directory",
+			files:       map[string]string{"README.md": "# bundles"},
+			file:        ".",
+			wantErr:     true,
+			errContains: "no JSON or YAML manifest files found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupTestEnv(t, server, grpcServer)
+			defer cleanup()
+			grpcServer.ClearPublishedEvents()
+
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(tmpDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create manifest file: %v", err)
+				}
+			}
+
+			cmd := newApplyCommand()
+			clients.AddRESTClientFlags(cmd)
+			clients.AddGRPCClientFlags(cmd, "test-source")
+			if err := cmd.ParseFlags(append([]string{"--file", filepath.Join(tmpDir, tt.file)}, tt.flags...)); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+
+			err := runApply(cmd, []string{})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runApply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("runApply() error = %v, should contain %v", err, tt.errContains)
+				}
+			}
+
+			if events := grpcServer.GetPublishedEvents(); len(events) != tt.wantPublished {
+				t.Errorf("expected %d published events, but got %d", tt.wantPublished, len(events))
+			}
+		})
+	}
+}
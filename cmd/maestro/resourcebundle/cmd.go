@@ -18,11 +18,15 @@ func NewResourceBundleCommand() *cobra.Command {
 Resource bundles are collections of Kubernetes manifests that are deployed to consumer clusters.
 
 Commands:
-  apply  - Create or update a resource bundle via gRPC
-  get    - Get a resource bundle by ID via REST API
-  list   - List resource bundles via REST API
-  delete - Delete a resource bundle via gRPC
-  status - Get resource bundle status via REST API`,
+  apply   - Create or update a resource bundle via gRPC
+  get     - Get a resource bundle by ID via REST API
+  list    - List resource bundles via REST API
+  delete  - Delete a resource bundle via gRPC
+  diff    - Diff resource bundles against manifest files via REST API
+  convert - Convert between ManifestWork and resource bundle files
+  status  - Get resource bundle status via REST API
+  watch   - Watch resource bundle status changes via gRPC
+  wait    - Wait for resource bundles to reach a condition via REST API`,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Suppress verbose logs by default for CLI commands
 			// Only suppress if user hasn't set -v flag
@@ -42,7 +46,11 @@ Commands:
 		newGetCommand(),
 		newListCommand(),
 		newDeleteCommand(),
+		newDiffCommand(),
+		newConvertCommand(),
 		newStatusCommand(),
+		newWatchCommand(),
+		newWaitCommand(),
 	)
 
 	return cmd
@@ -0,0 +1,311 @@
+package resourcebundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/client/cloudevents/grpcsource"
+)
+
+const (
+	// convertManifestWork is the OCM ManifestWork format that the grpcsource work client uses
+	convertManifestWork = "manifestwork"
+	// convertBundle is the resource bundle format of the REST API and of resourcebundle apply
+	convertBundle = "bundle"
+
+	manifestWorkKind = "ManifestWork"
+)
+
+func newConvertCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert -f <file|dir> --from manifestwork|bundle --to bundle|manifestwork",
+		Short: "Convert between ManifestWork and resource bundle files",
+		Long: `Convert OCM ManifestWork files to resource bundle files, or resource bundle files to
+ManifestWork files, so that a work definition can be used with both the ManifestWork
+client (grpcsource) and the resource bundle commands.
+
+The conversion maps:
+- the ManifestWork namespace to the consumer name of the resource bundle, --consumer
+  overrides it
+- the ManifestWork name to the resource bundle name
+- the ManifestWork name, namespace, labels and annotations to the resource bundle
+  metadata
+- the workload manifests, manifestConfigs and deleteOption to the manifests,
+  manifest_configs and delete_option of the resource bundle
+
+The server-side fields (ID, version, UID, resourceVersion, generation, timestamps and
+status) are not converted, so the result is a definition that can be applied as a new
+resource bundle or ManifestWork. The -f flag accepts a file or a directory, the same as
+resourcebundle apply. The results are printed as YAML documents separated by '---', or
+as JSON objects with --output json.
+
+Examples:
+  maestro resourcebundle convert -f work.yaml --from manifestwork --to bundle
+  maestro resourcebundle convert -f works/ --from manifestwork --to bundle --consumer cluster1 > bundles.yaml
+  maestro resourcebundle convert -f bundle.json --from bundle --to manifestwork --output json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runConvert(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Path to the file or directory to convert (required)")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().BoolP("recursive", "R", false, "Read the files in the subdirectories of the -f directory")
+	cmd.Flags().String("from", "", "The format of the input files: manifestwork or bundle (required)")
+	_ = cmd.MarkFlagRequired("from")
+	cmd.Flags().String("to", "", "The format to convert to: bundle or manifestwork (required)")
+	_ = cmd.MarkFlagRequired("to")
+	cmd.Flags().String("consumer", "", "The consumer of the results, it overrides the ManifestWork namespace or the resource bundle consumer")
+	cmd.Flags().StringP(output.FlagOutput, "o", string(output.FormatYAML), "Output format: yaml or json")
+
+	return cmd
+}
+
+func runConvert(cmd *cobra.Command, _ []string) error {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to read --file flag: %w", err)
+	}
+	recursive, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		return fmt.Errorf("failed to read --recursive flag: %w", err)
+	}
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return fmt.Errorf("failed to read --from flag: %w", err)
+	}
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return fmt.Errorf("failed to read --to flag: %w", err)
+	}
+	consumerName, err := cmd.Flags().GetString("consumer")
+	if err != nil {
+		return fmt.Errorf("failed to read --consumer flag: %w", err)
+	}
+	format, err := output.GetFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	if format != output.FormatYAML && format != output.FormatJSON {
+		return fmt.Errorf("invalid output format: %s (must be yaml or json)", format)
+	}
+	if (from != convertManifestWork && from != convertBundle) || (to != convertManifestWork && to != convertBundle) {
+		return fmt.Errorf("invalid conversion from %q to %q (must be from %s to %s or from %s to %s)",
+			from, to, convertManifestWork, convertBundle, convertBundle, convertManifestWork)
+	}
+	if from == to {
+		return fmt.Errorf("--from and --to must be different, both are %s", from)
+	}
+
+	docs, err := readInputDocuments(filePath, recursive)
+	if err != nil {
+		return err
+	}
+
+	results := make([]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		var result interface{}
+		if from == convertManifestWork {
+			result, err = convertManifestWorkDocument(doc, consumerName)
+		} else {
+			result, err = convertResourceBundleDocument(doc, consumerName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", doc.source, err)
+		}
+		results = append(results, result)
+	}
+
+	return printConverted(cmd.OutOrStdout(), format, results)
+}
+
+func convertManifestWorkDocument(doc inputDocument, consumerName string) (*openapi.ResourceBundle, error) {
+	if kind := doc.object["kind"]; kind != manifestWorkKind {
+		return nil, fmt.Errorf("the document is a %v, not a %s", kind, manifestWorkKind)
+	}
+
+	data, err := json.Marshal(doc.object)
+	if err != nil {
+		return nil, err
+	}
+	work := &workv1.ManifestWork{}
+	if err := json.Unmarshal(data, work); err != nil {
+		return nil, err
+	}
+	return manifestWorkToResourceBundle(work, consumerName)
+}
+
+func convertResourceBundleDocument(doc inputDocument, consumerName string) (map[string]interface{}, error) {
+	if kind := doc.object["kind"]; kind == manifestWorkKind {
+		return nil, fmt.Errorf("the document is a %s, use --from %s", manifestWorkKind, convertManifestWork)
+	}
+	if doc.isKubernetesManifest() {
+		return nil, fmt.Errorf("the document is a Kubernetes %v manifest, not a resource bundle", doc.object["kind"])
+	}
+
+	bundle, err := decodeResourceBundle(doc.object)
+	if err != nil {
+		return nil, err
+	}
+	work, err := resourceBundleToManifestWork(bundle, consumerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ManifestWork is printed without the empty status and creation timestamp of the Go type
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(work)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(object, "status")
+	unstructured.RemoveNestedField(object, "metadata", "creationTimestamp")
+	return object, nil
+}
+
+// manifestWorkToResourceBundle converts a ManifestWork to a resource bundle of the consumer, the ManifestWork namespace
+// is the consumer if consumerName is empty
+func manifestWorkToResourceBundle(work *workv1.ManifestWork, consumerName string) (*openapi.ResourceBundle, error) {
+	if consumerName == "" {
+		consumerName = work.Namespace
+	}
+	if consumerName == "" {
+		return nil, fmt.Errorf("ManifestWork %q has no namespace, use --consumer to set the consumer of the resource bundle", work.Name)
+	}
+	if work.Name == "" {
+		return nil, fmt.Errorf("the ManifestWork has no name")
+	}
+
+	// the grpcsource work client keeps the ManifestWork name, namespace, labels and annotations in the metadata
+	metadata := map[string]interface{}{"name": work.Name, "namespace": consumerName}
+	if len(work.Labels) != 0 {
+		metadata["labels"] = work.Labels
+	}
+	if len(work.Annotations) != 0 {
+		metadata["annotations"] = work.Annotations
+	}
+	metadata, err := toJSONObject(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &openapi.ResourceBundle{
+		Name:         openapi.PtrString(work.Name),
+		ConsumerName: openapi.PtrString(consumerName),
+		Metadata:     metadata,
+		Manifests:    []map[string]interface{}{},
+	}
+
+	for i, manifest := range work.Spec.Workload.Manifests {
+		object := map[string]interface{}{}
+		if err := json.Unmarshal(manifest.Raw, &object); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest %d: %w", i, err)
+		}
+		bundle.Manifests = append(bundle.Manifests, object)
+	}
+
+	for _, config := range work.Spec.ManifestConfigs {
+		object, err := toJSONObject(config)
+		if err != nil {
+			return nil, err
+		}
+		bundle.ManifestConfigs = append(bundle.ManifestConfigs, object)
+	}
+
+	if work.Spec.DeleteOption != nil {
+		if bundle.DeleteOption, err = toJSONObject(work.Spec.DeleteOption); err != nil {
+			return nil, err
+		}
+	}
+
+	return bundle, nil
+}
+
+// resourceBundleToManifestWork converts a resource bundle to a ManifestWork in the namespace of the consumer, the
+// resource bundle consumer is used if consumerName is empty
+func resourceBundleToManifestWork(bundle *openapi.ResourceBundle, consumerName string) (*workv1.ManifestWork, error) {
+	// the server-side fields are not converted, the version is only set since the conversion requires it
+	definition := *bundle
+	definition.Version = openapi.PtrInt32(0)
+	definition.Status = nil
+
+	work, err := grpcsource.ToManifestWork(&definition)
+	if err != nil {
+		return nil, err
+	}
+
+	work.TypeMeta = metav1.TypeMeta{APIVersion: workv1.GroupVersion.String(), Kind: manifestWorkKind}
+	if work.Name == "" {
+		work.Name = bundle.GetName()
+	}
+	if work.Name == "" {
+		work.Name = bundle.GetId()
+	}
+	if work.Name == "" {
+		return nil, fmt.Errorf("the resource bundle has no name or ID")
+	}
+
+	if consumerName == "" {
+		consumerName = bundle.GetConsumerName()
+	}
+	if consumerName != "" {
+		work.Namespace = consumerName
+	}
+
+	work.UID = ""
+	work.ResourceVersion = ""
+	work.Generation = 0
+	work.CreationTimestamp = metav1.Time{}
+	work.DeletionTimestamp = nil
+	work.ManagedFields = nil
+	work.Status = workv1.ManifestWorkStatus{}
+	return work, nil
+}
+
+// toJSONObject converts a value to its JSON object representation
+func toJSONObject(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// printConverted prints the results as YAML documents or JSON objects
+func printConverted(w io.Writer, format output.Format, results []interface{}) error {
+	for i, result := range results {
+		if format == output.FormatJSON {
+			if err := output.PrintJSON(w, result); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if i > 0 {
+			if _, err := fmt.Fprintln(w, "---"); err != nil {
+				return err
+			}
+		}
+		if err := output.PrintYAML(w, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
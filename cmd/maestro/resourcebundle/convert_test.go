@@ -0,0 +1,272 @@
+package resourcebundle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+const testManifestWork = `apiVersion: work.open-cluster-management.io/v1
+kind: ManifestWork
+metadata:
+  name: nginx-work
+  namespace: cluster1
+  uid: 5c4e2b0a-1111-2222-3333-444455556666
+  resourceVersion: "3"
+  labels:
+    app: nginx
+spec:
+  deleteOption:
+    propagationPolicy: Orphan
+  manifestConfigs:
+  - resourceIdentifier:
+      group: apps
+      resource: deployments
+      name: nginx
+      namespace: default
+    updateStrategy:
+      type: ServerSideApply
+  workload:
+    manifests:
+    - apiVersion: v1
+      kind: ConfigMap
+      metadata:
+        name: nginx
+        namespace: default
+`
+
+func TestRunConvert(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		flags        []string
+		wantContains []string
+		notContains  []string
+		wantErr      bool
+		errContains  string
+	}{
+		{
+			name:    "manifestwork to bundle",
+			content: testManifestWork,
+			flags:   []string{"--from", "manifestwork", "--to", "bundle"},
+			wantContains: []string{
+				"consumer_name: cluster1\n",
+				"name: nginx-work\n",
+				"delete_option:\n  propagationPolicy: Orphan\n",
+				"manifest_configs:\n- resourceIdentifier:\n",
+				"    app: nginx\n",
+			},
+			notContains: []string{"uid", "resourceVersion", "5c4e2b0a"},
+		},
+		{
+			name:         "manifestwork to bundle with another consumer",
+			content:      testManifestWork,
+			flags:        []string{"--from", "manifestwork", "--to", "bundle", "--consumer", "cluster2", "-o", "json"},
+			wantContains: []string{`"consumer_name": "cluster2"`, `"namespace": "cluster2"`},
+		},
+		{
+			name: "bundle to manifestwork",
+			content: `{"id": "bundle-1", "name": "nginx", "consumer_name": "cluster1", "version": 4,
+				"metadata": {"name": "nginx-work", "labels": {"app": "nginx"}, "uid": "old-uid", "resourceVersion": "4"},
+				"manifests": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "nginx", "namespace": "default"}}],
+				"delete_option": {"propagationPolicy": "Foreground"},
+				"status": {"conditions": [{"type": "Applied", "status": "True"}]}}`,
+			flags: []string{"--from", "bundle", "--to", "manifestwork"},
+			wantContains: []string{
+				"apiVersion: work.open-cluster-management.io/v1\nkind: ManifestWork\n",
+				"  name: nginx-work\n  namespace: cluster1\n",
+				"    app: nginx\n",
+				"propagationPolicy: Foreground",
+			},
+			notContains: []string{"status", "uid", "resourceVersion", "generation", "creationTimestamp"},
+		},
+		{
+			name: "multiple documents",
+			content: `name: web
+consumer_name: cluster1
+manifests: []
+---
+name: api
+consumer_name: cluster2
+manifests: []
+`,
+			flags:        []string{"--from", "bundle", "--to", "manifestwork"},
+			wantContains: []string{"  name: web\n  namespace: cluster1\n", "---\n", "  name: api\n  namespace: cluster2\n"},
+		},
+		{
+			name:        "manifestwork without namespace",
+			content:     strings.Replace(testManifestWork, "  namespace: cluster1\n", "", 1),
+			flags:       []string{"--from", "manifestwork", "--to", "bundle"},
+			wantErr:     true,
+			errContains: "use --consumer",
+		},
+		{
+			name:        "manifestwork is not a bundle",
+			content:     testManifestWork,
+			flags:       []string{"--from", "bundle", "--to", "manifestwork"},
+			wantErr:     true,
+			errContains: "use --from manifestwork",
+		},
+		{
+			name:        "bundle is not a manifestwork",
+			content:     `{"name": "nginx", "consumer_name": "cluster1", "manifests": []}`,
+			flags:       []string{"--from", "manifestwork", "--to", "bundle"},
+			wantErr:     true,
+			errContains: "not a ManifestWork",
+		},
+		{
+			name:        "same formats",
+			content:     testManifestWork,
+			flags:       []string{"--from", "bundle", "--to", "bundle"},
+			wantErr:     true,
+			errContains: "must be different",
+		},
+		{
+			name:        "invalid format",
+			content:     testManifestWork,
+			flags:       []string{"--from", "work", "--to", "bundle"},
+			wantErr:     true,
+			errContains: "invalid conversion",
+		},
+		{
+			name:        "invalid output format",
+			content:     testManifestWork,
+			flags:       []string{"--from", "manifestwork", "--to", "bundle", "-o", "table"},
+			wantErr:     true,
+			errContains: "must be yaml or json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "input.yaml")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create input file: %v", err)
+			}
+
+			cmd := newConvertCommand()
+			if err := cmd.ParseFlags(append([]string{"--file", file}, tt.flags...)); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+
+			err := runConvert(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runConvert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("runConvert() error = %v, should contain %v", err, tt.errContains)
+				}
+				return
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("runConvert() output missing %q:\n%s", want, out.String())
+				}
+			}
+			for _, notWant := range tt.notContains {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("runConvert() output should not contain %q:\n%s", notWant, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	work := &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "nginx-work",
+			Namespace:   "cluster1",
+			Labels:      map[string]string{"app": "nginx"},
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		Spec: workv1.ManifestWorkSpec{
+			Workload: workv1.ManifestsTemplate{
+				Manifests: []workv1.Manifest{
+					{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"nginx","namespace":"default"}}`)}},
+				},
+			},
+			DeleteOption: &workv1.DeleteOption{PropagationPolicy: workv1.DeletePropagationPolicyTypeOrphan},
+			ManifestConfigs: []workv1.ManifestConfigOption{
+				{
+					ResourceIdentifier: workv1.ResourceIdentifier{Group: "apps", Resource: "deployments", Name: "nginx", Namespace: "default"},
+					UpdateStrategy:     &workv1.UpdateStrategy{Type: workv1.UpdateStrategyTypeServerSideApply},
+				},
+			},
+		},
+	}
+
+	bundle, err := manifestWorkToResourceBundle(work, "")
+	if err != nil {
+		t.Fatalf("manifestWorkToResourceBundle() error = %v", err)
+	}
+	if bundle.GetName() != "nginx-work" || bundle.GetConsumerName() != "cluster1" || bundle.Id != nil || bundle.Version != nil {
+		t.Errorf("manifestWorkToResourceBundle() = %+v, want the nginx-work resource bundle of cluster1 without an ID and version", bundle)
+	}
+
+	got, err := resourceBundleToManifestWork(bundle, "")
+	if err != nil {
+		t.Fatalf("resourceBundleToManifestWork() error = %v", err)
+	}
+	if got.Kind != manifestWorkKind || got.APIVersion != workv1.GroupVersion.String() {
+		t.Errorf("resourceBundleToManifestWork() type = %s/%s, want %s", got.APIVersion, got.Kind, manifestWorkKind)
+	}
+	if !reflect.DeepEqual(got.ObjectMeta, work.ObjectMeta) {
+		t.Errorf("resourceBundleToManifestWork() metadata = %+v, want %+v", got.ObjectMeta, work.ObjectMeta)
+	}
+	if !reflect.DeepEqual(got.Spec.DeleteOption, work.Spec.DeleteOption) || !reflect.DeepEqual(got.Spec.ManifestConfigs, work.Spec.ManifestConfigs) {
+		t.Errorf("resourceBundleToManifestWork() spec = %+v, want %+v", got.Spec, work.Spec)
+	}
+	if len(got.Spec.Workload.Manifests) != 1 || !strings.Contains(string(got.Spec.Workload.Manifests[0].Raw), `"kind":"ConfigMap"`) {
+		t.Errorf("resourceBundleToManifestWork() manifests = %s, want the config map", got.Spec.Workload.Manifests)
+	}
+}
+
+func TestResourceBundleToManifestWork_Name(t *testing.T) {
+	tests := []struct {
+		name     string
+		bundle   *openapi.ResourceBundle
+		consumer string
+		wantName string
+		wantNS   string
+	}{
+		{
+			name:     "bundle name without metadata",
+			bundle:   &openapi.ResourceBundle{Id: openapi.PtrString("bundle-1"), Name: openapi.PtrString("nginx"), ConsumerName: openapi.PtrString("cluster1")},
+			wantName: "nginx",
+			wantNS:   "cluster1",
+		},
+		{
+			name:     "bundle ID without a name",
+			bundle:   &openapi.ResourceBundle{Id: openapi.PtrString("bundle-1"), ConsumerName: openapi.PtrString("cluster1")},
+			consumer: "cluster2",
+			wantName: "bundle-1",
+			wantNS:   "cluster2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			work, err := resourceBundleToManifestWork(tt.bundle, tt.consumer)
+			if err != nil {
+				t.Fatalf("resourceBundleToManifestWork() error = %v", err)
+			}
+			if work.Name != tt.wantName || work.Namespace != tt.wantNS {
+				t.Errorf("resourceBundleToManifestWork() = %s/%s, want %s/%s", work.Namespace, work.Name, tt.wantNS, tt.wantName)
+			}
+		})
+	}
+}
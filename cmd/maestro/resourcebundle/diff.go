@@ -0,0 +1,244 @@
+package resourcebundle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+const (
+	// diffExitCodeDifferent is the exit code of the diff command if the resource bundles are different
+	diffExitCodeDifferent = 1
+	// diffExitCodeError is the exit code of the diff command if the diff fails
+	diffExitCodeError = 2
+)
+
+func newDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff -f <file|dir>",
+		Short: "Diff the resource bundles on the server against the manifest files",
+		Long: `Diff the resource bundles on the server against the manifest files that would be applied.
+
+The manifest files are read the same way as by the apply command. Each resource bundle
+with an ID is fetched from the server and compared per manifest, the manifests are matched
+by their kind, namespace and name. The added, removed and changed manifests, the manifest
+configs and the delete option are printed as a unified diff. A resource bundle without an
+ID would be created, so all its content is printed as added.
+
+The exit code is 0 if there are no differences, 1 if there are differences and 2 if the
+diff fails.
+
+Examples:
+  maestro resourcebundle diff -f bundle.json
+  maestro resourcebundle diff -f bundles/ -R
+  maestro resourcebundle diff -f manifests/ --consumer cluster1 --name nginx`,
+		Run: func(cmd *cobra.Command, args []string) {
+			different, err := runDiff(cmd, args)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(diffExitCodeError)
+			}
+			if different {
+				os.Exit(diffExitCodeDifferent)
+			}
+		},
+	}
+
+	addInputFlags(cmd)
+
+	return cmd
+}
+
+// runDiff prints the diff of the resource bundles, it returns true if there are differences
+func runDiff(cmd *cobra.Command, _ []string) (bool, error) {
+	// Load REST client configuration
+	cfg, err := clients.LoadRESTConfigFromFlags(cmd)
+	if err != nil {
+		return false, err
+	}
+
+	// Create REST client
+	restClient, err := clients.NewRESTClient(cfg)
+	if err != nil {
+		return false, fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	ctx := context.Background()
+
+	// Read and parse the manifest files
+	bundles, err := loadResourceBundles(ctx, cmd, restClient)
+	if err != nil {
+		return false, err
+	}
+
+	different := false
+	for i := range bundles {
+		local := &bundles[i]
+
+		var current *openapi.ResourceBundle
+		if local.Id != nil && *local.Id != "" {
+			current, err = restClient.GetResourceBundle(ctx, *local.Id)
+			if err != nil {
+				return false, fmt.Errorf("cannot diff resource bundle %q: %w", *local.Id, err)
+			}
+		}
+
+		bundleDifferent, err := diffResourceBundle(cmd.OutOrStdout(), current, local)
+		if err != nil {
+			return false, err
+		}
+		different = different || bundleDifferent
+	}
+
+	return different, nil
+}
+
+// diffSection is a part of a resource bundle that is compared as a whole, a nil value means the section is absent
+type diffSection struct {
+	path    string
+	current interface{}
+	local   interface{}
+}
+
+// diffResourceBundle prints the unified diff of the current resource bundle on the server against the local one,
+// the current resource bundle is nil if it would be created. It returns true if there are differences.
+func diffResourceBundle(w io.Writer, current, local *openapi.ResourceBundle) (bool, error) {
+	if current == nil {
+		current = &openapi.ResourceBundle{}
+	}
+
+	bundlePath := local.GetId()
+	if bundlePath == "" {
+		bundlePath = local.GetName()
+	}
+	if bundlePath == "" {
+		bundlePath = "new"
+	}
+
+	var sections []diffSection
+
+	// the manifests are matched by their keys, the removed manifests are compared first, then the local manifests in
+	// their order
+	localManifests := map[string]map[string]interface{}{}
+	for _, manifest := range local.Manifests {
+		localManifests[manifestKey(manifest)] = manifest
+	}
+	currentManifests := map[string]map[string]interface{}{}
+	for _, manifest := range current.Manifests {
+		key := manifestKey(manifest)
+		currentManifests[key] = manifest
+		if _, ok := localManifests[key]; !ok {
+			sections = append(sections, diffSection{path: "manifests/" + key, current: manifest})
+		}
+	}
+	for _, manifest := range local.Manifests {
+		key := manifestKey(manifest)
+		section := diffSection{path: "manifests/" + key, local: manifest}
+		if currentManifest, ok := currentManifests[key]; ok {
+			section.current = currentManifest
+		}
+		sections = append(sections, section)
+	}
+
+	// an empty section is the same as an absent one
+	configs := diffSection{path: "manifest_configs"}
+	if len(current.ManifestConfigs) > 0 {
+		configs.current = current.ManifestConfigs
+	}
+	if len(local.ManifestConfigs) > 0 {
+		configs.local = local.ManifestConfigs
+	}
+	deleteOption := diffSection{path: "delete_option"}
+	if len(current.DeleteOption) > 0 {
+		deleteOption.current = current.DeleteOption
+	}
+	if len(local.DeleteOption) > 0 {
+		deleteOption.local = local.DeleteOption
+	}
+	sections = append(sections, configs, deleteOption)
+
+	different := false
+	for _, section := range sections {
+		text, err := unifiedDiff(bundlePath, section)
+		if err != nil {
+			return false, err
+		}
+		if text == "" {
+			continue
+		}
+
+		different = true
+		if _, err := io.WriteString(w, text); err != nil {
+			return false, err
+		}
+	}
+	return different, nil
+}
+
+// unifiedDiff returns the unified diff of the YAML of a section, it is empty if there are no differences
+func unifiedDiff(bundlePath string, section diffSection) (string, error) {
+	fromFile := fmt.Sprintf("a/%s/%s", bundlePath, section.path)
+	toFile := fmt.Sprintf("b/%s/%s", bundlePath, section.path)
+
+	currentYAML, err := toDiffYAML(section.current)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert %s to YAML: %w", fromFile, err)
+	}
+	if section.current == nil {
+		fromFile = "/dev/null"
+	}
+
+	localYAML, err := toDiffYAML(section.local)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert %s to YAML: %w", toFile, err)
+	}
+	if section.local == nil {
+		toFile = "/dev/null"
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(currentYAML),
+		B:        diffLines(localYAML),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// diffLines splits the text into lines, an empty text has no lines
+func diffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return difflib.SplitLines(text)
+}
+
+func toDiffYAML(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// manifestKey returns the manifest as kind/namespace/name, or kind/name for a cluster scoped manifest
+func manifestKey(manifest map[string]interface{}) string {
+	kind, _ := manifest["kind"].(string)
+	metadata, _ := manifest["metadata"].(map[string]interface{})
+	namespace, _ := metadata["namespace"].(string)
+	name, _ := metadata["name"].(string)
+	return output.ManifestStatus{Kind: kind, Namespace: namespace, Name: name}.Key()
+}
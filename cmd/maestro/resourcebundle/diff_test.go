@@ -0,0 +1,172 @@
+package resourcebundle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients/mock"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func TestRunDiff(t *testing.T) {
+	server := mock.NewMaestroServer()
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		manifest      string
+		wantDifferent bool
+		wantContains  []string
+		wantErr       bool
+		errContains   string
+	}{
+		{
+			name:          "no differences",
+			manifest:      `{"id": "bundle-1", "consumer_name": "test-consumer"}`,
+			wantDifferent: false,
+		},
+		{
+			name: "added manifest",
+			manifest: `{"id": "bundle-1", "consumer_name": "test-consumer",
+				"manifests": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test-cm", "namespace": "default"}}]}`,
+			wantDifferent: true,
+			wantContains: []string{
+				"--- /dev/null",
+				"+++ b/bundle-1/manifests/configmap/default/test-cm",
+				"+kind: ConfigMap",
+			},
+		},
+		{
+			name: "new resource bundle",
+			manifest: `{"name": "new-bundle", "consumer_name": "test-consumer",
+				"manifests": [{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "test"}}],
+				"delete_option": {"propagationPolicy": "Orphan"}}`,
+			wantDifferent: true,
+			wantContains: []string{
+				"+++ b/new-bundle/manifests/namespace/test",
+				"+++ b/new-bundle/delete_option",
+				"+propagationPolicy: Orphan",
+			},
+		},
+		{
+			name:        "resource bundle not found",
+			manifest:    `{"id": "not-found", "consumer_name": "test-consumer"}`,
+			wantErr:     true,
+			errContains: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupTestEnv(t, server, nil)
+			defer cleanup()
+
+			manifestFile := filepath.Join(t.TempDir(), "manifest.json")
+			if err := os.WriteFile(manifestFile, []byte(tt.manifest), 0644); err != nil {
+				t.Fatalf("Failed to create manifest file: %v", err)
+			}
+
+			cmd := newDiffCommand()
+			clients.AddRESTClientFlags(cmd)
+			if err := cmd.ParseFlags([]string{"--file", manifestFile}); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+
+			different, err := runDiff(cmd, []string{})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runDiff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("runDiff() error = %v, should contain %v", err, tt.errContains)
+				}
+			}
+
+			if different != tt.wantDifferent {
+				t.Errorf("runDiff() different = %v, want %v", different, tt.wantDifferent)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("runDiff() output = %s, should contain %s", out.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffResourceBundle(t *testing.T) {
+	configMap := func(data string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+			"data":       map[string]interface{}{"key": data},
+		}
+	}
+	deployment := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+	}
+
+	current := &openapi.ResourceBundle{
+		Id:        openapi.PtrString("bundle-1"),
+		Manifests: []map[string]interface{}{configMap("old"), deployment},
+		ManifestConfigs: []map[string]interface{}{
+			{"resourceIdentifier": map[string]interface{}{"name": "web"}, "updateStrategy": map[string]interface{}{"type": "Update"}},
+		},
+	}
+	local := &openapi.ResourceBundle{
+		Id:        openapi.PtrString("bundle-1"),
+		Manifests: []map[string]interface{}{configMap("new")},
+		ManifestConfigs: []map[string]interface{}{
+			{"resourceIdentifier": map[string]interface{}{"name": "web"}, "updateStrategy": map[string]interface{}{"type": "ServerSideApply"}},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	different, err := diffResourceBundle(out, current, local)
+	if err != nil {
+		t.Fatalf("diffResourceBundle() error = %v", err)
+	}
+	if !different {
+		t.Errorf("diffResourceBundle() different = false, want true")
+	}
+
+	for _, want := range []string{
+		// the removed deployment
+		"--- a/bundle-1/manifests/deployment/default/web\n+++ /dev/null\n",
+		"-kind: Deployment",
+		// the changed config map
+		"--- a/bundle-1/manifests/configmap/default/web\n+++ b/bundle-1/manifests/configmap/default/web\n",
+		"-  key: old\n+  key: new\n",
+		// the changed manifest configs
+		"+++ b/bundle-1/manifest_configs",
+		"+    type: ServerSideApply",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("diffResourceBundle() output = %s, should contain %q", out.String(), want)
+		}
+	}
+	if strings.Contains(out.String(), "delete_option") {
+		t.Errorf("diffResourceBundle() output = %s, should not contain delete_option", out.String())
+	}
+
+	// no differences against itself
+	out.Reset()
+	different, err = diffResourceBundle(out, local, local)
+	if err != nil {
+		t.Fatalf("diffResourceBundle() error = %v", err)
+	}
+	if different || out.Len() != 0 {
+		t.Errorf("diffResourceBundle() = %v, %s, want no differences", different, out.String())
+	}
+}
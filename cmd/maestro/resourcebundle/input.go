@@ -0,0 +1,236 @@
+package resourcebundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+// manifestFileExtensions are the extensions of the files read from a directory
+var manifestFileExtensions = map[string]bool{".json": true, ".yaml": true, ".yml": true}
+
+// inputDocument is a JSON or YAML document read from a manifest file, it is either a resource bundle or a raw
+// Kubernetes manifest
+type inputDocument struct {
+	source string
+	object map[string]interface{}
+}
+
+// isKubernetesManifest returns true if the document is a Kubernetes manifest rather than a resource bundle
+func (d inputDocument) isKubernetesManifest() bool {
+	_, hasManifests := d.object["manifests"]
+	_, hasKind := d.object["kind"]
+	_, hasAPIVersion := d.object["apiVersion"]
+	return !hasManifests && hasKind && hasAPIVersion
+}
+
+// addInputFlags adds the flags of the manifest files that the resource bundles are read from
+func addInputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "Path to the manifest file or directory (required)")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().BoolP("recursive", "R", false, "Read the manifest files in the subdirectories of the -f directory")
+	cmd.Flags().String("consumer", "", "Build a resource bundle for this consumer from raw Kubernetes manifests")
+	cmd.Flags().String("name", "", "The name of the resource bundle built from raw Kubernetes manifests")
+}
+
+// loadResourceBundles reads the resource bundles from the manifest files of the input flags. With --consumer and
+// --name, the manifest files have raw Kubernetes manifests, they are built into one resource bundle that has the ID
+// of the existing resource bundle with the name, if any.
+func loadResourceBundles(ctx context.Context, cmd *cobra.Command, restClient *clients.RESTClient) ([]openapi.ResourceBundle, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --file flag: %w", err)
+	}
+	recursive, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --recursive flag: %w", err)
+	}
+	consumerName, err := cmd.Flags().GetString("consumer")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --consumer flag: %w", err)
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --name flag: %w", err)
+	}
+
+	if (consumerName == "") != (name == "") {
+		return nil, fmt.Errorf("--consumer and --name must be set together")
+	}
+
+	docs, err := readInputDocuments(filePath, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	if consumerName == "" {
+		return toResourceBundles(docs)
+	}
+
+	bundle, err := buildResourceBundle(docs, consumerName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the resource bundle of the consumer with the same name if it exists, the resource bundles of the other
+	// consumers may have the same name
+	existing, err := restClient.ListResourceBundles(ctx, 1, 1, existingBundleSearch(consumerName, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resource bundle %q of consumer %q: %w", name, consumerName, err)
+	}
+	for _, item := range existing.GetItems() {
+		if item.GetConsumerName() == consumerName && item.GetName() == name {
+			bundle.Id = item.Id
+		}
+	}
+	return []openapi.ResourceBundle{*bundle}, nil
+}
+
+// existingBundleSearch returns the search filter of the resource bundle of the consumer with the name
+func existingBundleSearch(consumerName, name string) string {
+	return fmt.Sprintf("consumer_name=%s and name=%s", quoteSearchValue(consumerName), quoteSearchValue(name))
+}
+
+// quoteSearchValue quotes a value of a search filter, the single quotes in the value are escaped by doubling them
+func quoteSearchValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// readInputDocuments reads the documents of a JSON or YAML file, or of the JSON and YAML files in a directory, a
+// YAML file may have multiple documents. The subdirectories are only read if recursive is true.
+func readInputDocuments(path string, recursive bool) ([]inputDocument, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest file: %w", err)
+	}
+	if !info.IsDir() {
+		return readInputFile(path)
+	}
+
+	var docs []inputDocument
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !manifestFileExtensions[strings.ToLower(filepath.Ext(p))] {
+			return nil
+		}
+
+		fileDocs, err := readInputFile(p)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, fileDocs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no JSON or YAML manifest files found in %s", path)
+	}
+	return docs, nil
+}
+
+func readInputFile(path string) ([]inputDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest file: %w", err)
+	}
+
+	// the JSON files have a single document, they are not parsed as YAML so that the malformed JSON is rejected
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		object := map[string]interface{}{}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest file %s: %w", path, err)
+		}
+		return []inputDocument{{source: path, object: object}}, nil
+	}
+
+	var docs []inputDocument
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		object := map[string]interface{}{}
+		if err := decoder.Decode(&object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse manifest file %s: %w", path, err)
+		}
+		// skip the empty YAML documents
+		if len(object) == 0 {
+			continue
+		}
+		docs = append(docs, inputDocument{source: path, object: object})
+	}
+	return docs, nil
+}
+
+// toResourceBundles converts the documents to resource bundles, all the documents must be resource bundles.
+func toResourceBundles(docs []inputDocument) ([]openapi.ResourceBundle, error) {
+	bundles := make([]openapi.ResourceBundle, 0, len(docs))
+	for _, doc := range docs {
+		if doc.isKubernetesManifest() {
+			return nil, fmt.Errorf("%s has a Kubernetes %s manifest, use --consumer and --name to build a resource bundle from Kubernetes manifests",
+				doc.source, doc.object["kind"])
+		}
+
+		bundle, err := decodeResourceBundle(doc.object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest file %s: %w", doc.source, err)
+		}
+		bundles = append(bundles, *bundle)
+	}
+	return bundles, nil
+}
+
+// buildResourceBundle builds a resource bundle of the consumer from the Kubernetes manifests, all the documents must
+// be Kubernetes manifests.
+func buildResourceBundle(docs []inputDocument, consumerName, name string) (*openapi.ResourceBundle, error) {
+	manifests := make([]map[string]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		if !doc.isKubernetesManifest() {
+			return nil, fmt.Errorf("%s is not a Kubernetes manifest, --consumer cannot be used with resource bundle files", doc.source)
+		}
+		manifests = append(manifests, doc.object)
+	}
+
+	return &openapi.ResourceBundle{
+		Name:         openapi.PtrString(name),
+		ConsumerName: openapi.PtrString(consumerName),
+		Manifests:    manifests,
+	}, nil
+}
+
+// decodeResourceBundle decodes a resource bundle from its JSON object
+func decodeResourceBundle(object map[string]interface{}) (*openapi.ResourceBundle, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &openapi.ResourceBundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
@@ -0,0 +1,33 @@
+package resourcebundle
+
+import "testing"
+
+func TestExistingBundleSearch(t *testing.T) {
+	tests := []struct {
+		name         string
+		consumerName string
+		bundleName   string
+		want         string
+	}{
+		{
+			name:         "plain names",
+			consumerName: "cluster1",
+			bundleName:   "web",
+			want:         "consumer_name='cluster1' and name='web'",
+		},
+		{
+			name:         "quoted names",
+			consumerName: "cluster1",
+			bundleName:   "web' or name='api",
+			want:         "consumer_name='cluster1' and name='web'' or name=''api'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := existingBundleSearch(tt.consumerName, tt.bundleName); got != tt.want {
+				t.Errorf("existingBundleSearch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
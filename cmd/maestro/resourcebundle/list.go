@@ -74,15 +74,10 @@ func runList(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Output the result
-	format, err := output.GetFormat(cmd)
+	opts, err := output.GetOptions(cmd)
 	if err != nil {
 		return err
 	}
 
-	if format == output.FormatTable {
-		items := result.GetItems()
-		return output.PrintResourceBundleList(os.Stdout, items)
-	}
-
-	return output.PrintJSON(os.Stdout, result)
+	return output.PrintList(os.Stdout, opts, result, result.Items, output.ResourceBundleName, output.ResourceBundleTable)
 }
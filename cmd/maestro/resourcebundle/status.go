@@ -60,14 +60,10 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output the status field
-	format, err := output.GetFormat(cmd)
+	opts, err := output.GetOptions(cmd)
 	if err != nil {
 		return err
 	}
 
-	if format == output.FormatTable {
-		return output.PrintResourceBundleStatus(os.Stdout, bundleID, bundle.Status)
-	}
-
-	return output.PrintJSON(os.Stdout, bundle.Status)
+	return output.PrintObject(os.Stdout, opts, output.ResourceBundleStatusObject(bundleID, bundle.Status))
 }
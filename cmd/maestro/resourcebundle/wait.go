@@ -0,0 +1,282 @@
+package resourcebundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/common"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func newWaitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait [id...] --for <condition>",
+		Short: "Wait for resource bundles to reach a condition",
+		Long: `Wait for one or more resource bundles to reach a condition.
+
+The resource bundles are selected by their IDs, by --name or by --search. The
+condition is one of:
+
+  condition=<type>[=<status>]  a resource bundle condition, e.g. condition=Applied
+                               or condition=Available, the status defaults to True
+  delete                       the resource bundle is deleted
+
+With --manifest, the condition is checked on the given manifest of the resource
+bundle instead, e.g. --for condition=Available --manifest deployment/default/web.
+
+The command exits with a non-zero code if the condition is not met by all the
+resource bundles within the timeout.
+
+Examples:
+  maestro resourcebundle wait 2faPrp3ZoCMkzdHnBBWd9wqwVXd --for condition=Applied
+  maestro resourcebundle wait --name nginx --for condition=Available --timeout 10m
+  maestro resourcebundle wait --search "consumer_name='cluster-01'" --for delete
+  maestro resourcebundle wait 2faPrp3ZoCMkzdHnBBWd9wqwVXd --for condition=Available --manifest deployment/default/web`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runWait(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String("for", "", "The condition to wait for: condition=<type>[=<status>] or delete")
+	cmd.Flags().String("name", "", "Wait for the resource bundle with this name")
+	cmd.Flags().String("search", "", "Wait for the resource bundles that match the search filter (e.g., \"consumer_name='cluster-01'\")")
+	cmd.Flags().String("manifest", "", "Check the condition on this manifest, as <kind>/<namespace>/<name> or <kind>/<name>")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "The maximum time to wait")
+	cmd.Flags().Duration("poll-interval", 2*time.Second, "How often the status of the resource bundles is checked")
+	_ = cmd.MarkFlagRequired("for")
+
+	return cmd
+}
+
+func runWait(cmd *cobra.Command, args []string) error {
+	return waitFor(context.Background(), cmd, args, os.Stdout)
+}
+
+func waitFor(ctx context.Context, cmd *cobra.Command, args []string, w io.Writer) error {
+	forFlag, err := cmd.Flags().GetString("for")
+	if err != nil {
+		return fmt.Errorf("failed to read --for flag: %w", err)
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return fmt.Errorf("failed to read --name flag: %w", err)
+	}
+	search, err := cmd.Flags().GetString("search")
+	if err != nil {
+		return fmt.Errorf("failed to read --search flag: %w", err)
+	}
+	manifest, err := cmd.Flags().GetString("manifest")
+	if err != nil {
+		return fmt.Errorf("failed to read --manifest flag: %w", err)
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("failed to read --timeout flag: %w", err)
+	}
+	interval, err := cmd.Flags().GetDuration("poll-interval")
+	if err != nil {
+		return fmt.Errorf("failed to read --poll-interval flag: %w", err)
+	}
+
+	if len(args) > 0 && (name != "" || search != "") {
+		return fmt.Errorf("resource bundle IDs cannot be used with --name or --search")
+	}
+	if len(args) == 0 && name == "" && search == "" {
+		return fmt.Errorf("resource bundle IDs, --name or --search is required")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--poll-interval must be positive")
+	}
+
+	condition, err := parseWaitCondition(forFlag, manifest)
+	if err != nil {
+		return err
+	}
+
+	// Load REST client configuration
+	cfg, err := clients.LoadRESTConfigFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Create REST client
+	restClient, err := clients.NewRESTClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create REST client: %w", err)
+	}
+
+	bundleIDs := args
+	if len(bundleIDs) == 0 {
+		bundleIDs, err = listBundleIDs(ctx, restClient, bundleSearch(name, search))
+		if err != nil {
+			return err
+		}
+		if len(bundleIDs) == 0 {
+			if condition.deleted {
+				// nothing left to be deleted
+				return nil
+			}
+			return fmt.Errorf("no resource bundles found")
+		}
+	}
+
+	pending := map[string]bool{}
+	for _, id := range bundleIDs {
+		pending[id] = true
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		for _, id := range bundleIDs {
+			if !pending[id] {
+				continue
+			}
+
+			bundle, err := restClient.GetResourceBundle(ctx, id)
+			if err != nil && !errors.Is(err, clients.ErrResourceBundleNotFound) {
+				return false, fmt.Errorf("failed to get resource bundle %s: %w", id, err)
+			}
+
+			met, err := condition.isMet(id, bundle)
+			if err != nil {
+				return false, err
+			}
+			if met {
+				delete(pending, id)
+				fmt.Fprintf(w, "resource bundle %s %s\n", id, condition.metMessage())
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if wait.Interrupted(err) {
+		ids := make([]string, 0, len(pending))
+		for id := range pending {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return fmt.Errorf("timed out waiting for %s on resource bundles: %s", forFlag, strings.Join(ids, ", "))
+	}
+	return err
+}
+
+// waitCondition is the condition that the wait command waits for
+type waitCondition struct {
+	deleted bool
+	// condType and condStatus are the type and the expected status of a condition
+	condType   string
+	condStatus string
+	// manifest is the manifest to check the condition on, empty for the resource bundle conditions
+	manifest string
+}
+
+// parseWaitCondition parses the --for and --manifest flags
+func parseWaitCondition(forFlag, manifest string) (*waitCondition, error) {
+	if forFlag == "delete" {
+		if manifest != "" {
+			return nil, fmt.Errorf("--manifest cannot be used with --for delete")
+		}
+		return &waitCondition{deleted: true}, nil
+	}
+
+	spec, ok := strings.CutPrefix(forFlag, "condition=")
+	if !ok {
+		return nil, fmt.Errorf("invalid --for %q, it must be condition=<type>[=<status>] or delete", forFlag)
+	}
+	condType, condStatus, found := strings.Cut(spec, "=")
+	if !found {
+		condStatus = "True"
+	}
+	if condType == "" || condStatus == "" {
+		return nil, fmt.Errorf("invalid --for %q, it must be condition=<type>[=<status>] or delete", forFlag)
+	}
+
+	if manifest != "" {
+		parts := strings.Split(manifest, "/")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid --manifest %q, it must be <kind>/<namespace>/<name> or <kind>/<name>", manifest)
+		}
+		// the manifest keys use the lower case kinds
+		parts[0] = strings.ToLower(parts[0])
+		manifest = strings.Join(parts, "/")
+	}
+
+	return &waitCondition{condType: condType, condStatus: condStatus, manifest: manifest}, nil
+}
+
+// isMet returns true if the resource bundle reaches the condition, the bundle is nil if it is not found
+func (c *waitCondition) isMet(id string, bundle *openapi.ResourceBundle) (bool, error) {
+	if c.deleted {
+		if bundle == nil {
+			return true, nil
+		}
+		deleted := output.FindStatusCondition(output.GetStatusConditions(bundle.Status), common.ResourceDeleted)
+		return deleted != nil && deleted.Status == "True", nil
+	}
+
+	if bundle == nil {
+		return false, fmt.Errorf("resource bundle %s not found", id)
+	}
+
+	conditions := output.GetStatusConditions(bundle.Status)
+	if c.manifest != "" {
+		conditions = nil
+		for _, manifest := range output.GetManifestStatuses(bundle.Status) {
+			if manifest.Key() == c.manifest {
+				conditions = manifest.Conditions
+				break
+			}
+		}
+	}
+
+	cond := output.FindStatusCondition(conditions, c.condType)
+	return cond != nil && strings.EqualFold(cond.Status, c.condStatus), nil
+}
+
+func (c *waitCondition) metMessage() string {
+	if c.deleted {
+		return "deleted"
+	}
+	return "condition met"
+}
+
+// bundleSearch returns the search filter of the resource bundles selected by the name and the search flags
+func bundleSearch(name, search string) string {
+	switch {
+	case name == "":
+		return search
+	case search == "":
+		return fmt.Sprintf("name='%s'", name)
+	default:
+		return fmt.Sprintf("name='%s' and (%s)", name, search)
+	}
+}
+
+// listBundleIDs returns the IDs of all the resource bundles that match the search filter
+func listBundleIDs(ctx context.Context, restClient *clients.RESTClient, search string) ([]string, error) {
+	var ids []string
+	for page := 1; ; page++ {
+		result, err := restClient.ListResourceBundles(ctx, page, 100, search)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource bundles: %w", err)
+		}
+		for _, bundle := range result.GetItems() {
+			ids = append(ids, bundle.GetId())
+		}
+		if len(result.GetItems()) == 0 || len(ids) >= int(result.GetTotal()) {
+			return ids, nil
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package resourcebundle
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients/mock"
+)
+
+func TestWaitFor(t *testing.T) {
+	server := mock.NewMaestroServer()
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		args        []string
+		flags       []string
+		wantErr     bool
+		errContains string
+		wantOutput  string
+	}{
+		{
+			name:       "resource bundle is applied",
+			args:       []string{"bundle-1"},
+			flags:      []string{"--for", "condition=Applied"},
+			wantOutput: "resource bundle bundle-1 condition met",
+		},
+		{
+			name:        "resource bundle is not available",
+			args:        []string{"bundle-1"},
+			flags:       []string{"--for", "condition=Available", "--timeout", "50ms"},
+			wantErr:     true,
+			errContains: "timed out waiting for condition=Available on resource bundles: bundle-1",
+		},
+		{
+			name:        "resource bundle is not applied with false status",
+			args:        []string{"bundle-1"},
+			flags:       []string{"--for", "condition=Applied=False", "--timeout", "50ms"},
+			wantErr:     true,
+			errContains: "timed out",
+		},
+		{
+			name:       "resource bundle is deleted",
+			args:       []string{"not-found"},
+			flags:      []string{"--for", "delete"},
+			wantOutput: "resource bundle not-found deleted",
+		},
+		{
+			name:        "resource bundle is not found",
+			args:        []string{"not-found"},
+			flags:       []string{"--for", "condition=Applied"},
+			wantErr:     true,
+			errContains: "resource bundle not-found not found",
+		},
+		{
+			name:       "resource bundles by search",
+			flags:      []string{"--search", "test-bundle", "--for", "condition=Applied"},
+			wantOutput: "resource bundle bundle-1 condition met",
+		},
+		{
+			name:        "no resource bundles by search",
+			flags:       []string{"--search", "other", "--for", "condition=Applied"},
+			wantErr:     true,
+			errContains: "no resource bundles found",
+		},
+		{
+			name:  "no resource bundles to delete by search",
+			flags: []string{"--search", "other", "--for", "delete"},
+		},
+		{
+			name:        "manifest condition is not found",
+			args:        []string{"bundle-1"},
+			flags:       []string{"--for", "condition=Applied", "--manifest", "deployment/default/web", "--timeout", "50ms"},
+			wantErr:     true,
+			errContains: "timed out",
+		},
+		{
+			name:        "invalid condition",
+			args:        []string{"bundle-1"},
+			flags:       []string{"--for", "Applied"},
+			wantErr:     true,
+			errContains: "invalid --for",
+		},
+		{
+			name:        "no resource bundles selected",
+			flags:       []string{"--for", "delete"},
+			wantErr:     true,
+			errContains: "--name or --search is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupTestEnv(t, server, nil)
+			defer cleanup()
+
+			cmd := newWaitCommand()
+			clients.AddRESTClientFlags(cmd)
+			if err := cmd.ParseFlags(append(tt.flags, "--poll-interval", "10ms")); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+
+			out := &bytes.Buffer{}
+			err := waitFor(context.Background(), cmd, tt.args, out)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("waitFor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("waitFor() error = %v, should contain %v", err, tt.errContains)
+				}
+			}
+
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("waitFor() output = %s, should contain %s", out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestParseWaitCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		forFlag  string
+		manifest string
+		want     waitCondition
+		wantErr  bool
+	}{
+		{name: "delete", forFlag: "delete", want: waitCondition{deleted: true}},
+		{name: "condition", forFlag: "condition=Available", want: waitCondition{condType: "Available", condStatus: "True"}},
+		{name: "condition with status", forFlag: "condition=Applied=false", want: waitCondition{condType: "Applied", condStatus: "false"}},
+		{
+			name:     "manifest condition",
+			forFlag:  "condition=Available",
+			manifest: "Deployment/default/web",
+			want:     waitCondition{condType: "Available", condStatus: "True", manifest: "deployment/default/web"},
+		},
+		{name: "delete with manifest", forFlag: "delete", manifest: "configmap/web", wantErr: true},
+		{name: "empty condition type", forFlag: "condition=", wantErr: true},
+		{name: "invalid manifest", forFlag: "condition=Applied", manifest: "web", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWaitCondition(tt.forFlag, tt.manifest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWaitCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("parseWaitCondition() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
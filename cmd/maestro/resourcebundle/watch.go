@@ -0,0 +1,258 @@
+package resourcebundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+)
+
+// watchRowFormat is the format of a watch table row, the rows are printed as the status changes arrive, so the
+// columns have fixed widths instead of being aligned across the rows.
+const watchRowFormat = "%-19s  %-36s  %-16s  %-8s  %-8s  %-40s  %s\n"
+
+func newWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [id]",
+		Short: "Watch the status changes of resource bundles",
+		Long: `Watch the status changes of resource bundles via a gRPC status subscription.
+
+Each status change is printed as a table row with the observed version, the bundle
+conditions and the conditions of each manifest, or as a JSON line with --output json.
+The wide format is the same as the table format, the yaml format prints each status
+change as a YAML document, and the name, jsonpath and custom-columns formats print
+each status change on its own line.
+A deleted resource bundle is reported with the DELETED event. The command runs until
+it is interrupted.
+
+Without an ID, the status changes of all resource bundles of the source are printed.
+With --consumer and --selector, the server only sends the status changes of the resource
+bundles of the consumer and of the resource bundles whose labels match the selector.
+With --search, only the resource bundles that match the search filter are printed, the
+filter is checked with the REST API, the result is reused for a minute per resource
+bundle. Prefer --consumer and --selector, --search receives the status changes of all
+resource bundles of the source.
+
+Examples:
+  maestro resourcebundle watch
+  maestro resourcebundle watch 2faPrp3ZoCMkzdHnBBWd9wqwVXd
+  maestro resourcebundle watch --consumer prod-cluster-01 --selector app=nginx
+  maestro resourcebundle watch --search "name like 'nginx%'"
+  maestro resourcebundle watch --output json`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runWatch(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String("consumer", "", "Only watch the resource bundles of the consumer, the server filters the status changes")
+	cmd.Flags().StringP("selector", "l", "", "Only watch the resource bundles whose labels match the label selector (e.g., app=nginx), the server filters the status changes")
+	cmd.Flags().String("search", "", "Search filter (e.g., \"consumer_name='cluster-01'\"), checked with the REST API for each resource bundle")
+
+	output.AddFormatFlag(cmd)
+
+	return cmd
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return watch(ctx, cmd, args, os.Stdout)
+}
+
+func watch(ctx context.Context, cmd *cobra.Command, args []string, w io.Writer) error {
+	opts, err := output.GetOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if opts.SortBy != "" {
+		return fmt.Errorf("--sort-by cannot be used with watch, the status changes are printed as they arrive")
+	}
+	tableFormat := opts.Format == output.FormatTable || opts.Format == output.FormatWide
+	search, err := cmd.Flags().GetString("search")
+	if err != nil {
+		return fmt.Errorf("failed to read --search flag: %w", err)
+	}
+	watchOpts := clients.WatchOptions{}
+	if watchOpts.ConsumerName, err = cmd.Flags().GetString("consumer"); err != nil {
+		return fmt.Errorf("failed to read --consumer flag: %w", err)
+	}
+	if watchOpts.LabelSelector, err = cmd.Flags().GetString("selector"); err != nil {
+		return fmt.Errorf("failed to read --selector flag: %w", err)
+	}
+
+	// Load client configuration
+	cfg, err := clients.LoadConfigFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	filter := &watchFilter{search: search, searchTTL: searchResultTTL, matched: map[string]searchResult{}}
+	if len(args) == 1 {
+		filter.id = args[0]
+	}
+	if search != "" {
+		// Create REST client to check the search filter
+		filter.restClient, err = clients.NewRESTClient(&cfg.RESTConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create REST client: %w", err)
+		}
+	}
+
+	// Create gRPC client
+	grpcClient, err := clients.NewGRPCClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	defer grpcClient.Close()
+
+	if tableFormat && !opts.NoHeaders {
+		fmt.Fprintf(w, watchRowFormat, "TIME", "ID", "CONSUMER", "OBSERVED", "EVENT", "CONDITIONS", "MANIFESTS")
+	}
+
+	encoder := json.NewEncoder(w)
+	// the custom-columns headers are only printed before the first status change
+	objectOpts := *opts
+	return grpcClient.WatchStatus(ctx, watchOpts, func(evt *clients.ResourceBundleStatusEvent) error {
+		matched, err := filter.matches(ctx, evt.ID)
+		if err != nil {
+			return err
+		}
+		if evt.Deleted {
+			// a deleted resource bundle has no more status changes
+			filter.forget(evt.ID)
+		}
+		if !matched {
+			return nil
+		}
+
+		switch {
+		case tableFormat:
+			eventType := "MODIFIED"
+			if evt.Deleted {
+				eventType = "DELETED"
+			}
+			_, err := fmt.Fprintf(w, watchRowFormat,
+				evt.Time.Local().Format("2006-01-02 15:04:05"),
+				evt.ID,
+				evt.ConsumerName,
+				fmt.Sprintf("%d", evt.ObservedVersion),
+				eventType,
+				formatConditions(evt.Conditions),
+				formatManifestConditions(evt))
+			return err
+		case opts.Format == output.FormatJSON:
+			// one JSON document per line
+			return encoder.Encode(evt)
+		case opts.Format == output.FormatYAML:
+			if _, err := fmt.Fprintln(w, "---"); err != nil {
+				return err
+			}
+			return output.PrintYAML(w, evt)
+		}
+
+		obj := output.Object{Name: "resourcebundle/" + evt.ID, Data: evt}
+		if err := output.PrintObject(w, &objectOpts, obj); err != nil {
+			return err
+		}
+		objectOpts.NoHeaders = true
+		if opts.Format == output.FormatJSONPath {
+			// the JSONPath template has no trailing newline
+			_, err = fmt.Fprintln(w)
+		}
+		return err
+	})
+}
+
+// searchResultTTL is how long the search filter result of a resource bundle is reused, the resource bundle may be
+// changed to match the search filter or not
+const searchResultTTL = time.Minute
+
+// watchFilter selects the resource bundles to print by the ID and the search filter.
+type watchFilter struct {
+	id         string
+	search     string
+	searchTTL  time.Duration
+	restClient *clients.RESTClient
+	// matched caches whether a resource bundle matches the search filter
+	matched map[string]searchResult
+}
+
+// searchResult is whether a resource bundle matched the search filter when it was checked
+type searchResult struct {
+	matched   bool
+	checkedAt time.Time
+}
+
+func (f *watchFilter) matches(ctx context.Context, id string) (bool, error) {
+	if f.id != "" && id != f.id {
+		return false, nil
+	}
+	if f.search == "" {
+		return true, nil
+	}
+
+	if result, ok := f.matched[id]; ok && time.Since(result.checkedAt) < f.searchTTL {
+		return result.matched, nil
+	}
+
+	result, err := f.restClient.ListResourceBundles(ctx, 1, 1, fmt.Sprintf("id='%s' and (%s)", id, f.search))
+	if err != nil {
+		return false, fmt.Errorf("failed to check the search filter for resource bundle %s: %w", id, err)
+	}
+	f.matched[id] = searchResult{matched: len(result.GetItems()) > 0, checkedAt: time.Now()}
+	return f.matched[id].matched, nil
+}
+
+// forget drops the cached search filter result of the resource bundle
+func (f *watchFilter) forget(id string) {
+	delete(f.matched, id)
+}
+
+// formatConditions formats the conditions as Type=Status pairs ordered by the type
+func formatConditions(conditions []metav1.Condition) string {
+	if len(conditions) == 0 {
+		return "-"
+	}
+
+	parts := make([]string, 0, len(conditions))
+	for _, cond := range conditions {
+		parts = append(parts, fmt.Sprintf("%s=%s", cond.Type, cond.Status))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// formatManifestConditions formats the conditions of each manifest as kind/name(Type=Status,...)
+func formatManifestConditions(evt *clients.ResourceBundleStatusEvent) string {
+	if len(evt.Manifests) == 0 {
+		return "-"
+	}
+
+	parts := make([]string, 0, len(evt.Manifests))
+	for _, manifest := range evt.Manifests {
+		name := manifest.ResourceMeta.Name
+		if manifest.ResourceMeta.Namespace != "" {
+			name = manifest.ResourceMeta.Namespace + "/" + name
+		}
+		parts = append(parts, fmt.Sprintf("%s/%s(%s)",
+			strings.ToLower(manifest.ResourceMeta.Kind), name, formatConditions(manifest.Conditions)))
+	}
+	return strings.Join(parts, " ")
+}
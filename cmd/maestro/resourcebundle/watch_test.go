@@ -0,0 +1,237 @@
+package resourcebundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	workv1 "open-cluster-management.io/api/work/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/common"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients/mock"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func newStatusEvent(t *testing.T, id string, version int64, conditions []metav1.Condition) *pbv1.CloudEvent {
+	evt := cetypes.NewEventBuilder("test-agent", cetypes.CloudEventsType{
+		CloudEventsDataType: workpayload.ManifestBundleEventDataType,
+		SubResource:         cetypes.SubResourceStatus,
+		Action:              cetypes.UpdateRequestAction,
+	}).WithResourceID(id).WithResourceVersion(version).WithClusterName("cluster1").NewEvent()
+
+	status := &workpayload.ManifestBundleStatus{
+		Conditions: conditions,
+		ResourceStatus: []workv1.ManifestCondition{{
+			ResourceMeta: workv1.ManifestResourceMeta{Kind: "Deployment", Namespace: "default", Name: "nginx"},
+			Conditions:   []metav1.Condition{{Type: "Applied", Status: metav1.ConditionTrue}},
+		}},
+	}
+	if err := evt.SetData(cloudevents.ApplicationJSON, status); err != nil {
+		t.Fatalf("Failed to set event data: %v", err)
+	}
+
+	pbEvt := &pbv1.CloudEvent{}
+	if err := grpcprotocol.WritePBMessage(context.Background(), binding.ToMessage(&evt), pbEvt); err != nil {
+		t.Fatalf("Failed to convert event to protobuf: %v", err)
+	}
+	return pbEvt
+}
+
+func TestWatch(t *testing.T) {
+	server := mock.NewMaestroServer()
+	defer server.Close()
+
+	grpcServer, err := mock.NewGRPCServer()
+	if err != nil {
+		t.Fatalf("Failed to create gRPC server: %v", err)
+	}
+	defer grpcServer.Stop()
+
+	grpcServer.AddStatusEvent(newStatusEvent(t, "bundle-1", 1, []metav1.Condition{
+		{Type: "Available", Status: metav1.ConditionTrue},
+		{Type: "Applied", Status: metav1.ConditionTrue},
+	}))
+	grpcServer.AddStatusEvent(newStatusEvent(t, "bundle-2", 2, []metav1.Condition{
+		{Type: common.ResourceDeleted, Status: metav1.ConditionTrue},
+	}))
+
+	tests := []struct {
+		name             string
+		args             []string
+		flags            []string
+		output           string
+		wantContains     []string
+		wantExcludes     []string
+		wantSubscription mock.Subscription
+	}{
+		{
+			name:   "all resource bundles as table",
+			output: "table",
+			wantContains: []string{
+				"OBSERVED",
+				"bundle-1",
+				"Applied=True,Available=True",
+				"deployment/default/nginx(Applied=True)",
+				"DELETED",
+			},
+		},
+		{
+			name:         "one resource bundle as table",
+			args:         []string{"bundle-1"},
+			output:       "table",
+			wantContains: []string{"bundle-1", "MODIFIED"},
+			wantExcludes: []string{"bundle-2"},
+		},
+		{
+			name:             "consumer and selector are sent to the server",
+			flags:            []string{"--consumer", "cluster1", "-l", "app=nginx"},
+			output:           "table",
+			wantContains:     []string{"bundle-1"},
+			wantSubscription: mock.Subscription{ClusterName: "cluster1", LabelSelector: "app=nginx"},
+		},
+		{
+			name:         "yaml documents",
+			output:       "yaml",
+			wantContains: []string{"---\nconditions:", "id: bundle-1\n", "deleted: true\n"},
+		},
+		{
+			name:         "names",
+			output:       "name",
+			wantContains: []string{"resourcebundle/bundle-1\nresourcebundle/bundle-2\n"},
+		},
+		{
+			name:         "custom columns with the headers once",
+			output:       "custom-columns=ID:.id,VERSION:.observed_version",
+			wantContains: []string{"ID", "bundle-1   1\nbundle-2   2\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupTestEnv(t, server, grpcServer)
+			defer cleanup()
+
+			cmd := &cobra.Command{}
+			clients.AddClientFlags(cmd, "test-source")
+			cmd.Flags().String("search", "", "Search filter")
+			cmd.Flags().String("consumer", "", "Consumer name")
+			cmd.Flags().StringP("selector", "l", "", "Label selector")
+			output.AddFormatFlag(cmd)
+			if err := cmd.ParseFlags(tt.flags); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			cmd.Flags().Set(output.FlagOutput, tt.output)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			out := &bytes.Buffer{}
+			if err := watch(ctx, cmd, tt.args, out); err != nil {
+				t.Fatalf("watch() error = %v", err)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("watch() output = %s, should contain %s", out.String(), want)
+				}
+			}
+			for _, exclude := range tt.wantExcludes {
+				if strings.Contains(out.String(), exclude) {
+					t.Errorf("watch() output = %s, should not contain %s", out.String(), exclude)
+				}
+			}
+			if got := grpcServer.GetSubscription(); got != tt.wantSubscription {
+				t.Errorf("watch() subscription = %+v, want %+v", got, tt.wantSubscription)
+			}
+		})
+	}
+
+	t.Run("json lines", func(t *testing.T) {
+		cleanup := setupTestEnv(t, server, grpcServer)
+		defer cleanup()
+
+		cmd := &cobra.Command{}
+		clients.AddClientFlags(cmd, "test-source")
+		cmd.Flags().String("search", "", "Search filter")
+		cmd.Flags().String("consumer", "", "Consumer name")
+		cmd.Flags().StringP("selector", "l", "", "Label selector")
+		output.AddFormatFlag(cmd)
+		if err := cmd.ParseFlags([]string{"--output", "json"}); err != nil {
+			t.Fatalf("Failed to parse flags: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		out := &bytes.Buffer{}
+		if err := watch(ctx, cmd, nil, out); err != nil {
+			t.Fatalf("watch() error = %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("watch() printed %d lines, want 2: %s", len(lines), out.String())
+		}
+		evt := &clients.ResourceBundleStatusEvent{}
+		if err := json.Unmarshal([]byte(lines[1]), evt); err != nil {
+			t.Fatalf("Failed to parse JSON line: %v", err)
+		}
+		if evt.ID != "bundle-2" || !evt.Deleted || evt.ObservedVersion != 2 || evt.ConsumerName != "cluster1" {
+			t.Errorf("watch() event = %+v, want deleted bundle-2 at version 2 of cluster1", evt)
+		}
+	})
+}
+
+func TestWatchFilter_SearchResultExpires(t *testing.T) {
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searches = append(searches, r.URL.Query().Get("search"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi.ResourceBundleList{Items: []openapi.ResourceBundle{{Id: openapi.PtrString("bundle-1")}}})
+	}))
+	defer server.Close()
+
+	restClient, err := clients.NewRESTClient(&clients.RESTConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create REST client: %v", err)
+	}
+	filter := &watchFilter{search: "name='web'", searchTTL: time.Hour, restClient: restClient, matched: map[string]searchResult{}}
+
+	for i := 0; i < 2; i++ {
+		if matched, err := filter.matches(context.Background(), "bundle-1"); err != nil || !matched {
+			t.Fatalf("matches() = %v, %v, want true", matched, err)
+		}
+	}
+	if len(searches) != 1 || searches[0] != "id='bundle-1' and (name='web')" {
+		t.Fatalf("searches = %v, want one search of bundle-1", searches)
+	}
+
+	// the result is checked again once it expires or the resource bundle is deleted
+	filter.searchTTL = 0
+	if _, err := filter.matches(context.Background(), "bundle-1"); err != nil {
+		t.Fatalf("matches() error = %v", err)
+	}
+	filter.searchTTL = time.Hour
+	filter.forget("bundle-1")
+	if _, err := filter.matches(context.Background(), "bundle-1"); err != nil {
+		t.Fatalf("matches() error = %v", err)
+	}
+	if len(searches) != 3 {
+		t.Errorf("searches = %v, want 3 searches", searches)
+	}
+}
@@ -61,7 +61,7 @@ func runServer(cmd *cobra.Command, args []string) {
 	var eventFilter controllers.EventFilter
 	if environments.Environment().Config.MessageBroker.MessageBrokerType == "grpc" {
 		logger.Info("Setting up grpc broker")
-		eventServer = server.NewGRPCBroker(ctx, eventBroadcaster)
+		eventServer = server.NewGRPCBroker(ctx, eventBroadcaster, environments.Environment().Clients.GRPCAuthorizer)
 		eventFilter = controllers.NewPredicatedEventFilter(eventServer.PredicateEvent)
 	} else {
 		logger.Info("Setting up message queue event server")
@@ -84,7 +84,7 @@ func runServer(cmd *cobra.Command, args []string) {
 	// Create the servers
 	apiserver := server.NewAPIServer(ctx, eventBroadcaster)
 	metricsServer := server.NewMetricsServer()
-	healthcheckServer := server.NewHealthCheckServer(ctx)
+	healthcheckServer := server.NewHealthCheckServer(ctx, eventServer)
 	controllersServer := server.NewControllersServer(ctx, eventServer, eventFilter)
 
 	tracingShutdown := func(context.Context) error { return nil }
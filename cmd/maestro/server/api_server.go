@@ -89,9 +89,10 @@ func (s apiServer) Serve(ctx context.Context, listener net.Listener) {
 			)
 		}
 
-		// Serve with TLS
+		// Serve with TLS, the certificates are reloaded when they are rotated
 		logger.Info("Serving with TLS", "port", env().Config.HTTPServer.BindPort)
-		err = s.httpServer.ServeTLS(listener, env().Config.HTTPServer.HTTPSCertFile, env().Config.HTTPServer.HTTPSKeyFile)
+		s.httpServer.TLSConfig = newHTTPSConfig(ctx, "api")
+		err = s.httpServer.ServeTLS(listener, "", "")
 	} else {
 		logger.Info("Serving without TLS", "port", env().Config.HTTPServer.BindPort)
 		err = s.httpServer.Serve(listener)
@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 	"k8s.io/klog/v2"
 
+	"github.com/openshift-online/maestro/pkg/audit"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
 )
 
@@ -30,6 +31,18 @@ func newContextWithIdentity(ctx context.Context, user string, groups []string) c
 	return context.WithValue(ctx, contextGroupsKey, groups)
 }
 
+// auditActorFromContext returns the identity in the given context as the actor of the audit log.
+func auditActorFromContext(ctx context.Context) audit.Actor {
+	actor := audit.Actor{User: audit.AnonymousUser, Source: audit.SourceGRPC}
+	if user, ok := ctx.Value(contextUserKey).(string); ok && user != "" {
+		actor.User = user
+	}
+	if groups, ok := ctx.Value(contextGroupsKey).([]string); ok {
+		actor.Groups = groups
+	}
+	return actor
+}
+
 // identityFromCertificate retrieves the user and groups from the client certificate if they are present.
 func identityFromCertificate(ctx context.Context) (string, []string, error) {
 	p, ok := peer.FromContext(ctx)
@@ -95,6 +108,10 @@ func newAuthUnaryInterceptor(authNType string, authorizer grpcauthorizer.GRPCAut
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
+		if isHealthCheckMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
 		var user string
 		var groups []string
 		var err error
@@ -155,6 +172,10 @@ func newAuthStreamInterceptor(authNType string, authorizer grpcauthorizer.GRPCAu
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
+		if isHealthCheckMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
 		var user string
 		var groups []string
 		var err error
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+func init() {
+	// Register the metrics:
+	prometheus.MustRegister(certificateExpirationMetric)
+}
+
+// Description of the serving certificate expiration gauge metric:
+var certificateExpirationMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "tls_certificate_expiration_timestamp_seconds",
+		Help: "The expiration time of the serving certificate in unix seconds, labeled by server.",
+	},
+	[]string{"server"},
+)
+
+// certReloadInterval is the interval to check the certificate, key and client CA files for changes.
+const certReloadInterval = 10 * time.Second
+
+// fileState is the modification time and the size of a file, it is used to detect the file changes.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFiles(files ...string) ([]fileState, error) {
+	states := []fileState{}
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, fileState{modTime: info.ModTime(), size: info.Size()})
+	}
+	return states, nil
+}
+
+// certReloader loads the serving certificate and the client CA bundle from files, and reloads them when the
+// files change. The new certificates are used by the new TLS handshakes, the established connections and streams
+// are not affected. If the changed files are invalid, the last valid certificates are kept.
+type certReloader struct {
+	server       string
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	states    []fileState
+}
+
+// newCertReloader creates a certReloader for the given server and loads the certificates.
+func newCertReloader(server, certFile, keyFile, clientCAFile string) (*certReloader, error) {
+	r := &certReloader{
+		server:       server,
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	if _, err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig returns a copy of the given TLS config that serves the current certificates.
+func (r *certReloader) TLSConfig(base *tls.Config) *tls.Config {
+	tlsConfig := base.Clone()
+	tlsConfig.Certificates = nil
+	tlsConfig.GetCertificate = r.GetCertificate
+	if r.clientCAFile != "" {
+		// the client CAs can only be swapped by returning a new config for each handshake
+		tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			config := base.Clone()
+			config.Certificates = nil
+			config.GetCertificate = r.GetCertificate
+			config.ClientCAs = r.ClientCAs()
+			return config, nil
+		}
+	}
+	return tlsConfig
+}
+
+// GetCertificate returns the current serving certificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// ClientCAs returns the current client CA pool.
+func (r *certReloader) ClientCAs() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clientCAs
+}
+
+// Run checks the files for changes periodically until the context is done.
+func (r *certReloader) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		reloaded, err := r.load()
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "failed to reload the certificates, keep the current certificates", "server", r.server)
+			return
+		}
+		if reloaded {
+			klog.FromContext(ctx).Info("the certificates are reloaded", "server", r.server, "certFile", r.certFile)
+		}
+	}, certReloadInterval)
+}
+
+// load loads the certificates if the files are changed since the last load, it returns true if they are loaded.
+func (r *certReloader) load() (bool, error) {
+	states, err := statFiles(r.certFile, r.keyFile, r.clientCAFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := equalFileStates(states, r.states)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load certificates: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return false, fmt.Errorf("failed to parse certificate: %v", err)
+	}
+	cert.Leaf = leaf
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		clientCAs, err = x509.SystemCertPool()
+		if err != nil {
+			return false, fmt.Errorf("failed to load system cert pool: %v", err)
+		}
+		caPEM, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read client CA file: %v", err)
+		}
+		if ok := clientCAs.AppendCertsFromPEM(caPEM); !ok {
+			return false, fmt.Errorf("failed to append client CA to cert pool")
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.states = states
+	r.mu.Unlock()
+
+	certificateExpirationMetric.WithLabelValues(r.server).Set(float64(leaf.NotAfter.Unix()))
+	return true, nil
+}
+
+func equalFileStates(a, b []fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
+
+// newHTTPSConfig creates a TLS config for the HTTPS server with the configured certificate and key, the
+// certificates are reloaded until the context is done.
+func newHTTPSConfig(ctx context.Context, server string) *tls.Config {
+	reloader, err := newCertReloader(server, env().Config.HTTPServer.HTTPSCertFile, env().Config.HTTPServer.HTTPSKeyFile, "")
+	if err != nil {
+		check(ctx, err, "Can't start https server")
+	}
+	go reloader.Run(ctx)
+	return reloader.TLSConfig(&tls.Config{})
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// writeCert writes a self-signed certificate that expires at the given time, the files get a new modification
+// time in case the file system has a coarse resolution.
+func writeCert(t *testing.T, certFile, keyFile string, notAfter time.Time, modTime time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(notAfter.Unix()),
+		Subject:      pkix.Name{CommonName: "maestro"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)).To(Succeed())
+	Expect(os.Chtimes(certFile, modTime, modTime)).To(Succeed())
+	Expect(os.Chtimes(keyFile, modTime, modTime)).To(Succeed())
+}
+
+func TestCertReloader(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	now := time.Now().Truncate(time.Second)
+	firstExpiry := now.Add(24 * time.Hour)
+	writeCert(t, certFile, keyFile, firstExpiry, now)
+
+	reloader, err := newCertReloader("test", certFile, keyFile, "")
+	Expect(err).NotTo(HaveOccurred())
+
+	tlsConfig := reloader.TLSConfig(&tls.Config{MinVersion: tls.VersionTLS13})
+	Expect(tlsConfig.MinVersion).To(Equal(uint16(tls.VersionTLS13)))
+	cert, err := tlsConfig.GetCertificate(nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cert.Leaf.NotAfter).To(BeTemporally("==", firstExpiry))
+	Expect(testutil.ToFloat64(certificateExpirationMetric.WithLabelValues("test"))).To(Equal(float64(firstExpiry.Unix())))
+
+	// the unchanged files are not reloaded
+	reloaded, err := reloader.load()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(reloaded).To(BeFalse())
+
+	// the rotated certificate is served by the new handshakes
+	secondExpiry := now.Add(48 * time.Hour)
+	writeCert(t, certFile, keyFile, secondExpiry, now.Add(time.Minute))
+	reloaded, err = reloader.load()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(reloaded).To(BeTrue())
+	cert, err = tlsConfig.GetCertificate(nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cert.Leaf.NotAfter).To(BeTemporally("==", secondExpiry))
+	Expect(testutil.ToFloat64(certificateExpirationMetric.WithLabelValues("test"))).To(Equal(float64(secondExpiry.Unix())))
+
+	// an invalid certificate is not loaded, the last valid certificate is kept
+	Expect(os.WriteFile(certFile, []byte("invalid"), 0600)).To(Succeed())
+	_, err = reloader.load()
+	Expect(err).To(HaveOccurred())
+	cert, err = tlsConfig.GetCertificate(nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cert.Leaf.NotAfter).To(BeTemporally("==", secondExpiry))
+}
+
+func TestCertReloaderClientCAs(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	writeCert(t, certFile, keyFile, time.Now().Add(time.Hour), time.Now())
+
+	reloader, err := newCertReloader("test-ca", certFile, keyFile, certFile)
+	Expect(err).NotTo(HaveOccurred())
+
+	tlsConfig := reloader.TLSConfig(&tls.Config{ClientAuth: tls.RequireAndVerifyClientCert})
+	Expect(tlsConfig.GetConfigForClient).NotTo(BeNil())
+	config, err := tlsConfig.GetConfigForClient(nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(config.ClientAuth).To(Equal(tls.RequireAndVerifyClientCert))
+	Expect(config.ClientCAs).To(Equal(reloader.ClientCAs()))
+	Expect(config.GetCertificate).NotTo(BeNil())
+}
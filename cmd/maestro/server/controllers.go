@@ -16,14 +16,21 @@ import (
 func NewControllersServer(ctx context.Context, eventServer EventServer, eventFilter controllers.EventFilter) *ControllersServer {
 	logger := klog.FromContext(ctx)
 
+	statusEventRetention := time.Duration(env().Config.EventServer.StatusEventRetention) * time.Second
 	s := &ControllersServer{
 		StatusController: controllers.NewStatusController(
 			env().Services.StatusEvents(),
 			dao.NewInstanceDao(&env().Database.SessionFactory),
 			dao.NewEventInstanceDao(&env().Database.SessionFactory),
+			statusEventRetention,
 		),
 	}
 
+	// the status events are kept for the resumed subscribers and deleted by their age
+	if statusEventRetention > 0 {
+		s.StatusEventPurger = controllers.NewStatusEventPurger(env().Services.StatusEvents(), statusEventRetention)
+	}
+
 	// disable the spec controller if the message broker is disabled
 	if !env().Config.MessageBroker.Disable {
 		logger.V(4).Info("Message broker is enabled, setting up kind controller manager")
@@ -61,6 +68,10 @@ func NewControllersServer(ctx context.Context, eventServer EventServer, eventFil
 		}
 	}
 
+	if env().Config.Idempotency.KeyTTL > 0 {
+		s.IdempotencyPurger = controllers.NewIdempotencyPurger(env().Services.Idempotency())
+	}
+
 	s.StatusController.Add(map[api.StatusEventType][]controllers.StatusHandlerFunc{
 		api.StatusUpdateEventType: {eventServer.OnStatusUpdate},
 		api.StatusDeleteEventType: {eventServer.OnStatusUpdate},
@@ -74,6 +85,8 @@ type ControllersServer struct {
 	StatusController      *controllers.StatusController
 	UndeliveredDetector   *controllers.UndeliveredDetector
 	StaleDeleteDetector   *controllers.StaleDeleteDetector
+	IdempotencyPurger     *controllers.IdempotencyPurger
+	StatusEventPurger     *controllers.StatusEventPurger
 
 	DB db.SessionFactory
 }
@@ -100,6 +113,16 @@ func (s ControllersServer) Start(ctx context.Context) {
 		go wait.JitterUntilWithContext(ctx, s.StaleDeleteDetector.Run, 2*time.Minute, 0.25, true)
 	}
 
+	if s.IdempotencyPurger != nil {
+		logger.Info("Starting expired idempotency record purger")
+		go wait.JitterUntilWithContext(ctx, s.IdempotencyPurger.Run, 10*time.Minute, 0.25, true)
+	}
+
+	if s.StatusEventPurger != nil {
+		logger.Info("Starting status event purger")
+		go wait.JitterUntilWithContext(ctx, s.StatusEventPurger.Run, 2*time.Minute, 0.25, true)
+	}
+
 	logger.Info("Status controller handling events")
 	go s.StatusController.Run(ctx)
 	logger.Info("Status controller listening for status events")
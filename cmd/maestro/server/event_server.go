@@ -3,8 +3,10 @@ package server
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/klog/v2"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/common"
@@ -14,11 +16,14 @@ import (
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
+	"github.com/openshift-online/maestro/pkg/constants"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/dispatcher"
+	"github.com/openshift-online/maestro/pkg/errors"
 	"github.com/openshift-online/maestro/pkg/event"
 	"github.com/openshift-online/maestro/pkg/services"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 // EventServer handles resource-related events:
@@ -45,6 +50,7 @@ type EventServer interface {
 }
 
 var _ EventServer = &MessageQueueEventServer{}
+var _ HealthChecker = &MessageQueueEventServer{}
 
 // MessageQueueEventServer represents a event server responsible for publish resource spec events
 // from resource controller and handle resource status update events from the message queue.
@@ -91,6 +97,15 @@ func (s *MessageQueueEventServer) Start(ctx context.Context) {
 	logger.Info("Shutting down message queue event server")
 }
 
+// CheckHealth returns an error if the source client is not subscribed to the message broker, i.e. it has not
+// subscribed yet, or it is disconnected and has not subscribed again.
+func (s *MessageQueueEventServer) CheckHealth(ctx context.Context) error {
+	if !s.sourceClient.Subscribed() {
+		return fmt.Errorf("the source client is not subscribed to the message broker")
+	}
+	return nil
+}
+
 // startSubscription initiates the subscription to resource status update messages.
 // It runs asynchronously in the background until the provided context is canceled.
 func (s *MessageQueueEventServer) startSubscription(ctx context.Context) {
@@ -208,6 +223,15 @@ func HandleStatusUpdate(ctx context.Context, resource *api.Resource, resourceSer
 	logger = sdkgologging.SetLogTracingByCloudEvent(logger, statusEvent)
 	ctx = klog.NewContext(ctx, logger)
 
+	// continue the trace from the agent, the trace context is persisted with the status event
+	// and is removed from the event to avoid storing it in the resource status
+	ctx, span := tracing.StartSpanFromCloudEvent(ctx, "maestro.status-update", statusEvent,
+		attribute.String("maestro.resource.id", resource.ID),
+		attribute.String("maestro.consumer.name", resource.ConsumerName),
+	)
+	defer span.End()
+	tracing.RemoveCloudEventTraceContext(statusEvent)
+
 	// convert the resource spec to cloudevent
 	specEvent, err := api.JSONMAPToCloudEvent(found.Payload)
 	if err != nil {
@@ -260,6 +284,7 @@ func HandleStatusUpdate(ctx context.Context, resource *api.Resource, resourceSer
 		if updated {
 			_, sErr := statusEventService.Create(ctx, &api.StatusEvent{
 				ResourceID:      resource.ID,
+				ResourceSource:  resource.Source,
 				StatusEventType: api.StatusUpdateEventType,
 			})
 			if sErr != nil {
@@ -286,31 +311,19 @@ func broadcastStatusEvent(ctx context.Context,
 
 	logger := klog.FromContext(ctx).WithValues("resourceID", resourceID, "instanceID", instanceID, "eventID", eventID)
 
-	var resource *api.Resource
-	// check if the status event is delete event
-	if statusEvent.StatusEventType == api.StatusDeleteEventType {
-		// build resource with resource id and delete status
-		resource = &api.Resource{
-			Meta: api.Meta{
-				ID: resourceID,
-			},
-			Source:  statusEvent.ResourceSource,
-			Type:    statusEvent.ResourceType,
-			Payload: statusEvent.Payload,
-			Status:  statusEvent.Status,
+	resource, sErr := statusEventResource(ctx, resourceService, statusEvent)
+	if sErr != nil {
+		if sErr.Is404() {
+			logger.Info("skipping resource as it is not found")
+			return nil
 		}
-	} else {
-		resource, sErr = resourceService.Get(ctx, resourceID)
-		if sErr != nil {
-			if sErr.Is404() {
-				logger.Info("skipping resource as it is not found")
-				return nil
-			}
 
-			return fmt.Errorf("failed to get resource %s: %s", resourceID, sErr.Error())
-		}
+		return fmt.Errorf("failed to get resource %s: %s", resourceID, sErr.Error())
 	}
 
+	// propagate the trace context to subscribers
+	tracing.SetJSONMapTraceContext(ctx, resource.Status)
+
 	// broadcast the resource status to subscribers
 	logger.Info("Broadcast the resource status",
 		"source", resource.Source, "statusEventType", statusEvent.StatusEventType)
@@ -347,3 +360,40 @@ func broadcastStatusEvent(ctx context.Context,
 
 	return err
 }
+
+// statusEventResource builds the resource to broadcast for a status event. A delete event carries the resource
+// spec and status because the resource is deleted already, otherwise the current resource is got. The sequence
+// number of the event is set on the resource status, so that the subscribers can resume from it after they
+// reconnect.
+func statusEventResource(ctx context.Context, resourceService services.ResourceService,
+	statusEvent *api.StatusEvent) (*api.Resource, *errors.ServiceError) {
+	var resource *api.Resource
+	// check if the status event is delete event
+	if statusEvent.StatusEventType == api.StatusDeleteEventType {
+		// build resource with resource id and delete status
+		resource = &api.Resource{
+			Meta: api.Meta{
+				ID: statusEvent.ResourceID,
+			},
+			Source:  statusEvent.ResourceSource,
+			Type:    statusEvent.ResourceType,
+			Payload: statusEvent.Payload,
+			Status:  statusEvent.Status,
+		}
+		// the consumer name is kept in the resource spec
+		if consumerName, ok := statusEvent.Payload[types.ExtensionClusterName].(string); ok {
+			resource.ConsumerName = consumerName
+		}
+	} else {
+		var sErr *errors.ServiceError
+		resource, sErr = resourceService.Get(ctx, statusEvent.ResourceID)
+		if sErr != nil {
+			return nil, sErr
+		}
+	}
+
+	if len(resource.Status) != 0 && statusEvent.SequenceNumber != 0 {
+		resource.Status[constants.ExtensionStatusSequence] = strconv.FormatInt(statusEvent.SequenceNumber, 10)
+	}
+	return resource, nil
+}
@@ -3,16 +3,17 @@ package server
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net"
-	"os"
+	"sync/atomic"
 	"time"
 
 	ce "github.com/cloudevents/sdk-go/v2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
@@ -24,13 +25,18 @@ import (
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
+	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/event"
 	"github.com/openshift-online/maestro/pkg/services"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 const source = "maestro"
 
+// brokerHealthDialTimeout is how long the health check of the gRPC broker waits to connect to the broker.
+const brokerHealthDialTimeout = 5 * time.Second
+
 var _ EventServer = &GRPCBroker{}
 
 type GRPCBrokerService struct {
@@ -73,6 +79,11 @@ func (s *GRPCBrokerService) HandleStatusUpdate(ctx context.Context, evt *ce.Even
 		return fmt.Errorf("failed to decode cloudevent: %v", err)
 	}
 
+	// the agent can only update the status of the resources on the cluster that it is authorized for
+	if clusterName, ok := authorizedClusterFromContext(ctx); ok && resource.ConsumerName != clusterName {
+		return fmt.Errorf("the agent of cluster %s is not allowed to update the status of consumer %s", clusterName, resource.ConsumerName)
+	}
+
 	// handle the resource status update according status update type
 	if err := HandleStatusUpdate(ctx, resource, s.resourceService, s.statusEventService); err != nil {
 		return fmt.Errorf("failed to handle resource status update %s: %s", resource.ID, err.Error())
@@ -101,10 +112,17 @@ type GRPCBroker struct {
 	eventService       services.EventService
 	statusEventService services.StatusEventService
 	eventBroadcaster   *event.EventBroadcaster // event broadcaster to broadcast resource status update events to subscribers
+	healthServer       *grpcHealthServer
+	serving            atomic.Bool
+	listenAddr         string // the address the broker listens on, it is set before serving is true
 }
 
+var _ HealthChecker = &GRPCBroker{}
+
 // NewGRPCBroker creates a new gRPC broker with the given configuration.
-func NewGRPCBroker(ctx context.Context, eventBroadcaster *event.EventBroadcaster) EventServer {
+// If its authentication type is not mock, the agents are authenticated and authorized by the given authorizer
+// to subscribe and publish the events of their clusters (consumers), such a broker must be served with TLS.
+func NewGRPCBroker(ctx context.Context, eventBroadcaster *event.EventBroadcaster, grpcAuthorizer grpcauthorizer.GRPCAuthorizer) EventServer {
 	logger := klog.FromContext(ctx)
 
 	config := *env().Config.GRPCServer
@@ -135,34 +153,48 @@ func NewGRPCBroker(ctx context.Context, eventBroadcaster *event.EventBroadcaster
 				"Can't start gRPC broker",
 			)
 		}
-		// Serve with TLS
-		serverCerts, err := tls.LoadX509KeyPair(config.BrokerTLSCertFile, config.BrokerTLSKeyFile)
+		if config.BrokerClientCAFile == "" && config.BrokerGRPCAuthNType == "mtls" {
+			check(ctx, fmt.Errorf("no broker client CA file specified when using mtls authorization type"), "Can't start gRPC broker")
+		}
+
+		// Serve with TLS, the certificates and the client CA are reloaded when they are rotated
+		reloader, err := newCertReloader("grpc_broker", config.BrokerTLSCertFile, config.BrokerTLSKeyFile, config.BrokerClientCAFile)
 		if err != nil {
 			check(ctx, fmt.Errorf("failed to load broker certificates: %v", err), "Can't start gRPC broker")
 		}
+		go reloader.Run(ctx)
+
 		tlsConfig := &tls.Config{
-			Certificates: []tls.Certificate{serverCerts},
-			MinVersion:   tls.VersionTLS13,
-			MaxVersion:   tls.VersionTLS13,
+			MinVersion: tls.VersionTLS13,
+			MaxVersion: tls.VersionTLS13,
 		}
 		if config.BrokerClientCAFile != "" {
-			certPool, err := x509.SystemCertPool()
-			if err != nil {
-				check(ctx, fmt.Errorf("failed to load system cert pool: %v", err), "Can't start gRPC broker")
-			}
-			caPEM, err := os.ReadFile(config.BrokerClientCAFile)
-			if err != nil {
-				check(ctx, fmt.Errorf("failed to read broker client CA file: %v", err), "Can't start gRPC broker")
-			}
-			if ok := certPool.AppendCertsFromPEM(caPEM); !ok {
-				check(ctx, fmt.Errorf("failed to append broker client CA to cert pool"), "Can't start gRPC broker")
-			}
-			tlsConfig.ClientCAs = certPool
 			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
 		}
-		grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+
+		// add auth interceptors, the authorization interceptors use the identity from the authentication interceptors
+		if config.BrokerGRPCAuthNType != "mock" {
+			grpcServerOptions = append(grpcServerOptions,
+				grpc.ChainUnaryInterceptor(
+					newAuthUnaryInterceptor(config.BrokerGRPCAuthNType, grpcAuthorizer),
+					newBrokerAuthzUnaryInterceptor(grpcAuthorizer)),
+				grpc.ChainStreamInterceptor(
+					newAuthStreamInterceptor(config.BrokerGRPCAuthNType, grpcAuthorizer),
+					newBrokerAuthzStreamInterceptor(grpcAuthorizer)))
+			logger.Info("Authorizing gRPC broker agents", "authNType", config.BrokerGRPCAuthNType)
+		}
+
+		grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(reloader.TLSConfig(tlsConfig))))
 		logger.Info("Serving gRPC broker with TLS", "port", config.BrokerBindPort)
 	} else {
+		// the agents can't be authenticated over plaintext, refuse to serve them unauthorized
+		if config.BrokerGRPCAuthNType != "mock" {
+			check(ctx,
+				fmt.Errorf("the %s broker authentication type requires --grpc-broker-tls-cert-file and --grpc-broker-tls-key-file",
+					config.BrokerGRPCAuthNType),
+				"Can't start gRPC broker",
+			)
+		}
 		logger.Info("Serving gRPC broker without TLS", "port", config.BrokerBindPort)
 	}
 
@@ -180,7 +212,11 @@ func NewGRPCBroker(ctx context.Context, eventBroadcaster *event.EventBroadcaster
 	svc := NewGRPCBrokerService(resourceService, statusEventService)
 	eventServer.RegisterService(context.Background(), workpayload.ManifestBundleEventDataType, svc)
 
-	return &GRPCBroker{
+	if config.EnableReflection {
+		reflection.Register(grpcServer)
+	}
+
+	broker := &GRPCBroker{
 		instanceID:         env().Config.MessageBroker.ClientID,
 		bindAddress:        env().Config.HTTPServer.Hostname + ":" + config.BrokerBindPort,
 		grpcServer:         grpcServer,
@@ -191,6 +227,13 @@ func NewGRPCBroker(ctx context.Context, eventBroadcaster *event.EventBroadcaster
 		statusEventService: statusEventService,
 		eventBroadcaster:   eventBroadcaster,
 	}
+
+	// the broker service is reported separately from the source services of the gRPC server
+	broker.healthServer = newGRPCHealthServer([]string{BrokerHealthService, pbv1.CloudEventService_ServiceDesc.ServiceName},
+		append(newGRPCReadinessChecks(), healthCheck{name: "grpc-broker", check: broker.CheckHealth})...)
+	healthpb.RegisterHealthServer(grpcServer, broker.healthServer)
+
+	return broker
 }
 
 // Start starts the gRPC broker
@@ -206,13 +249,32 @@ func (bkr *GRPCBroker) Start(ctx context.Context) {
 			check(ctx, err, "gRPC broker terminated with errors")
 		}
 	}()
+	bkr.listenAddr = ln.Addr().String()
+	bkr.serving.Store(true)
+	go bkr.healthServer.Run(ctx)
 
 	// wait until context is done
 	<-ctx.Done()
 	logger.Info("Stopping gRPC broker", "bindAddress", bkr.bindAddress)
+	bkr.serving.Store(false)
 	bkr.grpcServer.GracefulStop()
 }
 
+// CheckHealth returns an error if the gRPC broker is not serving or its listener does not accept connections,
+// e.g. the listener is closed or the broker is out of file descriptors.
+func (bkr *GRPCBroker) CheckHealth(ctx context.Context) error {
+	if !bkr.serving.Load() {
+		return fmt.Errorf("the gRPC broker is not serving on %s", bkr.bindAddress)
+	}
+
+	dialer := net.Dialer{Timeout: brokerHealthDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", bkr.listenAddr)
+	if err != nil {
+		return fmt.Errorf("the gRPC broker does not accept connections on %s: %v", bkr.bindAddress, err)
+	}
+	return conn.Close()
+}
+
 // OnCreate is called by the controller when a resource is created on the maestro server.
 func (s *GRPCBroker) OnCreate(ctx context.Context, resourceID string) error {
 	logger := klog.FromContext(ctx).WithValues("resourceID", resourceID)
@@ -239,6 +301,8 @@ func (s *GRPCBroker) OnCreate(ctx context.Context, resourceID string) error {
 	if err != nil {
 		return kubeerrors.NewInternalError(err)
 	}
+	// propagate the trace context to the agent
+	tracing.SetCloudEventTraceContext(ctx, evt)
 	return s.eventServer.HandleEvent(ctx, evt)
 }
 
@@ -269,6 +333,8 @@ func (s *GRPCBroker) OnUpdate(ctx context.Context, resourceID string) error {
 	if err != nil {
 		return kubeerrors.NewInternalError(err)
 	}
+	// propagate the trace context to the agent
+	tracing.SetCloudEventTraceContext(ctx, evt)
 	return s.eventServer.HandleEvent(ctx, evt)
 }
 
@@ -285,6 +351,8 @@ func (s *GRPCBroker) OnDelete(ctx context.Context, resourceID string) error {
 		return err
 	}
 
+	// propagate the trace context to the agent
+	tracing.SetCloudEventTraceContext(ctx, evt)
 	return s.eventServer.HandleEvent(ctx, evt)
 }
 
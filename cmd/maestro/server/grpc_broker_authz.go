@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cetypes "github.com/cloudevents/sdk-go/v2/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+
+	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
+)
+
+// contextClusterKey is the context key of the cluster (consumer) name that the agent is authorized for.
+const contextClusterKey contextKey = "cluster"
+
+// authorizedClusterFromContext returns the cluster name that the agent is authorized for, it returns false
+// if the broker authorization is disabled.
+func authorizedClusterFromContext(ctx context.Context) (string, bool) {
+	clusterName, ok := ctx.Value(contextClusterKey).(string)
+	return clusterName, ok
+}
+
+// reviewClusterAccess checks if the agent identity in the given context is allowed to perform the action on the cluster.
+func reviewClusterAccess(ctx context.Context, authorizer grpcauthorizer.GRPCAuthorizer, action, clusterName string) error {
+	if clusterName == "" {
+		return status.Error(codes.InvalidArgument, "the cluster name is required")
+	}
+
+	user, _ := ctx.Value(contextUserKey).(string)
+	groups, _ := ctx.Value(contextGroupsKey).([]string)
+	allowed, err := authorizer.AccessReview(ctx, action, "cluster", clusterName, user, groups)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("failed to authorize the request: %v", err))
+	}
+	if !allowed {
+		klog.FromContext(ctx).Info("the agent is not authorized", "action", action, "cluster", clusterName, "user", user, "groups", groups)
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("unauthorized to %s the events of cluster %s", action, clusterName))
+	}
+	return nil
+}
+
+// newBrokerAuthzUnaryInterceptor creates a unary interceptor that authorizes the agent to publish the events
+// (status updates and resync requests) of the cluster in the event, the authorized cluster is added to the context.
+// It must be chained after the authentication interceptor.
+func newBrokerAuthzUnaryInterceptor(authorizer grpcauthorizer.GRPCAuthorizer) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		pubReq, ok := req.(*pbv1.PublishRequest)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		// WARNING: don't use "evt, err := pb.FromProto(pubReq.Event)" to convert protobuf to cloudevent
+		evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pubReq.Event))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to convert protobuf to cloudevent: %v", err))
+		}
+
+		clusterName, err := cetypes.ToString(evt.Extensions()[types.ExtensionClusterName])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to get clustername extension: %v", err))
+		}
+
+		if err := reviewClusterAccess(ctx, authorizer, "pub", clusterName); err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, contextClusterKey, clusterName), req)
+	}
+}
+
+// authorizedSubscriptionStream replays the subscription request that is already read and authorized
+// by the interceptor to the handler.
+type authorizedSubscriptionStream struct {
+	grpc.ServerStream
+
+	mu            sync.Mutex
+	authorizedReq *pbv1.SubscriptionRequest
+}
+
+// RecvMsg returns the authorized subscription request on the first call.
+func (s *authorizedSubscriptionStream) RecvMsg(m any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authorizedReq == nil {
+		return s.ServerStream.RecvMsg(m)
+	}
+
+	msg, ok := m.(*pbv1.SubscriptionRequest)
+	if !ok {
+		return fmt.Errorf("unsupported request type %T", m)
+	}
+	proto.Merge(msg, s.authorizedReq)
+	s.authorizedReq = nil
+	return nil
+}
+
+// newBrokerAuthzStreamInterceptor creates a stream interceptor that authorizes the agent to subscribe the
+// events of the cluster in the subscription request. It must be chained after the authentication interceptor.
+func newBrokerAuthzStreamInterceptor(authorizer grpcauthorizer.GRPCAuthorizer) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// only the subscriptions are authorized, e.g. the health watches are not
+		if info.FullMethod != pbv1.CloudEventService_Subscribe_FullMethodName {
+			return handler(srv, ss)
+		}
+
+		// the subscription request is the first message of the stream
+		subReq := &pbv1.SubscriptionRequest{}
+		if err := ss.RecvMsg(subReq); err != nil {
+			return err
+		}
+
+		if err := reviewClusterAccess(ss.Context(), authorizer, "sub", subReq.ClusterName); err != nil {
+			return err
+		}
+
+		ctx := context.WithValue(ss.Context(), contextClusterKey, subReq.ClusterName)
+		return handler(srv, &authorizedSubscriptionStream{
+			ServerStream:  newWrappedAuthStream(ctx, ss),
+			authorizedReq: subReq,
+		})
+	}
+}
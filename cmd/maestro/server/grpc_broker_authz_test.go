@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+
+	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
+)
+
+// clusterAuthorizer allows the agent user "system:agent:<cluster>" to access its own cluster only.
+type clusterAuthorizer struct {
+	grpcauthorizer.GRPCAuthorizer
+}
+
+func (a *clusterAuthorizer) AccessReview(_ context.Context, _, resourceType, resource, user string, _ []string) (bool, error) {
+	return resourceType == "cluster" && user == "system:agent:"+resource, nil
+}
+
+func newStatusEvent(t *testing.T, clusterName string) *ce.Event {
+	t.Helper()
+	evt := ce.NewEvent()
+	evt.SetID("2cd3ad6e-2e8b-4b23-9d0f-6a5a3a1cfe51")
+	evt.SetSource("agent")
+	evt.SetType(types.CloudEventsType{
+		CloudEventsDataType: workpayload.ManifestBundleEventDataType,
+		SubResource:         types.SubResourceStatus,
+		Action:              types.UpdateRequestAction,
+	}.String())
+	evt.SetExtension(types.ExtensionResourceID, "c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4")
+	evt.SetExtension(types.ExtensionResourceVersion, 1)
+	evt.SetExtension(types.ExtensionClusterName, clusterName)
+	evt.SetExtension(types.ExtensionOriginalSource, source)
+	if err := evt.SetData(ce.ApplicationJSON, &workpayload.ManifestBundleStatus{}); err != nil {
+		t.Fatal(err)
+	}
+	return &evt
+}
+
+func TestBrokerAuthzUnaryInterceptor(t *testing.T) {
+	RegisterTestingT(t)
+
+	interceptor := newBrokerAuthzUnaryInterceptor(&clusterAuthorizer{})
+
+	pbEvt := &pbv1.CloudEvent{}
+	err := grpcprotocol.WritePBMessage(context.Background(), binding.ToMessage(newStatusEvent(t, "cluster1")), pbEvt)
+	Expect(err).NotTo(HaveOccurred())
+	req := &pbv1.PublishRequest{Event: pbEvt}
+
+	var authorizedCluster string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		authorizedCluster, _ = authorizedClusterFromContext(ctx)
+		return nil, nil
+	}
+
+	// the agent of cluster1 can publish the status of cluster1
+	ctx := newContextWithIdentity(context.Background(), "system:agent:cluster1", []string{"agents"})
+	_, err = interceptor(ctx, req, &grpc.UnaryServerInfo{}, handler)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(authorizedCluster).To(Equal("cluster1"))
+
+	// the agent of cluster2 cannot publish the status of cluster1
+	ctx = newContextWithIdentity(context.Background(), "system:agent:cluster2", []string{"agents"})
+	_, err = interceptor(ctx, req, &grpc.UnaryServerInfo{}, handler)
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+}
+
+func TestBrokerHandleStatusUpdateRejectsUnauthorizedCluster(t *testing.T) {
+	RegisterTestingT(t)
+
+	svc := NewGRPCBrokerService(nil, nil)
+	ctx := context.WithValue(context.Background(), contextClusterKey, "cluster2")
+	err := svc.HandleStatusUpdate(ctx, newStatusEvent(t, "cluster1"))
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("not allowed to update the status of consumer cluster1"))
+}
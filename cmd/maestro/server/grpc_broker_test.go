@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"testing"
 	"time"
 
@@ -247,3 +248,22 @@ func TestGRPCBrokerOnDeletePublishesForExistingResource(t *testing.T) {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(fakeEventServer.handledEvents).To(HaveLen(1))
 }
+
+func TestGRPCBrokerCheckHealth(t *testing.T) {
+	RegisterTestingT(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	defer ln.Close()
+
+	broker := &GRPCBroker{bindAddress: ln.Addr().String()}
+	Expect(broker.CheckHealth(context.Background())).To(MatchError(ContainSubstring("is not serving")))
+
+	broker.listenAddr = ln.Addr().String()
+	broker.serving.Store(true)
+	Expect(broker.CheckHealth(context.Background())).To(Succeed())
+
+	// the broker is serving, but its listener is gone
+	Expect(ln.Close()).To(Succeed())
+	Expect(broker.CheckHealth(context.Background())).To(MatchError(ContainSubstring("does not accept connections")))
+}
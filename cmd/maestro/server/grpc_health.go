@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+const (
+	// SourceHealthService is the service name in the gRPC health checks of the gRPC server, it reports whether the
+	// sources can publish resources and subscribe to their status.
+	SourceHealthService = "maestro.source"
+	// BrokerHealthService is the service name in the gRPC health checks of the gRPC broker, it reports whether the
+	// agents can subscribe to resources and publish their status.
+	BrokerHealthService = "maestro.broker"
+
+	// grpcHealthCheckPeriod is how often the serving status of the gRPC health service is updated.
+	grpcHealthCheckPeriod = 10 * time.Second
+)
+
+// grpcHealthServer implements the grpc.health.v1 service, the serving status of the overall server ("") and of
+// the given services is updated by running the health checks periodically.
+type grpcHealthServer struct {
+	*health.Server
+
+	services []string
+	checks   []healthCheck
+}
+
+func newGRPCHealthServer(services []string, checks ...healthCheck) *grpcHealthServer {
+	h := &grpcHealthServer{
+		Server:   health.NewServer(),
+		services: services,
+		checks:   checks,
+	}
+	// the services are not serving until the checks pass
+	h.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+// newGRPCReadinessChecks returns the health checks of the gRPC health services, the current instance must be ready
+// and the database must be reachable.
+func newGRPCReadinessChecks() []healthCheck {
+	sessionFactory := env().Database.SessionFactory
+	instanceDao := dao.NewInstanceDao(&sessionFactory)
+	instanceID := env().Config.MessageBroker.ClientID
+	return []healthCheck{
+		{name: "instance", check: func(ctx context.Context) error {
+			return checkInstanceReady(ctx, instanceDao, instanceID)
+		}},
+		{name: "database", check: func(ctx context.Context) error {
+			return sessionFactory.CheckConnection()
+		}},
+	}
+}
+
+// Run updates the serving status until the context is done, all the services are not serving after that.
+func (h *grpcHealthServer) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, h.update, grpcHealthCheckPeriod)
+	h.Shutdown()
+}
+
+func (h *grpcHealthServer) update(ctx context.Context) {
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	for _, c := range h.checks {
+		if err := c.check(ctx); err != nil {
+			klog.FromContext(ctx).Info("gRPC health check failed", "check", c.name, "services", h.services, "message", err.Error())
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+			break
+		}
+	}
+	h.setServingStatus(servingStatus)
+}
+
+func (h *grpcHealthServer) setServingStatus(servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	h.SetServingStatus("", servingStatus)
+	for _, service := range h.services {
+		h.SetServingStatus(service, servingStatus)
+	}
+}
+
+// isHealthCheckMethod returns true if the method belongs to the gRPC health service, the health checks are not
+// authenticated so that the load balancers can probe the gRPC endpoints.
+func isHealthCheckMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
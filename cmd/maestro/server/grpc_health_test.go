@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+func TestGRPCHealthServer(t *testing.T) {
+	RegisterTestingT(t)
+
+	var dbErr error
+	h := newGRPCHealthServer([]string{BrokerHealthService},
+		healthCheck{name: "instance", check: func(ctx context.Context) error { return nil }},
+		healthCheck{name: "database", check: func(ctx context.Context) error { return dbErr }},
+	)
+	ctx := context.Background()
+
+	servingStatus := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := h.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		Expect(err).NotTo(HaveOccurred())
+		return resp.Status
+	}
+
+	// not serving until the checks pass
+	Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+	Expect(servingStatus(BrokerHealthService)).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+
+	h.update(ctx)
+	Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	Expect(servingStatus(BrokerHealthService)).To(Equal(healthpb.HealthCheckResponse_SERVING))
+
+	dbErr = fmt.Errorf("connection refused")
+	h.update(ctx)
+	Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+	Expect(servingStatus(BrokerHealthService)).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+
+	// the source service is only reported by the gRPC server
+	_, err := h.Check(ctx, &healthpb.HealthCheckRequest{Service: SourceHealthService})
+	Expect(err).To(HaveOccurred())
+}
+
+func TestAuthInterceptorSkipsHealthChecks(t *testing.T) {
+	RegisterTestingT(t)
+
+	interceptor := newAuthUnaryInterceptor("token", nil)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	// the health checks have no token
+	resp, err := interceptor(context.Background(), &healthpb.HealthCheckRequest{},
+		&grpc.UnaryServerInfo{FullMethod: healthpb.Health_Check_FullMethodName}, handler)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp).To(Equal("ok"))
+
+	_, err = interceptor(context.Background(), &pbv1.PublishRequest{},
+		&grpc.UnaryServerInfo{FullMethod: pbv1.CloudEventService_Publish_FullMethodName}, handler)
+	Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/common"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	rbv1 "github.com/openshift-online/maestro/pkg/api/grpc/resourcebundle/v1"
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/event"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// defaultListLimit is the default number of resource bundles in a list response, it is the same as the REST API.
+const defaultListLimit = 100
+
+// resourceBundleServer implements the ResourceBundleService on the gRPC server, the caller must be allowed to
+// subscribe to the source of the resource bundles.
+type resourceBundleServer struct {
+	rbv1.UnimplementedResourceBundleServiceServer
+
+	resourceService   services.ResourceService
+	eventBroadcaster  *event.EventBroadcaster
+	grpcAuthorizer    grpcauthorizer.GRPCAuthorizer
+	disableAuthorizer bool
+	subscriberConfig  subscriberConfig
+}
+
+var _ rbv1.ResourceBundleServiceServer = &resourceBundleServer{}
+
+func newResourceBundleServer(resourceService services.ResourceService, eventBroadcaster *event.EventBroadcaster,
+	grpcAuthorizer grpcauthorizer.GRPCAuthorizer, disableAuthorizer bool, subscriberConfig subscriberConfig) *resourceBundleServer {
+	return &resourceBundleServer{
+		resourceService:   resourceService,
+		eventBroadcaster:  eventBroadcaster,
+		grpcAuthorizer:    grpcAuthorizer,
+		disableAuthorizer: disableAuthorizer,
+		subscriberConfig:  subscriberConfig,
+	}
+}
+
+// Get implements the Get method of the ResourceBundleServiceServer interface
+func (s *resourceBundleServer) Get(ctx context.Context, req *rbv1.GetRequest) (*rbv1.ResourceBundle, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "the resource bundle id is required")
+	}
+
+	// a caller that is not allowed to get the resource bundle gets the same error as for a missing one, so that it
+	// cannot find out the IDs of the resource bundles of other sources
+	notFound := status.Error(codes.NotFound, fmt.Sprintf("resource bundle %s not found", req.Id))
+
+	resource, serviceErr := s.resourceService.Get(ctx, req.Id)
+	if serviceErr != nil {
+		if serviceErr.HttpCode == http.StatusNotFound {
+			return nil, notFound
+		}
+		return nil, serviceErrorToStatus(serviceErr)
+	}
+
+	if err := s.authorize(ctx, resource.Source); err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return nil, notFound
+		}
+		return nil, err
+	}
+
+	return toProtoResourceBundle(resource)
+}
+
+// List implements the List method of the ResourceBundleServiceServer interface
+func (s *resourceBundleServer) List(ctx context.Context, req *rbv1.ListRequest) (*rbv1.ListResponse, error) {
+	if err := validateSource(req.Source); err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, req.Source); err != nil {
+		return nil, err
+	}
+
+	page := 1
+	if req.Continue != "" {
+		var err error
+		page, err = strconv.Atoi(req.Continue)
+		if err != nil || page < 1 {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid continue token %q", req.Continue))
+		}
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "the limit cannot be less than 0")
+	}
+	limit := int64(req.Limit)
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	// the search is parsed on its own, and the source restriction is a separate filter of the list instead of a part
+	// of the search string, so that the search cannot widen it
+	if req.Search != "" {
+		if _, err := tsl.ParseTSL(req.Search); err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid search %q: %v", req.Search, err))
+		}
+	}
+	listArgs := services.NewListArguments(url.Values{
+		"page":   []string{strconv.Itoa(page)},
+		"size":   []string{strconv.FormatInt(limit, 10)},
+		"search": []string{req.Search},
+	})
+	listArgs.Filters = map[string]interface{}{"source": req.Source}
+
+	var resources []api.Resource
+	paging, serviceErr := s.resourceService.ListWithArgs(ctx, "", listArgs, &resources)
+	if serviceErr != nil {
+		return nil, serviceErrorToStatus(serviceErr)
+	}
+
+	resp := &rbv1.ListResponse{Total: paging.Total}
+	for i := range resources {
+		bundle, err := toProtoResourceBundle(&resources[i])
+		if err != nil {
+			return nil, err
+		}
+		resp.Items = append(resp.Items, bundle)
+	}
+	if int64(paging.Page)*listArgs.Size < paging.Total {
+		resp.Continue = strconv.Itoa(paging.Page + 1)
+	}
+	return resp, nil
+}
+
+// Watch implements the Watch method of the ResourceBundleServiceServer interface, it streams the status changes
+// of the resource bundles until the client cancels the watch. A slow watcher is handled like a slow status
+// subscriber.
+func (s *resourceBundleServer) Watch(req *rbv1.WatchRequest, watchServer rbv1.ResourceBundleService_WatchServer) error {
+	if err := validateSource(req.Source); err != nil {
+		return err
+	}
+	if err := s.authorize(watchServer.Context(), req.Source); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(watchServer.Context())
+	defer cancel()
+
+	logger := klog.FromContext(ctx).WithValues("source", req.Source, "consumer", req.ConsumerName)
+
+	watchID := uuid.NewString()
+	queue := newSubscriberQueue(req.Source, watchID, s.subscriberConfig, s.resourceService)
+	defer queue.Close()
+
+	disconnectErrCh := make(chan error, 1)
+	s.eventBroadcaster.Register(ctx, watchID, req.Source, func(res *api.Resource) error {
+		if req.ConsumerName != "" && res.ConsumerName != req.ConsumerName {
+			return nil
+		}
+
+		if err := queue.Push(ctx, res); err != nil {
+			// the watcher is too slow, stop the watch so that the client lists and watches again
+			select {
+			case disconnectErrCh <- status.Error(codes.ResourceExhausted, err.Error()):
+			default:
+			}
+			cancel()
+			return err
+		}
+		return nil
+	})
+	defer s.eventBroadcaster.Unregister(ctx, watchID)
+
+	for {
+		res, ok := queue.Pop(ctx)
+		if !ok {
+			select {
+			case err := <-disconnectErrCh:
+				return err
+			default:
+				return nil
+			}
+		}
+
+		evt, err := toProtoWatchEvent(res)
+		if err != nil {
+			logger.Error(err, "failed to convert resource to watch event", "resourceID", res.ID)
+			continue
+		}
+
+		if err := watchServer.Send(evt); err != nil {
+			logger.Error(err, "failed to send watch event")
+			return err
+		}
+	}
+}
+
+// authorize checks if the caller is allowed to subscribe to the given source.
+func (s *resourceBundleServer) authorize(ctx context.Context, source string) error {
+	if s.disableAuthorizer {
+		return nil
+	}
+
+	user, _ := ctx.Value(contextUserKey).(string)
+	groups, _ := ctx.Value(contextGroupsKey).([]string)
+	allowed, err := s.grpcAuthorizer.AccessReview(ctx, "sub", "source", source, user, groups)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("failed to authorize the request: %v", err))
+	}
+	if !allowed {
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("unauthorized to get the resource bundles of source %s", source))
+	}
+	return nil
+}
+
+func validateSource(source string) error {
+	if source == "" {
+		return status.Error(codes.InvalidArgument, "the source is required")
+	}
+	if strings.Contains(source, "'") {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid source %q", source))
+	}
+	return nil
+}
+
+func toProtoResourceBundle(resource *api.Resource) (*rbv1.ResourceBundle, error) {
+	bundle, err := presenters.PresentResourceBundle(resource)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to present resource bundle %s: %v", resource.ID, err))
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to marshal resource bundle %s: %v", resource.ID, err))
+	}
+
+	return &rbv1.ResourceBundle{
+		Id:           resource.ID,
+		ConsumerName: resource.ConsumerName,
+		Version:      resource.Version,
+		Data:         data,
+	}, nil
+}
+
+// toProtoWatchEvent converts a resource status change to a watch event, the event type is DELETED if the agent
+// reports the resource is deleted.
+func toProtoWatchEvent(resource *api.Resource) (*rbv1.WatchEvent, error) {
+	bundle, err := toProtoResourceBundle(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	evt := &rbv1.WatchEvent{Type: rbv1.WatchEvent_MODIFIED, ResourceBundle: bundle}
+	if len(resource.Status) == 0 {
+		return evt, nil
+	}
+
+	statusEvt, err := api.JSONMAPToCloudEvent(resource.Status)
+	if err != nil {
+		return nil, err
+	}
+	manifestBundleStatus := &workpayload.ManifestBundleStatus{}
+	if err := statusEvt.DataAs(manifestBundleStatus); err != nil {
+		return nil, err
+	}
+	if meta.IsStatusConditionTrue(manifestBundleStatus.Conditions, common.ResourceDeleted) {
+		evt.Type = rbv1.WatchEvent_DELETED
+	}
+	return evt, nil
+}
+
+// serviceErrorToStatus converts a service error to a gRPC status error.
+func serviceErrorToStatus(serviceErr *errors.ServiceError) error {
+	code := codes.Internal
+	switch serviceErr.HttpCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, serviceErr.Error())
+}
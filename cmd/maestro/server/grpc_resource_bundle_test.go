@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	rbv1 "github.com/openshift-online/maestro/pkg/api/grpc/resourcebundle/v1"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// sourceAuthorizer allows the user "<source>-client" to subscribe to the source only.
+type sourceAuthorizer struct {
+	grpcauthorizer.GRPCAuthorizer
+}
+
+func (a *sourceAuthorizer) AccessReview(_ context.Context, action, resourceType, resource, user string, _ []string) (bool, error) {
+	return action == "sub" && resourceType == "source" && user == resource+"-client", nil
+}
+
+// listResourceService returns the configured resources for any list and records the list arguments.
+type listResourceService struct {
+	services.ResourceService
+	resources []api.Resource
+	total     int64
+	args      *services.ListArguments
+}
+
+func (s *listResourceService) Get(_ context.Context, id string) (*api.Resource, *errors.ServiceError) {
+	for i := range s.resources {
+		if s.resources[i].ID == id {
+			return &s.resources[i], nil
+		}
+	}
+	return nil, errors.NotFound("resource %s not found", id)
+}
+
+func (s *listResourceService) ListWithArgs(_ context.Context, _ string, args *services.ListArguments, resources *[]api.Resource) (*api.PagingMeta, *errors.ServiceError) {
+	s.args = args
+	*resources = s.resources
+	return &api.PagingMeta{Page: args.Page, Size: int64(len(s.resources)), Total: s.total}, nil
+}
+
+func TestResourceBundleServerList(t *testing.T) {
+	RegisterTestingT(t)
+
+	resourceService := &listResourceService{
+		resources: []api.Resource{
+			{Meta: api.Meta{ID: "ab2bcd48-2fe9-4a7d-9b31-02a6e3b0d9a1"}, Source: "maestro", ConsumerName: "cluster1", Version: 1, Payload: testPayload(t)},
+			{Meta: api.Meta{ID: "0e6fa5b9-54a9-45b6-a1a4-0ee0f6b85c36"}, Source: "maestro", ConsumerName: "cluster1", Version: 2, Payload: testPayload(t)},
+		},
+		total: 5,
+	}
+	server := newResourceBundleServer(resourceService, nil, &sourceAuthorizer{}, false, subscriberConfig{})
+	ctx := context.WithValue(context.Background(), contextUserKey, "maestro-client")
+
+	resp, err := server.List(ctx, &rbv1.ListRequest{Source: "maestro", Search: "consumer_name='cluster1'", Limit: 2})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resourceService.args.Search).To(Equal("consumer_name='cluster1'"))
+	Expect(resourceService.args.Filters).To(Equal(map[string]interface{}{"source": "maestro"}))
+	Expect(resourceService.args.Page).To(Equal(1))
+	Expect(resourceService.args.Size).To(Equal(int64(2)))
+	Expect(resp.Total).To(Equal(int64(5)))
+	Expect(resp.Continue).To(Equal("2"))
+	Expect(resp.Items).To(HaveLen(2))
+	Expect(resp.Items[1].Id).To(Equal("0e6fa5b9-54a9-45b6-a1a4-0ee0f6b85c36"))
+	Expect(resp.Items[1].ConsumerName).To(Equal("cluster1"))
+	Expect(resp.Items[1].Version).To(Equal(int32(2)))
+
+	rb := &openapi.ResourceBundle{}
+	Expect(json.Unmarshal(resp.Items[1].Data, rb)).To(Succeed())
+	Expect(rb.GetId()).To(Equal("0e6fa5b9-54a9-45b6-a1a4-0ee0f6b85c36"))
+	Expect(rb.GetManifests()).To(HaveLen(1))
+
+	// the last page has no continue token
+	resp, err = server.List(ctx, &rbv1.ListRequest{Source: "maestro", Limit: 2, Continue: "3"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resourceService.args.Search).To(BeEmpty())
+	Expect(resourceService.args.Filters).To(Equal(map[string]interface{}{"source": "maestro"}))
+	Expect(resourceService.args.Page).To(Equal(3))
+	Expect(resp.Continue).To(BeEmpty())
+
+	_, err = server.List(ctx, &rbv1.ListRequest{Source: "maestro", Continue: "invalid"})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+	_, err = server.List(ctx, &rbv1.ListRequest{Source: "maestro' or source='other"})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+	// a search cannot break out of the source restriction, it is rejected if it does not parse on its own
+	_, err = server.List(ctx, &rbv1.ListRequest{Source: "maestro", Search: "consumer_name='x') or (source='other'"})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+	// a search that parses on its own is passed as it is, the source stays a separate filter
+	_, err = server.List(ctx, &rbv1.ListRequest{Source: "maestro", Search: "consumer_name='x' or source='other'"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resourceService.args.Search).To(Equal("consumer_name='x' or source='other'"))
+	Expect(resourceService.args.Filters).To(Equal(map[string]interface{}{"source": "maestro"}))
+
+	_, err = server.List(ctx, &rbv1.ListRequest{Source: "other"})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+}
+
+func TestResourceBundleServerGet(t *testing.T) {
+	RegisterTestingT(t)
+
+	resourceService := &listResourceService{
+		resources: []api.Resource{
+			{Meta: api.Meta{ID: "ab2bcd48-2fe9-4a7d-9b31-02a6e3b0d9a1"}, Source: "maestro", ConsumerName: "cluster1", Version: 1, Payload: testPayload(t)},
+		},
+	}
+	server := newResourceBundleServer(resourceService, nil, &sourceAuthorizer{}, false, subscriberConfig{})
+
+	rb, err := server.Get(context.WithValue(context.Background(), contextUserKey, "maestro-client"),
+		&rbv1.GetRequest{Id: "ab2bcd48-2fe9-4a7d-9b31-02a6e3b0d9a1"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(rb.ConsumerName).To(Equal("cluster1"))
+
+	_, err = server.Get(context.WithValue(context.Background(), contextUserKey, "maestro-client"),
+		&rbv1.GetRequest{Id: "e1b1bdf5-a4cb-4e61-a0ba-4a0f5ed51c3d"})
+	Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+	// the resource bundles of a source are not visible for the clients of other sources, they get the same error as
+	// for a missing resource bundle
+	_, err = server.Get(context.WithValue(context.Background(), contextUserKey, "other-client"),
+		&rbv1.GetRequest{Id: "ab2bcd48-2fe9-4a7d-9b31-02a6e3b0d9a1"})
+	Expect(status.Code(err)).To(Equal(codes.NotFound))
+	Expect(err.Error()).To(ContainSubstring("resource bundle ab2bcd48-2fe9-4a7d-9b31-02a6e3b0d9a1 not found"))
+
+	_, err = server.Get(context.WithValue(context.Background(), contextUserKey, "other-client"),
+		&rbv1.GetRequest{Id: "e1b1bdf5-a4cb-4e61-a0ba-4a0f5ed51c3d"})
+	Expect(status.Code(err)).To(Equal(codes.NotFound))
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/constants"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// replayPageSize is the number of status events read from the database at a time during a replay.
+const replayPageSize = 500
+
+// resumeCursor returns the resume cursor of a subscription request, it returns false if the subscriber does not
+// resume from a cursor.
+func resumeCursor(ctx context.Context) (int64, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false, nil
+	}
+
+	values := md.Get(constants.ResumeCursorMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return 0, false, nil
+	}
+
+	cursor, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || cursor < 1 {
+		return 0, false, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid resume cursor %q", values[0]))
+	}
+	return cursor, true, nil
+}
+
+// statusSequence returns the sequence number of the status event that a resource is broadcast for, it returns 0 if
+// the resource is not broadcast for a status event, e.g. it is resynced after its event is dropped.
+func statusSequence(res *api.Resource) int64 {
+	value, ok := res.Status[constants.ExtensionStatusSequence].(string)
+	if !ok {
+		return 0
+	}
+	sequence, _ := strconv.ParseInt(value, 10, 64)
+	return sequence
+}
+
+// statusReplayer replays the status events of a source that a resumed subscriber missed while it was disconnected.
+type statusReplayer struct {
+	source string
+	cursor int64
+	// window is how long before the status event of the cursor the status events are replayed as well. The sequence
+	// numbers are assigned in the order of the inserts of the status events, not of their commits, so an event with
+	// a lower number than the cursor may be committed after the subscriber received the cursor. Such an event is
+	// replayed as long as it is created within the window before the event of the cursor, i.e. its transaction is
+	// shorter than the window.
+	window time.Duration
+	// since is the creation time of the status event of the cursor minus the window
+	since              time.Time
+	statusEventService services.StatusEventService
+	resourceService    services.ResourceService
+
+	// replayed is the sequence numbers of the replayed status events, including the ones deduplicated. A status
+	// event may be committed after a later one is read, so the live events are skipped by their sequence numbers
+	// instead of the last replayed one.
+	replayed map[int64]bool
+}
+
+func newStatusReplayer(source string, cursor int64, window time.Duration,
+	statusEventService services.StatusEventService, resourceService services.ResourceService) *statusReplayer {
+	return &statusReplayer{
+		source:             source,
+		cursor:             cursor,
+		window:             window,
+		statusEventService: statusEventService,
+		resourceService:    resourceService,
+		replayed:           map[int64]bool{},
+	}
+}
+
+// Validate checks the status event of the cursor is still kept, otherwise the subscriber may miss the events that
+// are purged already and it has to relist the resources.
+func (r *statusReplayer) Validate(ctx context.Context) error {
+	statusEvent, svcErr := r.statusEventService.GetBySequence(ctx, r.cursor)
+	if svcErr != nil {
+		if svcErr.Is404() {
+			return status.Error(codes.OutOfRange, fmt.Sprintf(
+				"the resume cursor %d is expired, relist the resources and subscribe without the cursor", r.cursor))
+		}
+		return serviceErrorToStatus(svcErr)
+	}
+	if statusEvent.ResourceSource != r.source {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("the resume cursor %d is not of source %s", r.cursor, r.source))
+	}
+	r.since = statusEvent.CreatedAt.Add(-r.window)
+	return nil
+}
+
+// Replay returns the resources to resend for the status events after the cursor and the ones created within the
+// window before the event of the cursor in the order of their sequence numbers. Only the last event of each resource
+// is replayed, as the subscriber only needs the latest status, so a resource whose status the subscriber received
+// before the cursor is resent with its current status at most once.
+func (r *statusReplayer) Replay(ctx context.Context) ([]*api.Resource, error) {
+	start := r.cursor
+	first, svcErr := r.statusEventService.FirstSequenceCreatedSince(ctx, r.source, r.since)
+	if svcErr != nil && !svcErr.Is404() {
+		return nil, fmt.Errorf("failed to find the status events created since %s: %s", r.since, svcErr.Error())
+	}
+	if svcErr == nil && first <= r.cursor {
+		start = first - 1
+	}
+
+	latest := map[string]*api.StatusEvent{}
+	for sequence := start; ; {
+		statusEvents, svcErr := r.statusEventService.FindBySourceAfterSequence(ctx, r.source, sequence, replayPageSize)
+		if svcErr != nil {
+			return nil, fmt.Errorf("failed to find the status events after %d: %s", sequence, svcErr.Error())
+		}
+
+		for _, statusEvent := range statusEvents {
+			r.replayed[statusEvent.SequenceNumber] = true
+			latest[statusEvent.ResourceID] = statusEvent
+			sequence = statusEvent.SequenceNumber
+		}
+
+		if len(statusEvents) < replayPageSize {
+			break
+		}
+	}
+
+	statusEvents := make([]*api.StatusEvent, 0, len(latest))
+	for _, statusEvent := range latest {
+		statusEvents = append(statusEvents, statusEvent)
+	}
+	sort.Slice(statusEvents, func(i, j int) bool {
+		return statusEvents[i].SequenceNumber < statusEvents[j].SequenceNumber
+	})
+
+	resources := make([]*api.Resource, 0, len(statusEvents))
+	for _, statusEvent := range statusEvents {
+		resource, svcErr := statusEventResource(ctx, r.resourceService, statusEvent)
+		if svcErr != nil {
+			if svcErr.Is404() {
+				// the resource is deleted after the event, its delete event is replayed instead
+				klog.FromContext(ctx).V(4).Info("skipping resource as it is not found", "resourceID", statusEvent.ResourceID)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get resource %s: %s", statusEvent.ResourceID, svcErr.Error())
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// Replayed returns true if the live event of a resource is replayed already.
+func (r *statusReplayer) Replayed(res *api.Resource) bool {
+	sequence := statusSequence(res)
+	return sequence != 0 && r.replayed[sequence]
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/constants"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// sequenceStatusEventService keeps the status events in the order of their sequence numbers.
+type sequenceStatusEventService struct {
+	services.StatusEventService
+	statusEvents api.StatusEventList
+}
+
+func (s *sequenceStatusEventService) GetBySequence(_ context.Context, sequence int64) (*api.StatusEvent, *errors.ServiceError) {
+	for _, statusEvent := range s.statusEvents {
+		if statusEvent.SequenceNumber == sequence {
+			return statusEvent, nil
+		}
+	}
+	return nil, errors.NotFound("status event %d not found", sequence)
+}
+
+func (s *sequenceStatusEventService) FindBySourceAfterSequence(_ context.Context, source string, sequence int64, limit int) (api.StatusEventList, *errors.ServiceError) {
+	statusEvents := api.StatusEventList{}
+	for _, statusEvent := range s.statusEvents {
+		if statusEvent.ResourceSource == source && statusEvent.SequenceNumber > sequence && len(statusEvents) < limit {
+			statusEvents = append(statusEvents, statusEvent)
+		}
+	}
+	return statusEvents, nil
+}
+
+func (s *sequenceStatusEventService) FirstSequenceCreatedSince(_ context.Context, source string, since time.Time) (int64, *errors.ServiceError) {
+	for _, statusEvent := range s.statusEvents {
+		if statusEvent.ResourceSource == source && !statusEvent.CreatedAt.Before(since) {
+			return statusEvent.SequenceNumber, nil
+		}
+	}
+	return 0, errors.NotFound("no status event created since %s", since)
+}
+
+func TestResumeCursor(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, resumed, err := resumeCursor(context.Background())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resumed).To(BeFalse())
+
+	cursor, resumed, err := resumeCursor(metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(constants.ResumeCursorMetadataKey, "42")))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resumed).To(BeTrue())
+	Expect(cursor).To(Equal(int64(42)))
+
+	_, _, err = resumeCursor(metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(constants.ResumeCursorMetadataKey, "invalid")))
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+}
+
+func TestStatusReplayer(t *testing.T) {
+	RegisterTestingT(t)
+
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	statusEventService := &sequenceStatusEventService{
+		statusEvents: api.StatusEventList{
+			{Meta: api.Meta{CreatedAt: base.Add(-10 * time.Minute)},
+				ResourceID: "r1", ResourceSource: "maestro", StatusEventType: api.StatusUpdateEventType, SequenceNumber: 1},
+			{Meta: api.Meta{CreatedAt: base.Add(-10 * time.Minute)},
+				ResourceID: "r1", ResourceSource: "maestro", StatusEventType: api.StatusUpdateEventType, SequenceNumber: 2},
+			{Meta: api.Meta{CreatedAt: base},
+				ResourceID: "r2", ResourceSource: "maestro", StatusEventType: api.StatusUpdateEventType, SequenceNumber: 3},
+			{Meta: api.Meta{CreatedAt: base},
+				ResourceID: "r3", ResourceSource: "other", StatusEventType: api.StatusUpdateEventType, SequenceNumber: 4},
+			{Meta: api.Meta{CreatedAt: base.Add(30 * time.Second)},
+				ResourceID: "r1", ResourceSource: "maestro", StatusEventType: api.StatusUpdateEventType, SequenceNumber: 5},
+			{Meta: api.Meta{CreatedAt: base.Add(time.Minute)},
+				ResourceID: "r4", ResourceSource: "maestro", StatusEventType: api.StatusDeleteEventType, SequenceNumber: 6,
+				Status: datatypes.JSONMap{"specversion": "1.0"}},
+			{Meta: api.Meta{CreatedAt: base.Add(time.Minute)},
+				ResourceID: "r5", ResourceSource: "maestro", StatusEventType: api.StatusUpdateEventType, SequenceNumber: 7},
+		},
+	}
+	// r5 is deleted after its update event
+	resourceService := &listResourceService{resources: []api.Resource{
+		{Meta: api.Meta{ID: "r1"}, Source: "maestro", Status: datatypes.JSONMap{"specversion": "1.0"}},
+		{Meta: api.Meta{ID: "r2"}, Source: "maestro", Status: datatypes.JSONMap{"specversion": "1.0"}},
+	}}
+	ctx := context.Background()
+
+	replayer := newStatusReplayer("maestro", 1, time.Minute, statusEventService, resourceService)
+	Expect(replayer.Validate(ctx)).To(Succeed())
+
+	// only the last event of each resource is replayed in the order of the sequence numbers
+	resources, err := replayer.Replay(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resources).To(HaveLen(3))
+	Expect(resources[0].ID).To(Equal("r2"))
+	Expect(statusSequence(resources[0])).To(Equal(int64(3)))
+	Expect(resources[1].ID).To(Equal("r1"))
+	Expect(statusSequence(resources[1])).To(Equal(int64(5)))
+	Expect(resources[2].ID).To(Equal("r4"))
+	Expect(statusSequence(resources[2])).To(Equal(int64(6)))
+
+	// the live events are skipped if they are replayed already, including the deduplicated ones
+	Expect(replayer.Replayed(&api.Resource{Status: datatypes.JSONMap{constants.ExtensionStatusSequence: "2"}})).To(BeTrue())
+	Expect(replayer.Replayed(&api.Resource{Status: datatypes.JSONMap{constants.ExtensionStatusSequence: "4"}})).To(BeFalse())
+	Expect(replayer.Replayed(&api.Resource{Status: datatypes.JSONMap{constants.ExtensionStatusSequence: "8"}})).To(BeFalse())
+	Expect(replayer.Replayed(&api.Resource{})).To(BeFalse())
+
+	// the sequence numbers follow the inserts instead of the commits, so the event 3 may be committed after the
+	// subscriber received the event 5, the events created within the window before the cursor are replayed as well
+	replayer = newStatusReplayer("maestro", 5, time.Minute, statusEventService, resourceService)
+	Expect(replayer.Validate(ctx)).To(Succeed())
+	resources, err = replayer.Replay(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resources).To(HaveLen(3))
+	Expect(resources[0].ID).To(Equal("r2"))
+	Expect(statusSequence(resources[0])).To(Equal(int64(3)))
+	Expect(replayer.Replayed(&api.Resource{Status: datatypes.JSONMap{constants.ExtensionStatusSequence: "3"}})).To(BeTrue())
+	Expect(replayer.Replayed(&api.Resource{Status: datatypes.JSONMap{constants.ExtensionStatusSequence: "2"}})).To(BeFalse())
+
+	// the events created before the window are not replayed
+	replayer = newStatusReplayer("maestro", 5, 10*time.Second, statusEventService, resourceService)
+	Expect(replayer.Validate(ctx)).To(Succeed())
+	resources, err = replayer.Replay(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resources).To(HaveLen(2))
+	Expect(resources[0].ID).To(Equal("r1"))
+	Expect(statusSequence(resources[0])).To(Equal(int64(5)))
+
+	// the cursor is expired once its event is purged
+	err = newStatusReplayer("maestro", 100, time.Minute, statusEventService, resourceService).Validate(ctx)
+	Expect(status.Code(err)).To(Equal(codes.OutOfRange))
+
+	err = newStatusReplayer("maestro", 4, time.Minute, statusEventService, resourceService).Validate(ctx)
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+}
@@ -3,21 +3,26 @@ package server
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"net"
-	"os"
 	"time"
 
 	ce "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	cetypes "github.com/cloudevents/sdk-go/v2/types"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/datatypes"
 	"k8s.io/klog/v2"
 	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/constants"
@@ -28,11 +33,17 @@ import (
 	sdkgologging "open-cluster-management.io/sdk-go/pkg/logging"
 
 	"github.com/openshift-online/maestro/pkg/api"
+	rbv1 "github.com/openshift-online/maestro/pkg/api/grpc/resourcebundle/v1"
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/audit"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
 	"github.com/openshift-online/maestro/pkg/config"
+	maestroconstants "github.com/openshift-online/maestro/pkg/constants"
+	"github.com/openshift-online/maestro/pkg/errors"
 	"github.com/openshift-online/maestro/pkg/event"
 	"github.com/openshift-online/maestro/pkg/services"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 // GRPCServer includes a gRPC server and a resource service
@@ -41,11 +52,19 @@ type GRPCServer struct {
 	grpcServer             *grpc.Server
 	eventBroadcaster       *event.EventBroadcaster
 	resourceService        services.ResourceService
+	statusEventService     services.StatusEventService
+	idempotencyService     services.IdempotencyService
 	disableAuthorizer      bool
 	grpcAuthorizer         grpcauthorizer.GRPCAuthorizer
 	bindAddress            string
 	heartbeatCheckInterval time.Duration
 	heartbeatDisable       bool
+	subscriberConfig       subscriberConfig
+	enableReflection       bool
+	// statusEventRetention is how long the status events are kept for the resumed subscribers, the subscribers
+	// cannot resume if it is zero
+	statusEventRetention time.Duration
+	statusResumeWindow   time.Duration
 }
 
 // NewGRPCServer creates a new GRPCServer
@@ -73,6 +92,15 @@ func NewGRPCServer(
 		Timeout:          config.ServerPingTimeout,
 	}))
 
+	subscriberConfig := subscriberConfig{
+		bufferSize: config.SubscriberBufferSize,
+		policy:     config.SubscriberPolicy,
+		timeout:    config.SubscriberTimeout,
+	}
+	if err := subscriberConfig.validate(); err != nil {
+		check(ctx, err, "Can't start gRPC server")
+	}
+
 	disableTLS := (config.TLSCertFile == "" && config.TLSKeyFile == "")
 
 	if !disableTLS {
@@ -84,16 +112,9 @@ func NewGRPCServer(
 			)
 		}
 
-		// Serve with TLS
-		serverCerts, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
-		if err != nil {
-			check(ctx, fmt.Errorf("failed to load server certificates: %v", err), "Can't start gRPC server")
-		}
-
 		tlsConfig := &tls.Config{
-			Certificates: []tls.Certificate{serverCerts},
-			MinVersion:   tls.VersionTLS13,
-			MaxVersion:   tls.VersionTLS13,
+			MinVersion: tls.VersionTLS13,
+			MaxVersion: tls.VersionTLS13,
 		}
 
 		// add metrics and auth interceptors
@@ -106,27 +127,25 @@ func NewGRPCServer(
 				check(ctx, fmt.Errorf("no client CA file specified when using mtls authorization type"), "Can't start gRPC server")
 			}
 
-			certPool, err := x509.SystemCertPool()
-			if err != nil {
-				check(ctx, fmt.Errorf("failed to load system cert pool: %v", err), "Can't start gRPC server")
-			}
-
-			caPEM, err := os.ReadFile(config.ClientCAFile)
+			// Serve with mTLS, the certificates and the client CA are reloaded when they are rotated
+			reloader, err := newCertReloader("grpc_server", config.TLSCertFile, config.TLSKeyFile, config.ClientCAFile)
 			if err != nil {
-				check(ctx, fmt.Errorf("failed to read client CA file: %v", err), "Can't start gRPC server")
+				check(ctx, fmt.Errorf("failed to load server certificates: %v", err), "Can't start gRPC server")
 			}
+			go reloader.Run(ctx)
 
-			if ok := certPool.AppendCertsFromPEM(caPEM); !ok {
-				check(ctx, fmt.Errorf("failed to append client CA to cert pool"), "Can't start gRPC server")
-			}
-
-			tlsConfig.ClientCAs = certPool
 			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
-
-			grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+			grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(reloader.TLSConfig(tlsConfig))))
 			logger.Info("Serving gRPC service with mTLS", "port", config.ServerBindPort)
 		} else {
-			grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+			// Serve with TLS, the certificates are reloaded when they are rotated
+			reloader, err := newCertReloader("grpc_server", config.TLSCertFile, config.TLSKeyFile, "")
+			if err != nil {
+				check(ctx, fmt.Errorf("failed to load server certificates: %v", err), "Can't start gRPC server")
+			}
+			go reloader.Run(ctx)
+
+			grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(reloader.TLSConfig(tlsConfig))))
 			logger.Info("Serving gRPC service with TLS", "port", config.ServerBindPort)
 		}
 	} else {
@@ -142,11 +161,17 @@ func NewGRPCServer(
 		grpcServer:             grpc.NewServer(grpcServerOptions...),
 		eventBroadcaster:       eventBroadcaster,
 		resourceService:        resourceService,
+		statusEventService:     env().Services.StatusEvents(),
+		idempotencyService:     env().Services.Idempotency(),
 		disableAuthorizer:      disableTLS,
 		grpcAuthorizer:         grpcAuthorizer,
 		bindAddress:            env().Config.HTTPServer.Hostname + ":" + config.ServerBindPort,
 		heartbeatCheckInterval: config.HeartbeatCheckInterval,
 		heartbeatDisable:       config.HeartbeatDisable,
+		subscriberConfig:       subscriberConfig,
+		enableReflection:       config.EnableReflection,
+		statusEventRetention:   time.Duration(env().Config.EventServer.StatusEventRetention) * time.Second,
+		statusResumeWindow:     time.Duration(env().Config.EventServer.StatusResumeWindow) * time.Second,
 	}
 }
 
@@ -160,6 +185,21 @@ func (svr *GRPCServer) Start(ctx context.Context) error {
 		return err
 	}
 	pbv1.RegisterCloudEventServiceServer(svr.grpcServer, svr)
+	rbv1.RegisterResourceBundleServiceServer(svr.grpcServer,
+		newResourceBundleServer(svr.resourceService, svr.eventBroadcaster, svr.grpcAuthorizer, svr.disableAuthorizer, svr.subscriberConfig))
+
+	// the source services are reported together with the overall serving status
+	healthServer := newGRPCHealthServer([]string{
+		SourceHealthService,
+		pbv1.CloudEventService_ServiceDesc.ServiceName,
+		rbv1.ResourceBundleService_ServiceDesc.ServiceName,
+	}, newGRPCReadinessChecks()...)
+	healthpb.RegisterHealthServer(svr.grpcServer, healthServer)
+	go healthServer.Run(ctx)
+
+	if svr.enableReflection {
+		reflection.Register(svr.grpcServer)
+	}
 	return svr.grpcServer.Serve(lis)
 }
 
@@ -180,6 +220,24 @@ func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 	logger = sdkgologging.SetLogTracingByCloudEvent(logger, evt)
 	ctx = klog.NewContext(ctx, logger)
 
+	// start the trace from the source client, the trace context is persisted with the spec event
+	// and is removed from the event to avoid storing it in the resource spec
+	ctx, span := tracing.StartSpanFromCloudEvent(ctx, "maestro.grpc.publish", evt,
+		attribute.String("cloudevents.event_type", evt.Type()),
+		attribute.String("cloudevents.event_source", evt.Source()),
+	)
+	defer span.End()
+	tracing.RemoveCloudEventTraceContext(evt)
+
+	// the idempotency key is per request as well, it is removed from the event for the same reason
+	idempotencyKey := idempotencyKeyFromCloudEvent(evt)
+	evt.SetExtension(maestroconstants.ExtensionIdempotencyKey, nil)
+	dryRun, err := dryRunFromCloudEvent(evt)
+	if err != nil {
+		return nil, err
+	}
+	evt.SetExtension(maestroconstants.ExtensionDryRun, nil)
+
 	if !svr.disableAuthorizer {
 		// check if the event is from the authorized source
 		user := ctx.Value(contextUserKey).(string)
@@ -223,16 +281,66 @@ func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 		return nil, fmt.Errorf("failed to decode cloudevent: %v", err)
 	}
 
-	switch eventType.Action {
+	// record the publisher as the actor of the resource mutations in the audit log
+	ctx = audit.WithActor(ctx, auditActorFromContext(ctx))
+
+	if dryRun {
+		// the dry-run request is not persisted, so the idempotency key is not needed
+		if err := svr.dryRunResourceRequest(ctx, eventType.Action, res); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	if idempotencyKey == "" {
+		if err := svr.handleResourceRequest(ctx, eventType.Action, res); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	// the keys are scoped by the source, so that the sources cannot replay the requests of each other
+	idempotencyKey = fmt.Sprintf("grpc:%s:%s", evt.Source(), idempotencyKey)
+	request, err := idempotencyRequest(eventType.Action, res)
+	if err != nil {
+		return nil, err
+	}
+	replayed, svcErr := svr.idempotencyService.Begin(ctx, idempotencyKey, request)
+	if svcErr != nil {
+		return nil, fmt.Errorf("failed to reserve the idempotency key: %s", svcErr)
+	}
+	if replayed != nil {
+		logger.Info("the request is already handled, skip it", "idempotencyKey", idempotencyKey)
+		return &emptypb.Empty{}, nil
+	}
+
+	if err := svr.handleResourceRequest(ctx, eventType.Action, res); err != nil {
+		// release the key, so that the retry can handle the request again
+		if svcErr := svr.idempotencyService.Abort(ctx, idempotencyKey); svcErr != nil {
+			logger.Error(svcErr, "failed to release the idempotency key", "idempotencyKey", idempotencyKey)
+		}
+		return nil, err
+	}
+
+	if svcErr := svr.idempotencyService.Complete(ctx, idempotencyKey, nil); svcErr != nil {
+		return nil, fmt.Errorf("failed to complete the idempotency key: %s", svcErr)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// handleResourceRequest creates, updates or deletes the resource for the given request action.
+func (svr *GRPCServer) handleResourceRequest(ctx context.Context, action types.EventAction, res *api.Resource) error {
+	switch action {
 	case types.CreateRequestAction:
 		_, err := svr.resourceService.Create(ctx, res)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create resource: %v", err)
+			return fmt.Errorf("failed to create resource: %v", err)
 		}
 	case types.UpdateRequestAction:
 		found, err := svr.resourceService.Get(ctx, res.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get resource: %v", err)
+			return fmt.Errorf("failed to get resource: %v", err)
 		}
 
 		if res.Version == 0 {
@@ -241,18 +349,103 @@ func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 			res.Version = found.Version
 		}
 		if _, err = svr.resourceService.Update(ctx, res); err != nil {
-			return nil, fmt.Errorf("failed to update resource: %v", err)
+			return fmt.Errorf("failed to update resource: %v", err)
 		}
 	case types.DeleteRequestAction:
 		err := svr.resourceService.MarkAsDeleting(ctx, res.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to delete resource: %v", err)
+			return fmt.Errorf("failed to delete resource: %v", err)
 		}
 	default:
-		return nil, fmt.Errorf("unsupported action %s", eventType.Action)
+		return fmt.Errorf("unsupported action %s", action)
 	}
 
-	return &emptypb.Empty{}, nil
+	return nil
+}
+
+// dryRunResourceRequest checks the request without persisting it, the would-be resource bundle
+// is returned to the client in the response header.
+func (svr *GRPCServer) dryRunResourceRequest(ctx context.Context, action types.EventAction, res *api.Resource) error {
+	var result *api.Resource
+	var svcErr *errors.ServiceError
+	switch action {
+	case types.CreateRequestAction:
+		result, svcErr = svr.resourceService.DryRunCreate(ctx, res)
+	case types.UpdateRequestAction:
+		if res.Version == 0 {
+			// use the latest resource version, as the update does
+			found, err := svr.resourceService.Get(ctx, res.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get resource: %v", err)
+			}
+			res.Version = found.Version
+		}
+		result, svcErr = svr.resourceService.DryRunUpdate(ctx, res)
+	case types.DeleteRequestAction:
+		if svcErr = svr.resourceService.DryRunMarkAsDeleting(ctx, res.ID); svcErr == nil {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported action %s", action)
+	}
+	if svcErr != nil {
+		return fmt.Errorf("dry run of %s failed: %v", action, svcErr)
+	}
+
+	rb, err := presenters.PresentResourceBundle(result)
+	if err != nil {
+		return fmt.Errorf("failed to present resource bundle: %v", err)
+	}
+	rbJSON, err := json.Marshal(rb)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resource bundle: %v", err)
+	}
+	return grpc.SetHeader(ctx, metadata.Pairs(maestroconstants.DryRunResultMetadataKey, string(rbJSON)))
+}
+
+// dryRunFromCloudEvent returns true if the given CloudEvent requests a dry-run.
+func dryRunFromCloudEvent(evt *ce.Event) (bool, error) {
+	value, ok := evt.Extensions()[maestroconstants.ExtensionDryRun]
+	if !ok {
+		return false, nil
+	}
+	dryRun, err := cetypes.ToBool(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to get dryrun extension: %v", err)
+	}
+	return dryRun, nil
+}
+
+// idempotencyKeyFromCloudEvent returns the idempotency key extension of the given CloudEvent.
+func idempotencyKeyFromCloudEvent(evt *ce.Event) string {
+	value, ok := evt.Extensions()[maestroconstants.ExtensionIdempotencyKey]
+	if !ok {
+		return ""
+	}
+	key, _ := cetypes.ToString(value)
+	return key
+}
+
+// idempotencyRequest returns the request of a publish that is compared with the retries of the same idempotency key,
+// it covers the decoded resource, so the requests for different resources or versions with the same payload differ.
+func idempotencyRequest(action types.EventAction, res *api.Resource) ([]byte, error) {
+	request, err := json.Marshal(struct {
+		Action       types.EventAction `json:"action"`
+		ID           string            `json:"id"`
+		ConsumerName string            `json:"consumer_name"`
+		Version      int32             `json:"version"`
+		Payload      datatypes.JSONMap `json:"payload"`
+	}{
+		Action:       action,
+		ID:           res.ID,
+		ConsumerName: res.ConsumerName,
+		Version:      res.Version,
+		Payload:      res.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the idempotency request: %v", err)
+	}
+	return request, nil
 }
 
 // Subscribe implements the Subscribe method of the CloudEventServiceServer interface
@@ -271,6 +464,29 @@ func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 		}
 	}
 
+	// only the status of the resources that match the subscription is sent
+	filter, err := newSubscriptionFilter(subServer.Context(), subReq.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	// a resumed subscriber replays the status events it missed, the cursor must be still kept
+	var replayer *statusReplayer
+	cursor, resumed, err := resumeCursor(subServer.Context())
+	if err != nil {
+		return err
+	}
+	if resumed {
+		if svr.statusEventRetention <= 0 {
+			return status.Error(codes.OutOfRange, fmt.Sprintf(
+				"the resume cursor %d cannot be resumed as the status events are not retained, relist the resources and subscribe without the cursor", cursor))
+		}
+		replayer = newStatusReplayer(subReq.Source, cursor, svr.statusResumeWindow, svr.statusEventService, svr.resourceService)
+		if err := replayer.Validate(subServer.Context()); err != nil {
+			return err
+		}
+	}
+
 	// Generate subscription ID and send header IMMEDIATELY, before any other operations
 	// This ensures the client receives the header as soon as possible after the stream is established
 	// This is compatibility with sdk-go v1 and v2 gRPC clients
@@ -282,13 +498,16 @@ func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 	ctx, cancel := context.WithCancel(subServer.Context())
 	defer cancel()
 
-	// TODO make the channel size configurable
-	eventCh := make(chan *pbv1.CloudEvent, 100)
+	// the events are sent one by one, the status events are buffered in the queue of the subscriber
+	eventCh := make(chan *pbv1.CloudEvent)
 	heartbeatCh := make(chan *pbv1.CloudEvent, 10)
 	sendErrCh := make(chan error, 1)
 
 	logger := klog.FromContext(ctx)
 
+	queue := newSubscriberQueue(subReq.Source, clientID, svr.subscriberConfig, svr.resourceService)
+	defer queue.Close()
+
 	// send events
 	// The grpc send is not concurrency safe and non-blocking, see: https://github.com/grpc/grpc-go/blob/v1.75.1/stream.go#L1571
 	// Return the error without wrapping, as it includes the gRPC error code and message for further handling.
@@ -325,36 +544,76 @@ func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 		}
 	}()
 
-	svr.eventBroadcaster.Register(ctx, clientID, subReq.Source, func(res *api.Resource) error {
-		evt, err := encodeResourceStatus(res)
+	// encode the status events
+	sendStatusEvent := func(res *api.Resource) bool {
+		pbEvt, err := svr.encodeStatusEvent(ctx, logger, res)
 		if err != nil {
-			return fmt.Errorf("failed to encode cloudevent: %v", err)
+			logger.Error(err, "failed to encode the status event", "resourceID", res.ID)
+			return true
 		}
 
-		broadcastLogger := sdkgologging.SetLogTracingByCloudEvent(logger, evt)
-		if broadcastLogger.V(4).Enabled() {
-			evtJson, _ := evt.MarshalJSON()
-			broadcastLogger.V(4).Info("send the event to status subscribers", "event", string(evtJson))
-		} else {
-			broadcastLogger.Info("send the event to status subscribers",
-				"eventID", evt.ID(),
-				"eventType", evt.Type(),
-				"eventSource", evt.Source(),
-				"extensions", evt.Extensions())
+		select {
+		case eventCh <- pbEvt:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		// the live events are queued during the replay, the ones that are replayed already are skipped
+		if replayer != nil {
+			replayed, err := replayer.Replay(ctx)
+			if err != nil {
+				logger.Error(err, "failed to replay the status events", "cursor", cursor)
+				select {
+				case sendErrCh <- status.Error(codes.Internal, err.Error()):
+				default:
+				}
+				cancel()
+				return
+			}
+
+			logger.Info("replay the status events to the resumed subscriber", "cursor", cursor, "count", len(replayed))
+			for _, res := range replayed {
+				if !filter.Matches(res) {
+					continue
+				}
+				if !sendStatusEvent(res) {
+					return
+				}
+			}
 		}
 
-		// WARNING: don't use "pbEvt, err := pb.ToProto(evt)" to convert cloudevent to protobuf
-		pbEvt := &pbv1.CloudEvent{}
-		if err = grpcprotocol.WritePBMessage(ctx, binding.ToMessage(evt), pbEvt); err != nil {
-			return fmt.Errorf("failed to convert cloudevent to protobuf: %v", err)
+		for {
+			res, ok := queue.Pop(ctx)
+			if !ok {
+				return
+			}
+
+			if replayer != nil && replayer.Replayed(res) {
+				continue
+			}
+
+			if !sendStatusEvent(res) {
+				return
+			}
 		}
+	}()
 
-		select {
-		case eventCh <- pbEvt:
-		case <-ctx.Done():
+	svr.eventBroadcaster.Register(ctx, clientID, subReq.Source, func(res *api.Resource) error {
+		if !filter.Matches(res) {
 			return nil
 		}
 
+		if err := queue.Push(ctx, res); err != nil {
+			// the subscriber is too slow, disconnect it so that it resyncs the status after reconnecting
+			select {
+			case sendErrCh <- status.Error(codes.ResourceExhausted, err.Error()):
+			default:
+			}
+			cancel()
+			return err
+		}
 		return nil
 	})
 
@@ -391,10 +650,43 @@ func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 		return err
 	case <-ctx.Done():
 		svr.eventBroadcaster.Unregister(ctx, clientID)
+		// the subscriber may be disconnected for being too slow
+		select {
+		case err := <-sendErrCh:
+			return err
+		default:
+		}
 		return nil
 	}
 }
 
+// encodeStatusEvent encodes the status of a resource to a protobuf CloudEvent.
+func (svr *GRPCServer) encodeStatusEvent(ctx context.Context, logger klog.Logger, res *api.Resource) (*pbv1.CloudEvent, error) {
+	evt, err := encodeResourceStatus(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cloudevent: %v", err)
+	}
+
+	broadcastLogger := sdkgologging.SetLogTracingByCloudEvent(logger, evt)
+	if broadcastLogger.V(4).Enabled() {
+		evtJson, _ := evt.MarshalJSON()
+		broadcastLogger.V(4).Info("send the event to status subscribers", "event", string(evtJson))
+	} else {
+		broadcastLogger.Info("send the event to status subscribers",
+			"eventID", evt.ID(),
+			"eventType", evt.Type(),
+			"eventSource", evt.Source(),
+			"extensions", evt.Extensions())
+	}
+
+	// WARNING: don't use "pbEvt, err := pb.ToProto(evt)" to convert cloudevent to protobuf
+	pbEvt := &pbv1.CloudEvent{}
+	if err = grpcprotocol.WritePBMessage(ctx, binding.ToMessage(evt), pbEvt); err != nil {
+		return nil, fmt.Errorf("failed to convert cloudevent to protobuf: %v", err)
+	}
+	return pbEvt, nil
+}
+
 // decodeResourceSpec translates a CloudEvent into a resource containing the spec JSON map.
 func decodeResourceSpec(evt *ce.Event) (*api.Resource, error) {
 	evtExtensions := evt.Context.GetExtensions()
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gorm.io/datatypes"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func TestIdempotencyRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	payload := datatypes.JSONMap{"data": map[string]interface{}{"manifests": []interface{}{}}}
+	resource := func(id, consumerName string, version int32) *api.Resource {
+		return &api.Resource{Meta: api.Meta{ID: id}, ConsumerName: consumerName, Version: version, Payload: payload}
+	}
+
+	request, err := idempotencyRequest(types.UpdateRequestAction, resource("r1", "cluster1", 1))
+	Expect(err).NotTo(HaveOccurred())
+
+	// a retry of the same request is the same
+	retry, err := idempotencyRequest(types.UpdateRequestAction, resource("r1", "cluster1", 1))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(retry).To(Equal(request))
+
+	// the requests with the same payload differ by the action, the resource, the consumer and the version
+	for _, other := range []struct {
+		action   types.EventAction
+		resource *api.Resource
+	}{
+		{types.CreateRequestAction, resource("r1", "cluster1", 1)},
+		{types.UpdateRequestAction, resource("r2", "cluster1", 1)},
+		{types.UpdateRequestAction, resource("r1", "cluster2", 1)},
+		{types.UpdateRequestAction, resource("r1", "cluster1", 2)},
+	} {
+		otherRequest, err := idempotencyRequest(other.action, other.resource)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(otherRequest).NotTo(Equal(request))
+	}
+}
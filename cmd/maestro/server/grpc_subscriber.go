@@ -0,0 +1,229 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+func init() {
+	// Register the metrics:
+	prometheus.MustRegister(subscriberLagGaugeMetric)
+	prometheus.MustRegister(subscriberDroppedEventsCounterMetric)
+}
+
+// Description of the subscriber lag gauge metric:
+var subscriberLagGaugeMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: "grpc_server",
+		Name:      "subscriber_lag",
+		Help:      "Number of resource status events that are not sent to a subscriber yet, labeled by source and subscriber.",
+	},
+	[]string{"source", "subscriber"},
+)
+
+// Description of the subscriber dropped events counter metric:
+var subscriberDroppedEventsCounterMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "grpc_server",
+		Name:      "subscriber_dropped_events_total",
+		Help:      "Number of resource status events dropped for slow subscribers, labeled by source.",
+	},
+	[]string{"source"},
+)
+
+const (
+	// SubscriberPolicyBlock blocks the status delivery until the subscriber has room in its buffer.
+	SubscriberPolicyBlock = "block"
+	// SubscriberPolicyDropOldest drops the oldest buffered event when the buffer is full, the latest status of
+	// the resources whose events are dropped is resent once the buffer has room.
+	SubscriberPolicyDropOldest = "drop-oldest"
+	// SubscriberPolicyDisconnect disconnects the subscriber if its buffer stays full for the timeout, the subscriber
+	// resyncs the status after it reconnects.
+	SubscriberPolicyDisconnect = "disconnect"
+)
+
+// subscriberConfig configures the buffer of the status subscribers and how the slow subscribers are handled.
+type subscriberConfig struct {
+	bufferSize int
+	policy     string
+	timeout    time.Duration
+}
+
+func (c subscriberConfig) validate() error {
+	if c.bufferSize < 1 {
+		return fmt.Errorf("the subscriber buffer size must be greater than 0")
+	}
+	switch c.policy {
+	case SubscriberPolicyBlock, SubscriberPolicyDropOldest:
+	case SubscriberPolicyDisconnect:
+		if c.timeout <= 0 {
+			return fmt.Errorf("the subscriber timeout must be greater than 0 with the %s policy", c.policy)
+		}
+	default:
+		return fmt.Errorf("unsupported subscriber policy %q, must be one of %s, %s or %s",
+			c.policy, SubscriberPolicyBlock, SubscriberPolicyDropOldest, SubscriberPolicyDisconnect)
+	}
+	return nil
+}
+
+// subscriberQueue buffers the resource status events of a subscriber between the event broadcaster and the
+// stream. The event broadcaster fans out the events serially, so a full buffer is handled with the configured
+// policy to avoid stalling the other subscribers.
+type subscriberQueue struct {
+	source          string
+	subscriber      string
+	config          subscriberConfig
+	resourceService services.ResourceService
+
+	events chan *api.Resource
+
+	mu     sync.Mutex
+	closed bool
+	// dropped is the last dropped event of each resource, the resources are resynced once the buffer has room.
+	dropped map[string]*api.Resource
+	// droppedCh signals Pop that there are dropped events to resync.
+	droppedCh chan struct{}
+}
+
+func newSubscriberQueue(source, subscriber string, config subscriberConfig, resourceService services.ResourceService) *subscriberQueue {
+	return &subscriberQueue{
+		source:          source,
+		subscriber:      subscriber,
+		config:          config,
+		resourceService: resourceService,
+		events:          make(chan *api.Resource, config.bufferSize),
+		dropped:         map[string]*api.Resource{},
+		droppedCh:       make(chan struct{}, 1),
+	}
+}
+
+// Push adds an event to the queue. With the disconnect policy it returns an error if the event cannot be buffered
+// before the timeout, the subscriber should be disconnected then.
+func (q *subscriberQueue) Push(ctx context.Context, res *api.Resource) error {
+	defer q.updateLag()
+
+	switch q.config.policy {
+	case SubscriberPolicyDropOldest:
+		for {
+			select {
+			case q.events <- res:
+				return nil
+			default:
+			}
+
+			select {
+			case oldest := <-q.events:
+				q.drop(oldest)
+			default:
+			}
+		}
+	case SubscriberPolicyDisconnect:
+		timer := time.NewTimer(q.config.timeout)
+		defer timer.Stop()
+		select {
+		case q.events <- res:
+		case <-ctx.Done():
+		case <-timer.C:
+			return fmt.Errorf("the subscriber %s of source %s is too slow, its buffer of %d events is full for %s",
+				q.subscriber, q.source, q.config.bufferSize, q.config.timeout)
+		}
+	default:
+		select {
+		case q.events <- res:
+		case <-ctx.Done():
+		}
+	}
+	return nil
+}
+
+// Pop returns the next event to send, the buffered events are returned first, then the latest status of the
+// resources whose events are dropped. It returns false once the context is done.
+func (q *subscriberQueue) Pop(ctx context.Context) (*api.Resource, bool) {
+	for {
+		select {
+		case res := <-q.events:
+			q.updateLag()
+			return res, true
+		default:
+		}
+
+		if res := q.popDropped(ctx); res != nil {
+			q.updateLag()
+			return res, true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case res := <-q.events:
+			q.updateLag()
+			return res, true
+		case <-q.droppedCh:
+		}
+	}
+}
+
+// Close removes the lag metric of the subscriber, the lag is not reported after the queue is closed.
+func (q *subscriberQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	subscriberLagGaugeMetric.DeleteLabelValues(q.source, q.subscriber)
+}
+
+func (q *subscriberQueue) drop(res *api.Resource) {
+	q.mu.Lock()
+	q.dropped[res.ID] = res
+	q.mu.Unlock()
+
+	subscriberDroppedEventsCounterMetric.WithLabelValues(q.source).Inc()
+	select {
+	case q.droppedCh <- struct{}{}:
+	default:
+	}
+}
+
+// popDropped returns the current state of a resource whose event is dropped. A newer event of the resource may be
+// sent already, so the resource is got from the service to avoid sending a stale status. If the resource is gone,
+// e.g. its deletion is reported, the dropped event is returned instead.
+func (q *subscriberQueue) popDropped(ctx context.Context) *api.Resource {
+	q.mu.Lock()
+	var res *api.Resource
+	for id, dropped := range q.dropped {
+		res = dropped
+		delete(q.dropped, id)
+		break
+	}
+	q.mu.Unlock()
+
+	if res == nil {
+		return nil
+	}
+
+	current, serviceErr := q.resourceService.Get(ctx, res.ID)
+	if serviceErr != nil {
+		if !serviceErr.Is404() {
+			klog.FromContext(ctx).Error(serviceErr, "failed to get the resource to resync, send the dropped event",
+				"resourceID", res.ID, "source", q.source)
+		}
+		return res
+	}
+	return current
+}
+
+func (q *subscriberQueue) updateLag() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	subscriberLagGaugeMetric.WithLabelValues(q.source, q.subscriber).Set(float64(len(q.events) + len(q.dropped)))
+}
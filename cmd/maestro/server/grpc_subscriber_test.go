@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func newTestResource(id string, version int32) *api.Resource {
+	return &api.Resource{Meta: api.Meta{ID: id}, Source: "maestro", ConsumerName: "cluster1", Version: version}
+}
+
+func TestSubscriberQueueDropOldest(t *testing.T) {
+	RegisterTestingT(t)
+
+	// the stored r1 is newer than its dropped event, and r2 is gone when it is resynced
+	resourceService := &listResourceService{resources: []api.Resource{*newTestResource("r1", 3)}}
+	queue := newSubscriberQueue("drop-source", "sub1",
+		subscriberConfig{bufferSize: 2, policy: SubscriberPolicyDropOldest}, resourceService)
+	ctx := context.Background()
+
+	dropped := testutil.ToFloat64(subscriberDroppedEventsCounterMetric.WithLabelValues("drop-source"))
+	Expect(queue.Push(ctx, newTestResource("r1", 1))).To(Succeed())
+	Expect(queue.Push(ctx, newTestResource("r2", 1))).To(Succeed())
+	Expect(queue.Push(ctx, newTestResource("r3", 1))).To(Succeed())
+	Expect(queue.Push(ctx, newTestResource("r4", 1))).To(Succeed())
+	Expect(testutil.ToFloat64(subscriberDroppedEventsCounterMetric.WithLabelValues("drop-source")) - dropped).To(Equal(float64(2)))
+	Expect(testutil.ToFloat64(subscriberLagGaugeMetric.WithLabelValues("drop-source", "sub1"))).To(Equal(float64(4)))
+
+	// the buffered events are sent first, then the stored r1 and the dropped event of r2
+	for _, id := range []string{"r3", "r4"} {
+		res, ok := queue.Pop(ctx)
+		Expect(ok).To(BeTrue())
+		Expect(res.ID).To(Equal(id))
+	}
+	resynced := map[string]int32{}
+	for i := 0; i < 2; i++ {
+		res, ok := queue.Pop(ctx)
+		Expect(ok).To(BeTrue())
+		resynced[res.ID] = res.Version
+	}
+	Expect(resynced).To(Equal(map[string]int32{"r1": 3, "r2": 1}))
+	Expect(testutil.ToFloat64(subscriberLagGaugeMetric.WithLabelValues("drop-source", "sub1"))).To(Equal(float64(0)))
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, ok := queue.Pop(cancelCtx)
+	Expect(ok).To(BeFalse())
+
+	queue.Close()
+	Expect(testutil.CollectAndCount(subscriberLagGaugeMetric, "grpc_server_subscriber_lag")).To(Equal(0))
+}
+
+func TestSubscriberQueueDisconnect(t *testing.T) {
+	RegisterTestingT(t)
+
+	queue := newSubscriberQueue("disconnect-source", "sub1",
+		subscriberConfig{bufferSize: 1, policy: SubscriberPolicyDisconnect, timeout: 10 * time.Millisecond}, nil)
+	defer queue.Close()
+
+	Expect(queue.Push(context.Background(), newTestResource("r1", 1))).To(Succeed())
+	Expect(queue.Push(context.Background(), newTestResource("r2", 1))).NotTo(Succeed())
+}
+
+func TestSubscriberQueueBlock(t *testing.T) {
+	RegisterTestingT(t)
+
+	queue := newSubscriberQueue("block-source", "sub1", subscriberConfig{bufferSize: 1, policy: SubscriberPolicyBlock}, nil)
+	defer queue.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	Expect(queue.Push(ctx, newTestResource("r1", 1))).To(Succeed())
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- queue.Push(ctx, newTestResource("r2", 1))
+	}()
+	Consistently(pushed, 50*time.Millisecond).ShouldNot(Receive())
+
+	// the blocked push returns once an event is sent
+	res, ok := queue.Pop(ctx)
+	Expect(ok).To(BeTrue())
+	Expect(res.ID).To(Equal("r1"))
+	Eventually(pushed).Should(Receive(BeNil()))
+	cancel()
+}
+
+func TestSubscriberConfigValidate(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(subscriberConfig{bufferSize: 100, policy: SubscriberPolicyBlock}.validate()).To(Succeed())
+	Expect(subscriberConfig{bufferSize: 100, policy: SubscriberPolicyDisconnect, timeout: time.Second}.validate()).To(Succeed())
+	Expect(subscriberConfig{bufferSize: 0, policy: SubscriberPolicyBlock}.validate()).NotTo(Succeed())
+	Expect(subscriberConfig{bufferSize: 100, policy: SubscriberPolicyDisconnect}.validate()).NotTo(Succeed())
+	Expect(subscriberConfig{bufferSize: 100, policy: "unknown"}.validate()).NotTo(Succeed())
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/labels"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/constants"
+)
+
+// subscriptionFilter selects the resources whose status is sent to a subscriber, so that the status of the other
+// resources of the source is neither buffered nor encoded.
+type subscriptionFilter struct {
+	clusterName   string
+	labelSelector labels.Selector
+}
+
+// newSubscriptionFilter returns the filter of a subscription, the status of the resources is filtered by the
+// cluster name of the request and the label selector in the request header.
+func newSubscriptionFilter(ctx context.Context, clusterName string) (*subscriptionFilter, error) {
+	filter := &subscriptionFilter{clusterName: clusterName, labelSelector: labels.Everything()}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return filter, nil
+	}
+
+	values := md.Get(constants.LabelSelectorMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return filter, nil
+	}
+
+	selector, err := labels.Parse(values[0])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid label selector %q: %v", values[0], err))
+	}
+	filter.labelSelector = selector
+	return filter, nil
+}
+
+// Matches returns true if the status of the resource should be sent to the subscriber.
+func (f *subscriptionFilter) Matches(res *api.Resource) bool {
+	if f.clusterName != "" && res.ConsumerName != f.clusterName {
+		return false
+	}
+	if f.labelSelector.Empty() {
+		return true
+	}
+	return f.labelSelector.Matches(labels.Set(workMetaLabels(res)))
+}
+
+// workMetaLabels returns the labels in the work meta of the resource spec.
+func workMetaLabels(res *api.Resource) map[string]string {
+	workMeta, ok := res.Payload[types.ExtensionWorkMeta].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	workLabels, ok := workMeta["labels"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	result := make(map[string]string, len(workLabels))
+	for key, value := range workLabels {
+		if str, ok := value.(string); ok {
+			result[key] = str
+		}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/datatypes"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/constants"
+)
+
+func newLabeledResource(consumerName string, workLabels map[string]any) *api.Resource {
+	return &api.Resource{
+		ConsumerName: consumerName,
+		Payload: datatypes.JSONMap{
+			types.ExtensionWorkMeta: map[string]any{"name": "work1", "labels": workLabels},
+		},
+	}
+}
+
+func TestSubscriptionFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	// no filter
+	filter, err := newSubscriptionFilter(context.Background(), "")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(filter.Matches(newLabeledResource("cluster1", nil))).To(BeTrue())
+	Expect(filter.Matches(&api.Resource{ConsumerName: "cluster2"})).To(BeTrue())
+
+	// filter by cluster name and labels
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(constants.LabelSelectorMetadataKey, "app=nginx,tier!=db"))
+	filter, err = newSubscriptionFilter(ctx, "cluster1")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(filter.Matches(newLabeledResource("cluster1", map[string]any{"app": "nginx"}))).To(BeTrue())
+	Expect(filter.Matches(newLabeledResource("cluster2", map[string]any{"app": "nginx"}))).To(BeFalse())
+	Expect(filter.Matches(newLabeledResource("cluster1", map[string]any{"app": "nginx", "tier": "db"}))).To(BeFalse())
+	Expect(filter.Matches(newLabeledResource("cluster1", nil))).To(BeFalse())
+	Expect(filter.Matches(&api.Resource{ConsumerName: "cluster1"})).To(BeFalse())
+
+	ctx = metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(constants.LabelSelectorMetadataKey, "app in (nginx"))
+	_, err = newSubscriptionFilter(ctx, "")
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	e "errors"
 	"fmt"
 	"net/http"
@@ -16,8 +17,36 @@ import (
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/services"
 )
 
+// HealthCheckFunc checks a dependency of the maestro server, it returns an error if the dependency is not healthy.
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthChecker is implemented by the components that report their health to the health check server,
+// e.g. the event servers report the connection state of the message broker or the gRPC broker.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+type healthCheck struct {
+	name  string
+	check HealthCheckFunc
+}
+
+// healthCheckStatus is the status of a single health check in the verbose health check response.
+type healthCheckStatus struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// healthCheckResponse is the response of the verbose health check.
+type healthCheckResponse struct {
+	Status string              `json:"status"`
+	Checks []healthCheckStatus `json:"checks"`
+}
+
 type HealthCheckServer struct {
 	httpServer        *http.Server
 	lockFactory       db.LockFactory
@@ -25,9 +54,10 @@ type HealthCheckServer struct {
 	instanceID        string
 	heartbeatInterval int
 	brokerType        string
+	checks            []healthCheck
 }
 
-func NewHealthCheckServer(ctx context.Context) *HealthCheckServer {
+func NewHealthCheckServer(ctx context.Context, eventServer EventServer) *HealthCheckServer {
 	router := mux.NewRouter()
 	srv := &http.Server{
 		Handler: router,
@@ -45,12 +75,52 @@ func NewHealthCheckServer(ctx context.Context) *HealthCheckServer {
 		brokerType:        env().Config.MessageBroker.MessageBrokerType,
 	}
 
+	// the readiness depends on the instance heartbeat and the dependencies of the maestro server
+	server.addCheck("instance", server.checkInstance)
+	server.addCheck("database", func(ctx context.Context) error {
+		return sessionFactory.CheckConnection()
+	})
+	if !env().Config.MessageBroker.Disable {
+		if checker, ok := eventServer.(HealthChecker); ok {
+			server.addCheck("message-broker", checker.CheckHealth)
+		}
+		server.addCheck("events-listener", func(ctx context.Context) error {
+			return sessionFactory.CheckListener("events")
+		})
+		if maxLag := env().Config.HealthCheck.MaxEventQueueLag; maxLag > 0 {
+			server.addCheck("event-queue-lag", newEventQueueLagCheck(env().Services.Events(), maxLag))
+		}
+	}
+	server.addCheck("status-events-listener", func(ctx context.Context) error {
+		return sessionFactory.CheckListener("status_events")
+	})
+
 	router.HandleFunc("/healthcheck", server.healthCheckHandler).Methods(http.MethodGet)
+	router.HandleFunc("/healthz/verbose", server.verboseHealthCheckHandler).Methods(http.MethodGet)
 	router.HandleFunc("/livez", server.livenessHandler).Methods(http.MethodGet)
 
 	return server
 }
 
+func (s *HealthCheckServer) addCheck(name string, check HealthCheckFunc) {
+	s.checks = append(s.checks, healthCheck{name: name, check: check})
+}
+
+// newEventQueueLagCheck returns a health check that fails if the oldest unreconciled resource event
+// has been waiting longer than the given seconds.
+func newEventQueueLagCheck(eventService services.EventService, maxLag int) HealthCheckFunc {
+	return func(ctx context.Context) error {
+		age, err := eventService.FindAgeOfOldestUnreconciledEvent(ctx)
+		if err != nil {
+			return err
+		}
+		if age != nil && *age > float64(maxLag) {
+			return fmt.Errorf("the oldest unreconciled event has been waiting for %.0f seconds, exceeds the threshold %d seconds", *age, maxLag)
+		}
+		return nil
+	}
+}
+
 func (s *HealthCheckServer) Start(ctx context.Context) {
 	logger := klog.FromContext(ctx).WithValues("instanceID", s.instanceID)
 	ctx = klog.NewContext(ctx, logger)
@@ -71,9 +141,10 @@ func (s *HealthCheckServer) Start(ctx context.Context) {
 			)
 		}
 
-		// Serve with TLS
+		// Serve with TLS, the certificates are reloaded when they are rotated
 		logger.Info("Serving HealthCheck with TLS", "port", env().Config.HealthCheck.BindPort)
-		err = s.httpServer.ListenAndServeTLS(env().Config.HTTPServer.HTTPSCertFile, env().Config.HTTPServer.HTTPSKeyFile)
+		s.httpServer.TLSConfig = newHTTPSConfig(ctx, "healthcheck")
+		err = s.httpServer.ListenAndServeTLS("", "")
 	} else {
 		logger.Info("Serving HealthCheck without TLS", "port", env().Config.HealthCheck.BindPort)
 		err = s.httpServer.ListenAndServe()
@@ -176,20 +247,45 @@ func (s *HealthCheckServer) checkInstances(ctx context.Context) {
 	}
 }
 
-// healthCheckHandler returns a 200 OK if the instance is ready, 503 Service Unavailable otherwise.
-func (s *HealthCheckServer) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	logger := klog.FromContext(r.Context()).WithValues("instanceID", s.instanceID)
-	instance, err := s.instanceDao.Get(r.Context(), s.instanceID)
+// checkInstance returns an error if the current instance is not marked as ready by the heartbeat.
+func (s *HealthCheckServer) checkInstance(ctx context.Context) error {
+	return checkInstanceReady(ctx, s.instanceDao, s.instanceID)
+}
+
+// checkInstanceReady returns an error if the given instance is not marked as ready by the heartbeat.
+func checkInstanceReady(ctx context.Context, instanceDao dao.InstanceDao, instanceID string) error {
+	instance, err := instanceDao.Get(ctx, instanceID)
 	if err != nil {
-		logger.Error(err, "Error getting instance")
-		w.WriteHeader(http.StatusInternalServerError)
-		_, err := w.Write([]byte(`{"status": "error"}`))
-		if err != nil {
-			logger.Error(err, "Error writing healthcheck response")
+		return fmt.Errorf("failed to get instance: %v", err)
+	}
+	if !instance.Ready {
+		return fmt.Errorf("the instance %s is not ready", instanceID)
+	}
+	return nil
+}
+
+// runChecks runs all the health checks and returns the status of each check, it also returns
+// true if all the checks pass.
+func (s *HealthCheckServer) runChecks(ctx context.Context) ([]healthCheckStatus, bool) {
+	ready := true
+	statuses := make([]healthCheckStatus, 0, len(s.checks))
+	for _, c := range s.checks {
+		status := healthCheckStatus{Name: c.name, Status: "ok"}
+		if err := c.check(ctx); err != nil {
+			status.Status = "failed"
+			status.Message = err.Error()
+			ready = false
 		}
-		return
+		statuses = append(statuses, status)
 	}
-	if instance.Ready {
+	return statuses, ready
+}
+
+// healthCheckHandler returns a 200 OK if the instance and its dependencies are ready, 503 Service Unavailable otherwise.
+func (s *HealthCheckServer) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
+	logger := klog.FromContext(r.Context()).WithValues("instanceID", s.instanceID)
+	statuses, ready := s.runChecks(r.Context())
+	if ready {
 		w.WriteHeader(http.StatusOK)
 		_, err := w.Write([]byte(`{"status": "ok"}`))
 		if err != nil {
@@ -198,9 +294,39 @@ func (s *HealthCheckServer) healthCheckHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	for _, status := range statuses {
+		if status.Status != "ok" {
+			logger.Info("Health check failed", "check", status.Name, "message", status.Message)
+		}
+	}
 	w.WriteHeader(http.StatusServiceUnavailable)
-	_, err = w.Write([]byte(`{"status": "not ready"}`))
+	_, err := w.Write([]byte(`{"status": "not ready"}`))
+	if err != nil {
+		logger.Error(err, "Error writing healthcheck response")
+	}
+}
+
+// verboseHealthCheckHandler returns the status of each health check in one JSON document, with a 200 OK if
+// all the checks pass, 503 Service Unavailable otherwise.
+func (s *HealthCheckServer) verboseHealthCheckHandler(w http.ResponseWriter, r *http.Request) {
+	logger := klog.FromContext(r.Context()).WithValues("instanceID", s.instanceID)
+	statuses, ready := s.runChecks(r.Context())
+	resp := healthCheckResponse{Status: "ok", Checks: statuses}
+	code := http.StatusOK
+	if !ready {
+		resp.Status = "not ready"
+		code = http.StatusServiceUnavailable
+	}
+
+	body, err := json.Marshal(resp)
 	if err != nil {
+		logger.Error(err, "Error marshaling healthcheck response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(body); err != nil {
 		logger.Error(err, "Error writing healthcheck response")
 	}
 }
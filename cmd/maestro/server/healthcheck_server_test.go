@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestVerboseHealthCheckHandler(t *testing.T) {
+	RegisterTestingT(t)
+
+	cases := []struct {
+		name         string
+		checks       []healthCheck
+		expectedCode int
+		expected     healthCheckResponse
+	}{
+		{
+			name: "all checks pass",
+			checks: []healthCheck{
+				{name: "instance", check: func(ctx context.Context) error { return nil }},
+				{name: "database", check: func(ctx context.Context) error { return nil }},
+			},
+			expectedCode: http.StatusOK,
+			expected: healthCheckResponse{
+				Status: "ok",
+				Checks: []healthCheckStatus{
+					{Name: "instance", Status: "ok"},
+					{Name: "database", Status: "ok"},
+				},
+			},
+		},
+		{
+			name: "one check fails",
+			checks: []healthCheck{
+				{name: "instance", check: func(ctx context.Context) error { return nil }},
+				{name: "events-listener", check: func(ctx context.Context) error {
+					return fmt.Errorf("the listener for channel events is not started")
+				}},
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expected: healthCheckResponse{
+				Status: "not ready",
+				Checks: []healthCheckStatus{
+					{Name: "instance", Status: "ok"},
+					{Name: "events-listener", Status: "failed", Message: "the listener for channel events is not started"},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := &HealthCheckServer{checks: c.checks}
+
+			recorder := httptest.NewRecorder()
+			s.verboseHealthCheckHandler(recorder, httptest.NewRequest(http.MethodGet, "/healthz/verbose", nil))
+			Expect(recorder.Code).To(Equal(c.expectedCode))
+
+			resp := healthCheckResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp).To(Equal(c.expected))
+
+			recorder = httptest.NewRecorder()
+			s.healthCheckHandler(recorder, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+			Expect(recorder.Code).To(Equal(c.expectedCode))
+		})
+	}
+}
@@ -24,7 +24,7 @@ func RegisterLoggerMiddleware(ctx context.Context, router *mux.Router) {
 					doLog = false
 				}
 
-				if path == "/healthcheck" {
+				if path == "/healthcheck" || path == "/healthz/verbose" {
 					logLevel = 4
 				}
 
@@ -55,9 +55,10 @@ func (s metricsServer) Start(ctx context.Context) {
 			)
 		}
 
-		// Serve with TLS
+		// Serve with TLS, the certificates are reloaded when they are rotated
 		logger.Info("Serving Metrics with TLS", "port", env().Config.Metrics.BindPort)
-		err = s.httpServer.ListenAndServeTLS(env().Config.HTTPServer.HTTPSCertFile, env().Config.HTTPServer.HTTPSKeyFile)
+		s.httpServer.TLSConfig = newHTTPSConfig(ctx, "metrics")
+		err = s.httpServer.ListenAndServeTLS("", "")
 	} else {
 		logger.Info("Serving Metrics without TLS at", "port", env().Config.Metrics.BindPort)
 		err = s.httpServer.ListenAndServe()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/openshift-online/maestro/cmd/maestro/server/logging"
 	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/audit"
 	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/handlers"
 	"github.com/openshift-online/maestro/pkg/logger"
@@ -23,8 +24,9 @@ func (s *apiServer) routes(ctx context.Context) *mux.Router {
 	}
 
 	resourceBundleHandler := handlers.NewResourceBundleHandler(services.Resources(), services.Generic())
-	consumerHandler := handlers.NewConsumerHandler(services.Consumers(), services.Resources(), services.Generic())
+	consumerHandler := handlers.NewConsumerHandler(services.Consumers(), services.Resources(), services.Generic(), services.Idempotency())
 	errorsHandler := handlers.NewErrorsHandler()
+	auditRecordHandler := handlers.NewAuditRecordHandler(services.AuditRecords())
 
 	// mainRouter is top level "/"
 	mainRouter := mux.NewRouter()
@@ -58,6 +60,8 @@ func (s *apiServer) routes(ctx context.Context) *mux.Router {
 	apiV1ResourceBundleRouter := apiV1Router.PathPrefix("/resource-bundles").Subrouter()
 	apiV1ResourceBundleRouter.HandleFunc("", resourceBundleHandler.List).Methods(http.MethodGet)
 	apiV1ResourceBundleRouter.HandleFunc("/{id}", resourceBundleHandler.Get).Methods(http.MethodGet)
+	apiV1ResourceBundleRouter.HandleFunc("", resourceBundleHandler.Create).Methods(http.MethodPost)
+	apiV1ResourceBundleRouter.HandleFunc("/{id}", resourceBundleHandler.Patch).Methods(http.MethodPatch)
 	apiV1ResourceBundleRouter.HandleFunc("/{id}", resourceBundleHandler.Delete).Methods(http.MethodDelete)
 
 	//  /api/maestro/v1/consumers
@@ -68,12 +72,19 @@ func (s *apiServer) routes(ctx context.Context) *mux.Router {
 	apiV1ConsumersRouter.HandleFunc("/{id}", consumerHandler.Patch).Methods(http.MethodPatch)
 	apiV1ConsumersRouter.HandleFunc("/{id}", consumerHandler.Delete).Methods(http.MethodDelete)
 
+	//  /api/maestro/v1/audit
+	apiV1AuditRouter := apiV1Router.PathPrefix("/audit").Subrouter()
+	apiV1AuditRouter.HandleFunc("", auditRecordHandler.List).Methods(http.MethodGet)
+
 	return mainRouter
 }
 
 func registerApiMiddleware(router *mux.Router) {
 	router.Use(MetricsMiddleware)
 
+	// record the authenticated identity of the request as the actor of the mutations in the audit log
+	router.Use(audit.ActorMiddleware(env().Config.Audit.TrustForwardedHeaders))
+
 	router.Use(
 		func(next http.Handler) http.Handler {
 			return db.TransactionMiddleware(next, env().Database.SessionFactory)
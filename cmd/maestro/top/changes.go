@@ -0,0 +1,121 @@
+package top
+
+import (
+	"time"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+)
+
+const (
+	// recentChangeLimit is the number of the most recent status changes that are kept
+	recentChangeLimit = 50
+	// noConditions is the conditions of the resource bundles without a status
+	noConditions = "<none>"
+	// deletedConditions is the conditions of the resource bundles that the agent deleted
+	deletedConditions = "Deleted"
+
+	sourceStream = "stream"
+	sourcePoll   = "poll"
+)
+
+// statusChange is a change of the conditions of a resource bundle
+type statusChange struct {
+	Time       time.Time
+	BundleID   string
+	Consumer   string
+	Conditions string
+	// Source is stream for a change from the status subscription, poll for a change found by a refresh
+	Source string
+}
+
+// changeLog keeps the most recent status changes, the first one is the most recent
+type changeLog struct {
+	// states are the last known conditions of each resource bundle
+	states  map[string]statusChange
+	seeded  bool
+	changes []statusChange
+}
+
+func newChangeLog() *changeLog {
+	return &changeLog{states: map[string]statusChange{}}
+}
+
+// observeFleet records the resource bundles whose conditions differ from the last known ones and the ones that are
+// gone, the first snapshot only sets the known conditions
+func (l *changeLog) observeFleet(f *fleet) {
+	listed := map[string]bool{}
+	for consumer, bundles := range f.Bundles {
+		for _, bundle := range bundles {
+			listed[bundle.GetId()] = true
+			conditions := output.FormatConditions(output.GetStatusConditions(bundle.Status))
+			if conditions == "" {
+				conditions = noConditions
+			}
+			l.observe(statusChange{
+				Time:       f.Time,
+				BundleID:   bundle.GetId(),
+				Consumer:   consumer,
+				Conditions: conditions,
+				Source:     sourcePoll,
+			}, l.seeded)
+		}
+	}
+
+	for id, last := range l.states {
+		if listed[id] {
+			continue
+		}
+		delete(l.states, id)
+		if l.seeded && last.Conditions != deletedConditions {
+			l.add(statusChange{Time: f.Time, BundleID: id, Consumer: last.Consumer, Conditions: deletedConditions, Source: sourcePoll})
+		}
+	}
+	l.seeded = true
+}
+
+// observeEvent records the status change of the status subscription if it differs from the last known conditions
+func (l *changeLog) observeEvent(evt *clients.ResourceBundleStatusEvent) {
+	conditions := make([]output.StatusCondition, 0, len(evt.Conditions))
+	for _, cond := range evt.Conditions {
+		conditions = append(conditions, output.StatusCondition{Type: cond.Type, Status: string(cond.Status)})
+	}
+
+	change := statusChange{
+		Time:       evt.Time,
+		BundleID:   evt.ID,
+		Consumer:   evt.ConsumerName,
+		Conditions: output.FormatConditions(conditions),
+		Source:     sourceStream,
+	}
+	if evt.Deleted {
+		change.Conditions = deletedConditions
+	}
+	if change.Conditions == "" {
+		change.Conditions = noConditions
+	}
+	l.observe(change, true)
+}
+
+func (l *changeLog) observe(change statusChange, record bool) {
+	last, known := l.states[change.BundleID]
+	if change.Consumer == "" {
+		change.Consumer = last.Consumer
+	}
+	l.states[change.BundleID] = change
+	if !record || (known && last.Conditions == change.Conditions) {
+		return
+	}
+	// a resource bundle that the poll sees for the first time is new, it is not a status change
+	if !known && change.Source == sourcePoll && change.Conditions == noConditions {
+		return
+	}
+	l.add(change)
+}
+
+func (l *changeLog) add(change statusChange) {
+	l.changes = append([]statusChange{change}, l.changes...)
+	if len(l.changes) > recentChangeLimit {
+		l.changes = l.changes[:recentChangeLimit]
+	}
+}
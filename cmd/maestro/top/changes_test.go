@@ -0,0 +1,99 @@
+package top
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func TestChangeLog(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	snapshot := func(offset time.Duration, bundles ...openapi.ResourceBundle) *fleet {
+		return summarizeFleet(base.Add(offset), nil, bundles)
+	}
+
+	log := newChangeLog()
+
+	// the first snapshot only sets the known conditions
+	log.observeFleet(snapshot(0,
+		testBundle("bundle-1", "web", "cluster1", "Applied", "True"),
+		testBundle("bundle-2", "api", "cluster1", "Applied", "True"),
+	))
+	if len(log.changes) != 0 {
+		t.Fatalf("observeFleet() changes = %+v, want none for the first snapshot", log.changes)
+	}
+
+	// the status subscription reports a change, the same conditions are not a change
+	evt := &clients.ResourceBundleStatusEvent{
+		Time:         base.Add(time.Second),
+		ID:           "bundle-1",
+		ConsumerName: "cluster1",
+		Conditions: []metav1.Condition{
+			{Type: "Applied", Status: metav1.ConditionTrue},
+			{Type: "Available", Status: metav1.ConditionTrue},
+		},
+	}
+	log.observeEvent(evt)
+	log.observeEvent(evt)
+
+	// the next refresh sees the change of the subscription, a new undelivered bundle, a change and a deleted bundle
+	log.observeFleet(snapshot(5*time.Second,
+		testBundle("bundle-1", "web", "cluster1", "Applied", "True", "Available", "True"),
+		testBundle("bundle-3", "db", "cluster1"),
+		testBundle("bundle-4", "cache", "cluster2", "Applied", "False"),
+	))
+
+	var got []string
+	for _, change := range log.changes {
+		got = append(got, change.BundleID+" "+change.Consumer+" "+change.Conditions+" "+change.Source)
+	}
+	// bundle-2 is gone and bundle-4 changed in the same refresh, so their order is not fixed
+	if len(got) == 3 && got[0] > got[1] {
+		got[0], got[1] = got[1], got[0]
+	}
+	want := []string{
+		"bundle-2 cluster1 Deleted poll",
+		"bundle-4 cluster2 Applied=False poll",
+		"bundle-1 cluster1 Applied=True,Available=True stream",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+
+	// the deletion that the subscription reports is not reported again by the refresh
+	log.observeEvent(&clients.ResourceBundleStatusEvent{Time: base.Add(6 * time.Second), ID: "bundle-3", Deleted: true})
+	log.observeFleet(snapshot(10*time.Second,
+		testBundle("bundle-1", "web", "cluster1", "Applied", "True", "Available", "True"),
+		testBundle("bundle-4", "cache", "cluster2", "Applied", "False"),
+	))
+	if len(log.changes) != 4 || log.changes[0].Conditions != deletedConditions || log.changes[0].Consumer != "cluster1" {
+		t.Errorf("changes = %+v, want the deletion of bundle-3 of cluster1 once", log.changes)
+	}
+}
+
+func TestChangeLog_Limit(t *testing.T) {
+	log := newChangeLog()
+	for i := 0; i < recentChangeLimit+10; i++ {
+		status := metav1.ConditionTrue
+		if i%2 == 1 {
+			status = metav1.ConditionFalse
+		}
+		log.observeEvent(&clients.ResourceBundleStatusEvent{
+			Time:       time.Unix(int64(i), 0),
+			ID:         "bundle-1",
+			Conditions: []metav1.Condition{{Type: "Applied", Status: status}},
+		})
+	}
+
+	if len(log.changes) != recentChangeLimit {
+		t.Fatalf("changes = %d, want %d", len(log.changes), recentChangeLimit)
+	}
+	if !log.changes[0].Time.Equal(time.Unix(recentChangeLimit+9, 0)) {
+		t.Errorf("first change time = %v, want the most recent change", log.changes[0].Time)
+	}
+}
@@ -0,0 +1,156 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+// listPageSize is the page size of the consumer and resource bundle lists of a refresh
+const listPageSize = 100
+
+// fleet is a snapshot of the consumers and their resource bundles
+type fleet struct {
+	Time      time.Time
+	Consumers []consumerSummary
+	// Bundles are the resource bundles of each consumer by the consumer name, ordered by the name
+	Bundles map[string][]openapi.ResourceBundle
+	// Queue is nil if the metrics of the server are not scraped
+	Queue *queueStatus
+	// QueueErr is the error of the metrics scrape
+	QueueErr error
+}
+
+// consumerSummary is the number of the resource bundles of a consumer by their conditions
+type consumerSummary struct {
+	ID   string
+	Name string
+	// Total is the number of the resource bundles of the consumer
+	Total     int
+	Applied   int
+	Available int
+	// Failed is the number of the resource bundles with a failed condition, see output.IsFailedCondition
+	Failed int
+	// Pending is the number of the resource bundles that are not delivered yet, they have no status
+	Pending  int
+	Deleting int
+}
+
+// totalBundles returns the number of the resource bundles of the fleet
+func (f *fleet) totalBundles() int {
+	total := 0
+	for _, bundles := range f.Bundles {
+		total += len(bundles)
+	}
+	return total
+}
+
+// findBundle returns the resource bundle with the ID, nil if it is not found
+func (f *fleet) findBundle(id string) *openapi.ResourceBundle {
+	for _, bundles := range f.Bundles {
+		for i := range bundles {
+			if bundles[i].GetId() == id {
+				return &bundles[i]
+			}
+		}
+	}
+	return nil
+}
+
+// fetchFleet lists all the consumers and resource bundles and summarizes the resource bundles of each consumer
+func fetchFleet(ctx context.Context, restClient *clients.RESTClient) (*fleet, error) {
+	var consumers []openapi.Consumer
+	for page := 1; ; page++ {
+		result, err := restClient.ListConsumers(ctx, page, listPageSize, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list consumers: %w", err)
+		}
+		consumers = append(consumers, result.GetItems()...)
+		if len(result.GetItems()) == 0 || len(consumers) >= int(result.GetTotal()) {
+			break
+		}
+	}
+
+	var bundles []openapi.ResourceBundle
+	for page := 1; ; page++ {
+		result, err := restClient.ListResourceBundles(ctx, page, listPageSize, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource bundles: %w", err)
+		}
+		bundles = append(bundles, result.GetItems()...)
+		if len(result.GetItems()) == 0 || len(bundles) >= int(result.GetTotal()) {
+			break
+		}
+	}
+
+	return summarizeFleet(time.Now(), consumers, bundles), nil
+}
+
+// summarizeFleet groups the resource bundles by their consumer and counts them by their conditions, the resource
+// bundles of a consumer that is not listed get a summary without an ID
+func summarizeFleet(now time.Time, consumers []openapi.Consumer, bundles []openapi.ResourceBundle) *fleet {
+	f := &fleet{Time: now, Bundles: map[string][]openapi.ResourceBundle{}}
+
+	summaries := map[string]*consumerSummary{}
+	for _, consumer := range consumers {
+		summaries[consumer.GetName()] = &consumerSummary{ID: consumer.GetId(), Name: consumer.GetName()}
+	}
+
+	for _, bundle := range bundles {
+		name := bundle.GetConsumerName()
+		summary, ok := summaries[name]
+		if !ok {
+			summary = &consumerSummary{Name: name}
+			summaries[name] = summary
+		}
+		summary.add(bundle)
+		f.Bundles[name] = append(f.Bundles[name], bundle)
+	}
+
+	for _, summary := range summaries {
+		f.Consumers = append(f.Consumers, *summary)
+	}
+	sort.Slice(f.Consumers, func(i, j int) bool { return f.Consumers[i].Name < f.Consumers[j].Name })
+	for _, consumerBundles := range f.Bundles {
+		sort.Slice(consumerBundles, func(i, j int) bool {
+			return consumerBundles[i].GetName() < consumerBundles[j].GetName()
+		})
+	}
+	return f
+}
+
+// add counts the resource bundle by its conditions
+func (s *consumerSummary) add(bundle openapi.ResourceBundle) {
+	s.Total++
+	if bundle.DeletedAt != nil {
+		s.Deleting++
+	}
+	if len(bundle.Status) == 0 {
+		s.Pending++
+		return
+	}
+
+	conditions := output.GetStatusConditions(bundle.Status)
+	if isConditionTrue(conditions, "Applied") {
+		s.Applied++
+	}
+	if isConditionTrue(conditions, "Available") {
+		s.Available++
+	}
+	for _, cond := range conditions {
+		if output.IsFailedCondition(cond) {
+			s.Failed++
+			break
+		}
+	}
+}
+
+func isConditionTrue(conditions []output.StatusCondition, condType string) bool {
+	cond := output.FindStatusCondition(conditions, condType)
+	return cond != nil && cond.Status == "True"
+}
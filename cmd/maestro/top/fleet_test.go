@@ -0,0 +1,84 @@
+package top
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func testBundle(id, name, consumer string, conditions ...string) openapi.ResourceBundle {
+	bundle := openapi.ResourceBundle{
+		Id:           openapi.PtrString(id),
+		Name:         openapi.PtrString(name),
+		ConsumerName: openapi.PtrString(consumer),
+		Version:      openapi.PtrInt32(1),
+	}
+	if len(conditions) == 0 {
+		return bundle
+	}
+
+	var conds []interface{}
+	for i := 0; i+1 < len(conditions); i += 2 {
+		conds = append(conds, map[string]interface{}{"type": conditions[i], "status": conditions[i+1]})
+	}
+	bundle.Status = map[string]interface{}{"conditions": conds}
+	return bundle
+}
+
+func TestSummarizeFleet(t *testing.T) {
+	now := time.Now()
+	deleting := testBundle("bundle-4", "deleting", "cluster1", "Applied", "True")
+	deleting.DeletedAt = &now
+
+	consumers := []openapi.Consumer{
+		{Id: openapi.PtrString("consumer-2"), Name: openapi.PtrString("cluster2")},
+		{Id: openapi.PtrString("consumer-1"), Name: openapi.PtrString("cluster1")},
+	}
+	bundles := []openapi.ResourceBundle{
+		testBundle("bundle-1", "web", "cluster1", "Applied", "True", "Available", "True"),
+		testBundle("bundle-2", "api", "cluster1", "Applied", "True", "Available", "False"),
+		testBundle("bundle-3", "db", "cluster1"),
+		deleting,
+		testBundle("bundle-5", "orphan", "cluster3", "Applied", "False"),
+	}
+
+	f := summarizeFleet(now, consumers, bundles)
+
+	want := []consumerSummary{
+		{ID: "consumer-1", Name: "cluster1", Total: 4, Applied: 3, Available: 1, Failed: 1, Pending: 1, Deleting: 1},
+		{ID: "consumer-2", Name: "cluster2"},
+		{Name: "cluster3", Total: 1, Failed: 1},
+	}
+	if !reflect.DeepEqual(f.Consumers, want) {
+		t.Errorf("summarizeFleet() consumers = %+v, want %+v", f.Consumers, want)
+	}
+	if f.totalBundles() != 5 {
+		t.Errorf("totalBundles() = %d, want 5", f.totalBundles())
+	}
+
+	var names []string
+	for _, bundle := range f.Bundles["cluster1"] {
+		names = append(names, bundle.GetName())
+	}
+	if !reflect.DeepEqual(names, []string{"api", "db", "deleting", "web"}) {
+		t.Errorf("summarizeFleet() bundles of cluster1 = %v, want them ordered by the name", names)
+	}
+	if bundle := f.findBundle("bundle-5"); bundle == nil || bundle.GetName() != "orphan" {
+		t.Errorf("findBundle() = %v, want the orphan bundle", bundle)
+	}
+}
+
+func TestConsumerSummary_HealthyConditions(t *testing.T) {
+	s := consumerSummary{Name: "cluster1"}
+	s.add(testBundle("bundle-1", "web", "cluster1",
+		"Applied", "True", "Available", "True", "Progressing", "False", "Degraded", "False"))
+	s.add(testBundle("bundle-2", "api", "cluster1",
+		"Applied", "True", "Available", "True", "Progressing", "True", "Degraded", "True"))
+
+	want := consumerSummary{Name: "cluster1", Total: 2, Applied: 2, Available: 2, Failed: 1}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("add() = %+v, want %+v", s, want)
+	}
+}
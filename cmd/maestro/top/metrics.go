@@ -0,0 +1,102 @@
+package top
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+)
+
+const (
+	// queueDepthMetric is the depth of the work queues of the server, by the queue name
+	queueDepthMetric = "workqueue_depth"
+	// specEventQueueName is the name of the work queue of the spec event controller
+	specEventQueueName = "event-controller"
+	// oldestEventAgeMetric is the age of the oldest unreconciled spec event, the server reports it periodically
+	oldestEventAgeMetric = "spec_controller_event_oldest_unreconciled_age_seconds"
+)
+
+// queueStatus is the state of the spec event queue of the server
+type queueStatus struct {
+	Depth float64
+	// OldestEventAge is the age of the oldest unreconciled spec event in seconds, NaN if the server failed to query it
+	OldestEventAge float64
+}
+
+// metricsScraper reads the spec event queue from the metrics endpoint of the server
+type metricsScraper struct {
+	url    string
+	client *http.Client
+}
+
+func newMetricsScraper(url string, cfg *clients.RESTConfig) *metricsScraper {
+	return &metricsScraper{
+		url: url,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: cfg.InsecureSkipVerify,
+				},
+			},
+		},
+	}
+}
+
+func (s *metricsScraper) scrape(ctx context.Context) (*queueStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the metrics request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the metrics: unexpected status code %d", resp.StatusCode)
+	}
+	return parseQueueStatus(resp.Body)
+}
+
+// parseQueueStatus reads the spec event queue from metrics in the Prometheus text format
+func parseQueueStatus(r io.Reader) (*queueStatus, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics: %w", err)
+	}
+
+	status := &queueStatus{}
+
+	depthFound := false
+	if family, ok := families[queueDepthMetric]; ok {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "queue_name" && label.GetValue() == specEventQueueName {
+					status.Depth = metric.GetGauge().GetValue()
+					depthFound = true
+				}
+			}
+		}
+	}
+	if !depthFound {
+		return nil, fmt.Errorf("the metrics have no %s of the %s queue", queueDepthMetric, specEventQueueName)
+	}
+
+	family, ok := families[oldestEventAgeMetric]
+	if !ok || len(family.GetMetric()) == 0 {
+		return nil, fmt.Errorf("the metrics have no %s", oldestEventAgeMetric)
+	}
+	status.OldestEventAge = family.GetMetric()[0].GetGauge().GetValue()
+
+	return status, nil
+}
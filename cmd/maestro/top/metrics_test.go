@@ -0,0 +1,97 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+)
+
+const testMetrics = `# HELP workqueue_depth Current depth of workqueue
+# TYPE workqueue_depth gauge
+workqueue_depth{queue_name="event-controller"} 7
+workqueue_depth{queue_name="status-event-controller"} 2
+# HELP spec_controller_event_oldest_unreconciled_age_seconds Age in seconds of the oldest unreconciled spec event.
+# TYPE spec_controller_event_oldest_unreconciled_age_seconds gauge
+spec_controller_event_oldest_unreconciled_age_seconds 42.5
+`
+
+func TestParseQueueStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		metrics     string
+		want        *queueStatus
+		errContains string
+	}{
+		{
+			name:    "spec event queue",
+			metrics: testMetrics,
+			want:    &queueStatus{Depth: 7, OldestEventAge: 42.5},
+		},
+		{
+			name:        "no spec event queue",
+			metrics:     "workqueue_depth{queue_name=\"status-event-controller\"} 2\n",
+			errContains: "no workqueue_depth of the event-controller queue",
+		},
+		{
+			name:        "no oldest event age",
+			metrics:     "workqueue_depth{queue_name=\"event-controller\"} 2\n",
+			errContains: "no spec_controller_event_oldest_unreconciled_age_seconds",
+		},
+		{
+			name:        "invalid metrics",
+			metrics:     "workqueue_depth{queue_name=\"event-controller\" 2\n",
+			errContains: "failed to parse the metrics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseQueueStatus(strings.NewReader(tt.metrics))
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("parseQueueStatus() error = %v, should contain %v", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseQueueStatus() error = %v", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("parseQueueStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsScraper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, strings.Replace(testMetrics, "42.5", "NaN", 1))
+	}))
+	defer server.Close()
+
+	cfg := &clients.RESTConfig{Timeout: 5 * time.Second}
+
+	status, err := newMetricsScraper(server.URL+"/metrics", cfg).scrape(context.Background())
+	if err != nil {
+		t.Fatalf("scrape() error = %v", err)
+	}
+	if status.Depth != 7 || !math.IsNaN(status.OldestEventAge) {
+		t.Errorf("scrape() = %+v, want depth 7 and an unknown age", status)
+	}
+
+	if _, err := newMetricsScraper(server.URL+"/other", cfg).scrape(context.Background()); err == nil ||
+		!strings.Contains(err.Error(), "unexpected status code 404") {
+		t.Errorf("scrape() error = %v, should report the status code", err)
+	}
+}
@@ -0,0 +1,126 @@
+package top
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h"
+	leaveAltScreen = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	cursorHome     = "\x1b[H"
+	clearLine      = "\x1b[K"
+	clearScreenEnd = "\x1b[J"
+
+	// defaultWidth and defaultHeight are the screen size if the terminal does not report it
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// terminal draws the dashboard on the alternate screen of a terminal in raw mode
+type terminal struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+}
+
+// openTerminal switches the terminal to raw mode and to the alternate screen, close restores it
+func openTerminal(in, out *os.File) (*terminal, error) {
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, fmt.Errorf("maestro top requires a terminal, use --once to print a single snapshot")
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set the terminal to raw mode: %w", err)
+	}
+	fmt.Fprint(out, enterAltScreen+hideCursor)
+
+	return &terminal{in: in, out: out, state: state}, nil
+}
+
+func (t *terminal) close() error {
+	fmt.Fprint(t.out, showCursor+leaveAltScreen)
+	return term.Restore(int(t.in.Fd()), t.state)
+}
+
+// draw renders the dashboard over the previous one
+func (t *terminal) draw(d *dashboard) error {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = defaultWidth, defaultHeight
+	}
+
+	var buf bytes.Buffer
+	if err := d.render(&buf, width, height); err != nil {
+		return err
+	}
+
+	// the raw mode does not return the carriage on a new line, the last line has no new line so that the screen
+	// does not scroll
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	_, err = fmt.Fprint(t.out, cursorHome+strings.Join(lines, clearLine+"\r\n")+clearLine+clearScreenEnd)
+	return err
+}
+
+// readKeys sends the keys pressed in the terminal until the context is done, the reads of the standard input are
+// not interrupted, so the goroutine ends with the next key press
+func (t *terminal) readKeys(ctx context.Context, keys chan<- key) {
+	buf := make([]byte, 64)
+	for {
+		n, err := t.in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, k := range parseKeys(buf[:n]) {
+			select {
+			case keys <- k:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// parseKeys returns the keys of the input of a terminal in raw mode, the other input is ignored
+func parseKeys(input []byte) []key {
+	var keys []key
+	for i := 0; i < len(input); i++ {
+		switch input[i] {
+		case 0x1b:
+			// the arrow keys are ESC [ A and ESC [ B, or ESC O A and ESC O B in the application mode
+			if i+2 < len(input) && (input[i+1] == '[' || input[i+1] == 'O') {
+				switch input[i+2] {
+				case 'A':
+					keys = append(keys, keyUp)
+				case 'B':
+					keys = append(keys, keyDown)
+				}
+				i += 2
+				continue
+			}
+			keys = append(keys, keyBack)
+		case 'k':
+			keys = append(keys, keyUp)
+		case 'j':
+			keys = append(keys, keyDown)
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 0x7f, 0x08, 'h':
+			keys = append(keys, keyBack)
+		case 'r':
+			keys = append(keys, keyRefresh)
+		// Ctrl-C does not send a signal in raw mode
+		case 'q', 0x03:
+			keys = append(keys, keyQuit)
+		}
+	}
+	return keys
+}
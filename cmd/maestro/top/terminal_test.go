@@ -0,0 +1,30 @@
+package top
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []key
+	}{
+		{name: "arrow keys", input: "\x1b[A\x1b[B\x1bOA", want: []key{keyUp, keyDown, keyUp}},
+		{name: "vi keys", input: "kjh", want: []key{keyUp, keyDown, keyBack}},
+		{name: "enter", input: "\r", want: []key{keyEnter}},
+		{name: "escape", input: "\x1b", want: []key{keyBack}},
+		{name: "backspace", input: "\x7f", want: []key{keyBack}},
+		{name: "refresh and quit", input: "rq\x03", want: []key{keyRefresh, keyQuit, keyQuit}},
+		{name: "other keys", input: "x\x1b[C", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeys(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+)
+
+const (
+	flagInterval     = "interval"
+	flagMetricsURL   = "metrics-url"
+	flagStatusStream = "status-stream"
+	flagOnce         = "once"
+
+	// redrawInterval is the period of the redraws between the refreshes, so that a resized terminal is redrawn
+	redrawInterval = time.Second
+)
+
+// NewTopCommand creates the top command
+func NewTopCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show a live dashboard of the consumers and their resource bundles",
+		Long: `Show a terminal dashboard of the consumers and the number of their resource bundles by
+condition, refreshed with the REST API every --interval.
+
+The fleet view shows for each consumer the number of its resource bundles, of the ones
+with the Applied and Available conditions, of the failed ones (a condition has the False
+status), of the pending ones (they have no status yet) and of the ones pending deletion.
+It also shows the recent status changes, from the gRPC status subscription of the
+source and from the differences between the refreshes.
+
+With --metrics-url, the depth of the spec event queue and the age of the oldest
+unreconciled spec event are read from the metrics endpoint of a Maestro server.
+
+Keys:
+  up/down, k/j     select a consumer or a resource bundle, scroll the detail
+  enter            show the resource bundles of the consumer, or the resource bundle
+  esc, backspace   go back
+  r                refresh now
+  q, ctrl-c        quit
+
+With --once, the consumers are printed once without a terminal, e.g. for a script or
+a bug report.
+
+Examples:
+  maestro top
+  maestro top --interval 10s --metrics-url http://localhost:8080/metrics
+  maestro top --status-stream=false
+  maestro top --once`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runTop(cmd, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	clients.AddClientFlags(cmd, "maestro-cli")
+	cmd.Flags().Duration(flagInterval, 5*time.Second, "The refresh interval")
+	cmd.Flags().String(flagMetricsURL, "", "The metrics endpoint of a Maestro server (e.g., http://localhost:8080/metrics)")
+	cmd.Flags().Bool(flagStatusStream, true, "Show the status changes of the gRPC status subscription of the source")
+	cmd.Flags().Bool(flagOnce, false, "Print the consumers once and exit")
+
+	return cmd
+}
+
+// snapshotSource reads the fleet from the REST API and the spec event queue from the metrics of the server
+type snapshotSource struct {
+	restClient *clients.RESTClient
+	// metrics is nil if --metrics-url is not set
+	metrics *metricsScraper
+}
+
+func (s *snapshotSource) fetch(ctx context.Context) (*fleet, error) {
+	f, err := fetchFleet(ctx, s.restClient)
+	if err != nil {
+		return nil, err
+	}
+	if s.metrics != nil {
+		f.Queue, f.QueueErr = s.metrics.scrape(ctx)
+	}
+	return f, nil
+}
+
+func runTop(cmd *cobra.Command, _ []string) error {
+	interval, err := cmd.Flags().GetDuration(flagInterval)
+	if err != nil {
+		return fmt.Errorf("failed to read --%s flag: %w", flagInterval, err)
+	}
+	metricsURL, err := cmd.Flags().GetString(flagMetricsURL)
+	if err != nil {
+		return fmt.Errorf("failed to read --%s flag: %w", flagMetricsURL, err)
+	}
+	statusStream, err := cmd.Flags().GetBool(flagStatusStream)
+	if err != nil {
+		return fmt.Errorf("failed to read --%s flag: %w", flagStatusStream, err)
+	}
+	once, err := cmd.Flags().GetBool(flagOnce)
+	if err != nil {
+		return fmt.Errorf("failed to read --%s flag: %w", flagOnce, err)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--%s must be positive", flagInterval)
+	}
+
+	// Load client configuration
+	cfg, err := clients.LoadConfigFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Create REST client
+	restClient, err := clients.NewRESTClient(&cfg.RESTConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create REST client: %w", err)
+	}
+	src := &snapshotSource{restClient: restClient}
+	if metricsURL != "" {
+		src.metrics = newMetricsScraper(metricsURL, &cfg.RESTConfig)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if once {
+		return printOnce(ctx, cmd.OutOrStdout(), src, interval)
+	}
+
+	t, err := openTerminal(os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	// the logs of the clients, e.g. the gRPC connection errors, would be drawn over the dashboard
+	klog.LogToStderr(false)
+	klog.SetOutput(io.Discard)
+	defer klog.LogToStderr(true)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	d := newDashboard(interval, true, "disabled")
+	events := make(chan *clients.ResourceBundleStatusEvent, 100)
+	streamStates := make(chan string, 1)
+	if statusStream {
+		d.stream = fmt.Sprintf("connecting to %s", cfg.GRPCConfig.ServerAddress)
+		go watchStatus(ctx, cfg, interval, events, streamStates)
+	}
+
+	keys := make(chan key)
+	go t.readKeys(ctx, keys)
+
+	return runDashboard(ctx, t, d, src, keys, events, streamStates)
+}
+
+// printOnce prints the fleet view of a single refresh
+func printOnce(ctx context.Context, w io.Writer, src *snapshotSource, interval time.Duration) error {
+	f, err := src.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	d := newDashboard(interval, false, "")
+	d.update(f)
+	return d.render(w, 0, 0)
+}
+
+// refreshResult is the result of a refresh of the dashboard
+type refreshResult struct {
+	fleet *fleet
+	err   error
+}
+
+// runDashboard refreshes and draws the dashboard until the context is done or the dashboard is closed, the refreshes
+// run in the background so that the keys are handled while the REST API is slow
+func runDashboard(ctx context.Context, t *terminal, d *dashboard, src *snapshotSource, keys <-chan key,
+	events <-chan *clients.ResourceBundleStatusEvent, streamStates <-chan string) error {
+	results := make(chan refreshResult, 1)
+	refreshing := false
+	refresh := func() {
+		if refreshing {
+			return
+		}
+		refreshing = true
+		go func() {
+			f, err := src.fetch(ctx)
+			results <- refreshResult{fleet: f, err: err}
+		}()
+	}
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	redraw := time.NewTicker(redrawInterval)
+	defer redraw.Stop()
+
+	refresh()
+	for {
+		if err := t.draw(d); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			refresh()
+		case <-redraw.C:
+		case result := <-results:
+			refreshing = false
+			if result.err != nil {
+				d.refreshErr = result.err
+				continue
+			}
+			d.update(result.fleet)
+		case k := <-keys:
+			if k == keyRefresh {
+				refresh()
+				continue
+			}
+			if !d.handleKey(k) {
+				return nil
+			}
+		case evt := <-events:
+			d.changes.observeEvent(evt)
+		case state := <-streamStates:
+			d.stream = state
+		}
+	}
+}
+
+// watchStatus sends the status changes of the status subscription of the source and the state of the subscription,
+// it subscribes again an interval after a failure until the context is done
+func watchStatus(ctx context.Context, cfg *clients.Config, interval time.Duration,
+	events chan<- *clients.ResourceBundleStatusEvent, states chan<- string) {
+	for {
+		err := subscribe(ctx, cfg, events, states)
+		if ctx.Err() != nil {
+			return
+		}
+
+		state := "disconnected, subscribing again"
+		if err != nil {
+			state = fmt.Sprintf("unavailable: %v", err)
+		}
+		if !sendState(ctx, states, state) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func subscribe(ctx context.Context, cfg *clients.Config, events chan<- *clients.ResourceBundleStatusEvent,
+	states chan<- string) error {
+	// Create gRPC client
+	grpcClient, err := clients.NewGRPCClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	defer grpcClient.Close()
+
+	sendState(ctx, states, fmt.Sprintf("subscribed to %s as source %s", cfg.GRPCConfig.ServerAddress, cfg.GRPCConfig.SourceID))
+	return grpcClient.WatchStatus(ctx, clients.WatchOptions{}, func(evt *clients.ResourceBundleStatusEvent) error {
+		select {
+		case events <- evt:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// sendState sends the state of the subscription, it returns false if the context is done
+func sendState(ctx context.Context, states chan<- string, state string) bool {
+	select {
+	case states <- state:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+package top
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients/mock"
+)
+
+func setupTestEnv(_ *testing.T, server *mock.Server) func() {
+	os.Setenv(clients.EnvRESTURL, server.URL)
+	return func() {
+		os.Unsetenv(clients.EnvRESTURL)
+	}
+}
+
+func TestRunTop(t *testing.T) {
+	server := mock.NewMaestroServer()
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		flags        []string
+		wantContains []string
+		wantErr      bool
+		errContains  string
+	}{
+		{
+			name:  "once",
+			flags: []string{"--once"},
+			wantContains: []string{
+				"2 consumers, 1 resource bundles",
+				"Spec events: set --metrics-url",
+				"test-consumer-1",
+				"test-consumer (unknown)   1         1",
+			},
+		},
+		{
+			name:        "invalid interval",
+			flags:       []string{"--once", "--interval", "0s"},
+			wantErr:     true,
+			errContains: "--interval must be positive",
+		},
+		{
+			name:        "no terminal",
+			flags:       []string{},
+			wantErr:     true,
+			errContains: "requires a terminal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := setupTestEnv(t, server)
+			defer cleanup()
+
+			cmd := NewTopCommand()
+			if err := cmd.ParseFlags(tt.flags); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+
+			err := runTop(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runTop() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("runTop() error = %v, should contain %v", err, tt.errContains)
+				}
+				return
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("runTop() output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+package top
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/output"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+// viewMode is the view of the dashboard
+type viewMode int
+
+const (
+	// viewFleet is the consumers with their resource bundle counts and the recent status changes
+	viewFleet viewMode = iota
+	// viewConsumer is the resource bundles of a consumer
+	viewConsumer
+	// viewBundle is the detail of a resource bundle
+	viewBundle
+)
+
+// key is a key press that the dashboard handles
+type key int
+
+const (
+	keyUp key = iota
+	keyDown
+	keyEnter
+	keyBack
+	keyRefresh
+	keyQuit
+)
+
+const (
+	// minChangeRows is the number of the recent status changes of the fleet view if the screen is small
+	minChangeRows = 3
+	// timeFormat is the format of the times of the dashboard
+	timeFormat = "2006-01-02 15:04:05"
+)
+
+// dashboard is the state of the top screen
+type dashboard struct {
+	interval time.Duration
+	// interactive dashboards mark the selected row and print the keys
+	interactive bool
+
+	fleet *fleet
+	// refreshErr is the error of the last refresh, the last fleet is still shown
+	refreshErr error
+	changes    *changeLog
+	// stream is the state of the status subscription, empty if it is not used
+	stream string
+
+	mode          viewMode
+	consumerIndex int
+	bundleIndex   int
+	// bundleScroll is the first line of the bundle view
+	bundleScroll int
+	// consumer is the name of the consumer of the consumer view
+	consumer string
+	// bundleID is the ID of the resource bundle of the bundle view
+	bundleID string
+}
+
+func newDashboard(interval time.Duration, interactive bool, stream string) *dashboard {
+	return &dashboard{interval: interval, interactive: interactive, changes: newChangeLog(), stream: stream}
+}
+
+// update sets the fleet of the last refresh, the selected consumer is kept by its name
+func (d *dashboard) update(f *fleet) {
+	d.refreshErr = nil
+	selected := d.selectedConsumer()
+	d.fleet = f
+	d.changes.observeFleet(f)
+
+	for i, consumer := range f.Consumers {
+		if consumer.Name == selected {
+			d.consumerIndex = i
+		}
+	}
+	d.consumerIndex = clamp(d.consumerIndex, len(f.Consumers))
+	d.bundleIndex = clamp(d.bundleIndex, len(f.Bundles[d.consumer]))
+}
+
+func (d *dashboard) selectedConsumer() string {
+	if d.fleet == nil || d.consumerIndex >= len(d.fleet.Consumers) {
+		return ""
+	}
+	return d.fleet.Consumers[d.consumerIndex].Name
+}
+
+// handleKey moves the selection or changes the view, it returns false if the dashboard is closed
+func (d *dashboard) handleKey(k key) bool {
+	switch k {
+	case keyQuit:
+		return false
+	case keyUp:
+		d.move(-1)
+	case keyDown:
+		d.move(1)
+	case keyEnter:
+		if d.fleet == nil {
+			return true
+		}
+		switch d.mode {
+		case viewFleet:
+			if len(d.fleet.Consumers) > 0 {
+				d.mode, d.consumer, d.bundleIndex = viewConsumer, d.selectedConsumer(), 0
+			}
+		case viewConsumer:
+			if bundles := d.fleet.Bundles[d.consumer]; d.bundleIndex < len(bundles) {
+				d.mode, d.bundleID, d.bundleScroll = viewBundle, bundles[d.bundleIndex].GetId(), 0
+			}
+		}
+	case keyBack:
+		switch d.mode {
+		case viewBundle:
+			d.mode = viewConsumer
+		case viewConsumer:
+			d.mode = viewFleet
+		}
+	}
+	return true
+}
+
+func (d *dashboard) move(delta int) {
+	if d.fleet == nil {
+		return
+	}
+	switch d.mode {
+	case viewFleet:
+		d.consumerIndex = clamp(d.consumerIndex+delta, len(d.fleet.Consumers))
+	case viewConsumer:
+		d.bundleIndex = clamp(d.bundleIndex+delta, len(d.fleet.Bundles[d.consumer]))
+	case viewBundle:
+		d.bundleScroll = max(d.bundleScroll+delta, 0)
+	}
+}
+
+// render prints the view in the width and height of the screen, a zero width or height is not limited
+func (d *dashboard) render(w io.Writer, width, height int) error {
+	var buf bytes.Buffer
+	d.printHeader(&buf)
+
+	// the rows of the view are limited to the lines that the header and the keys leave
+	rows := 0
+	if height > 0 {
+		rows = max(height-lineCount(buf.String())-d.footerLines(), 1)
+	}
+
+	var err error
+	switch {
+	case d.fleet == nil:
+		fmt.Fprintln(&buf, "Loading...")
+	case d.mode == viewFleet:
+		err = d.printFleet(&buf, rows)
+	case d.mode == viewConsumer:
+		err = d.printConsumer(&buf, rows)
+	default:
+		err = d.printBundle(&buf, rows)
+	}
+	if err != nil {
+		return err
+	}
+	d.printFooter(&buf)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	for _, line := range lines {
+		if width > 0 && len([]rune(line)) > width {
+			line = string([]rune(line)[:width])
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *dashboard) printHeader(w io.Writer) {
+	title := "maestro top"
+	if d.fleet != nil {
+		title += " - " + d.fleet.Time.Local().Format(timeFormat)
+	}
+	if d.interactive {
+		title += fmt.Sprintf(" - refresh every %s", d.interval)
+	}
+	if d.fleet != nil {
+		title += fmt.Sprintf(" - %d consumers, %d resource bundles", len(d.fleet.Consumers), d.fleet.totalBundles())
+	}
+	fmt.Fprintln(w, title)
+
+	switch {
+	case d.fleet == nil:
+	case d.fleet.QueueErr != nil:
+		fmt.Fprintf(w, "Spec events: unavailable: %v\n", d.fleet.QueueErr)
+	case d.fleet.Queue == nil:
+		fmt.Fprintln(w, "Spec events: set --metrics-url to show the spec event queue")
+	default:
+		fmt.Fprintf(w, "Spec events: queue depth %.0f, oldest unreconciled %s\n",
+			d.fleet.Queue.Depth, formatAge(d.fleet.Queue.OldestEventAge))
+	}
+	if d.stream != "" {
+		fmt.Fprintf(w, "Status stream: %s\n", d.stream)
+	}
+
+	if d.refreshErr != nil {
+		fmt.Fprintf(w, "Refresh failed: %v\n", d.refreshErr)
+	}
+	fmt.Fprintln(w)
+}
+
+func (d *dashboard) footerLines() int {
+	if !d.interactive {
+		return 0
+	}
+	return 2
+}
+
+func (d *dashboard) printFooter(w io.Writer) {
+	if !d.interactive {
+		return
+	}
+	fmt.Fprintln(w)
+	switch d.mode {
+	case viewFleet:
+		fmt.Fprintln(w, "up/down select   enter bundles   r refresh   q quit")
+	case viewConsumer:
+		fmt.Fprintln(w, "up/down select   enter detail   esc back   r refresh   q quit")
+	default:
+		fmt.Fprintln(w, "up/down scroll   esc back   r refresh   q quit")
+	}
+}
+
+func (d *dashboard) printFleet(w io.Writer, rows int) error {
+	changes := d.changes.changes
+	changeRows := len(changes)
+	consumerRows := 0
+	if rows > 0 {
+		// the consumers get two thirds of the rows, the status changes at least a few
+		changeRows = min(changeRows, max(rows/3, minChangeRows))
+		// the table headers and the title of the status changes
+		consumerRows = max(rows-changeRows-4, 1)
+	}
+
+	table := &output.Table{
+		Columns: []output.TableColumn{
+			{Header: "CONSUMER"}, {Header: "BUNDLES"}, {Header: "APPLIED"}, {Header: "AVAILABLE"}, {Header: "FAILED"},
+			{Header: "PENDING"}, {Header: "DELETING"},
+		},
+	}
+	for _, c := range d.fleet.Consumers {
+		name := c.Name
+		if c.ID == "" {
+			// the resource bundles of a consumer that is not listed
+			name += " (unknown)"
+		}
+		table.Rows = append(table.Rows, []string{
+			name,
+			fmt.Sprintf("%d", c.Total),
+			fmt.Sprintf("%d", c.Applied),
+			fmt.Sprintf("%d", c.Available),
+			fmt.Sprintf("%d", c.Failed),
+			fmt.Sprintf("%d", c.Pending),
+			fmt.Sprintf("%d", c.Deleting),
+		})
+	}
+	if err := d.printSelectable(w, table, d.consumerIndex, consumerRows); err != nil {
+		return err
+	}
+	// the status changes are found between the refreshes of an interactive dashboard
+	if !d.interactive {
+		return nil
+	}
+
+	fmt.Fprintln(w, "\nRecent status changes:")
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "<none>")
+		return nil
+	}
+	changeTable := &output.Table{
+		Columns: []output.TableColumn{
+			{Header: "TIME"}, {Header: "BUNDLE"}, {Header: "CONSUMER"}, {Header: "CONDITIONS"}, {Header: "VIA"},
+		},
+	}
+	for _, change := range changes[:changeRows] {
+		changeTable.Rows = append(changeTable.Rows, []string{
+			change.Time.Local().Format(timeFormat), change.BundleID, change.Consumer, change.Conditions, change.Source,
+		})
+	}
+	return changeTable.Print(w, false, false)
+}
+
+func (d *dashboard) printConsumer(w io.Writer, rows int) error {
+	bundles := d.fleet.Bundles[d.consumer]
+	fmt.Fprintf(w, "Resource bundles of consumer %s:\n", d.consumer)
+	if len(bundles) == 0 {
+		fmt.Fprintln(w, "<none>")
+		return nil
+	}
+
+	table := &output.Table{
+		Columns: []output.TableColumn{
+			{Header: "ID"}, {Header: "NAME"}, {Header: "VERSION"}, {Header: "CONDITIONS"}, {Header: "UPDATED"},
+		},
+	}
+	for _, bundle := range bundles {
+		conditions := output.FormatConditions(output.GetStatusConditions(bundle.Status))
+		if conditions == "" {
+			conditions = noConditions
+		}
+		if bundle.DeletedAt != nil {
+			conditions += " (deleting)"
+		}
+		table.Rows = append(table.Rows, []string{
+			bundle.GetId(), bundle.GetName(), fmt.Sprintf("%d", bundle.GetVersion()), conditions, formatTime(bundle.UpdatedAt),
+		})
+	}
+	// the title line
+	if rows > 0 {
+		rows = max(rows-2, 1)
+	}
+	return d.printSelectable(w, table, d.bundleIndex, rows)
+}
+
+func (d *dashboard) printBundle(w io.Writer, rows int) error {
+	bundle := d.fleet.findBundle(d.bundleID)
+	if bundle == nil {
+		fmt.Fprintf(w, "Resource bundle %s is not found, it may have been deleted\n", d.bundleID)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := printBundleDetail(&buf, bundle); err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	d.bundleScroll = clamp(d.bundleScroll, len(lines))
+	lines = lines[d.bundleScroll:]
+	if rows > 0 && len(lines) > rows {
+		lines = lines[:rows]
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// printBundleDetail prints the resource bundle with its conditions and the conditions of its manifests
+func printBundleDetail(w io.Writer, bundle *openapi.ResourceBundle) error {
+	if err := output.PrintResourceBundle(w, bundle); err != nil {
+		return err
+	}
+	if bundle.DeletedAt != nil {
+		fmt.Fprintf(w, "\nPending deletion since %s\n", formatTime(bundle.DeletedAt))
+	}
+
+	fmt.Fprintln(w, "\nConditions:")
+	conditions := output.GetStatusConditions(bundle.Status)
+	if len(conditions) == 0 {
+		fmt.Fprintln(w, "<none>")
+	} else if err := conditionTable(conditions).Print(w, false, false); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nManifests:")
+	manifests := output.GetManifestStatuses(bundle.Status)
+	if len(manifests) == 0 {
+		fmt.Fprintln(w, "<none>")
+		return nil
+	}
+	table := &output.Table{
+		Columns: []output.TableColumn{
+			{Header: "MANIFEST"}, {Header: "CONDITION"}, {Header: "STATUS"}, {Header: "REASON"}, {Header: "MESSAGE"},
+		},
+	}
+	for _, manifest := range manifests {
+		if len(manifest.Conditions) == 0 {
+			table.Rows = append(table.Rows, []string{manifest.Key(), "-", "-", "", ""})
+		}
+		for _, cond := range manifest.Conditions {
+			table.Rows = append(table.Rows, []string{manifest.Key(), cond.Type, cond.Status, cond.Reason, cond.Message})
+		}
+	}
+	return table.Print(w, false, false)
+}
+
+func conditionTable(conditions []output.StatusCondition) *output.Table {
+	table := &output.Table{
+		Columns: []output.TableColumn{
+			{Header: "TYPE"}, {Header: "STATUS"}, {Header: "REASON"}, {Header: "MESSAGE"}, {Header: "LAST TRANSITION"},
+		},
+	}
+	for _, cond := range conditions {
+		table.Rows = append(table.Rows, []string{cond.Type, cond.Status, cond.Reason, cond.Message, cond.LastTransitionTime})
+	}
+	return table
+}
+
+// printSelectable prints the rows of the table around the selected row, the selected row is marked in an interactive
+// dashboard, a zero rows is not limited
+func (d *dashboard) printSelectable(w io.Writer, table *output.Table, selected, rows int) error {
+	start, end := 0, len(table.Rows)
+	if rows > 0 && len(table.Rows) > rows {
+		start = min(max(selected-rows/2, 0), len(table.Rows)-rows)
+		end = start + rows
+	}
+
+	visible := &output.Table{Columns: table.Columns, Rows: table.Rows[start:end]}
+	if d.interactive {
+		visible.Columns = append([]output.TableColumn{{Header: " "}}, table.Columns...)
+		visible.Rows = nil
+		for i, row := range table.Rows[start:end] {
+			marker := " "
+			if start+i == selected {
+				marker = ">"
+			}
+			visible.Rows = append(visible.Rows, append([]string{marker}, row...))
+		}
+	}
+	return visible.Print(w, false, false)
+}
+
+// clamp returns the index in the range of a list of n items
+func clamp(index, n int) int {
+	return max(min(index, n-1), 0)
+}
+
+func lineCount(s string) int {
+	return strings.Count(s, "\n")
+}
+
+// formatAge formats an age in seconds as a duration rounded to the second
+func formatAge(seconds float64) string {
+	if math.IsNaN(seconds) {
+		return "unknown"
+	}
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second).String()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(timeFormat)
+}
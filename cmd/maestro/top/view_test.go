@@ -0,0 +1,153 @@
+package top
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-online/maestro/cmd/maestro/common/clients"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func testFleet() *fleet {
+	consumers := []openapi.Consumer{
+		{Id: openapi.PtrString("consumer-1"), Name: openapi.PtrString("cluster1")},
+		{Id: openapi.PtrString("consumer-2"), Name: openapi.PtrString("cluster2")},
+	}
+	bundle := testBundle("bundle-1", "web", "cluster1", "Applied", "True")
+	bundle.Status["resourceStatus"] = []interface{}{
+		map[string]interface{}{
+			"resourceMeta": map[string]interface{}{"kind": "Deployment", "namespace": "default", "name": "web"},
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False", "reason": "MinimumReplicasUnavailable"},
+			},
+		},
+	}
+	f := summarizeFleet(time.Now(), consumers, []openapi.ResourceBundle{bundle, testBundle("bundle-2", "api", "cluster1")})
+	f.Queue = &queueStatus{Depth: 3, OldestEventAge: 75}
+	return f
+}
+
+func renderDashboard(t *testing.T, d *dashboard, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := d.render(&buf, width, height); err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	return buf.String()
+}
+
+func assertContains(t *testing.T, got string, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !strings.Contains(got, want) {
+			t.Errorf("render() output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDashboard_Views(t *testing.T) {
+	d := newDashboard(5*time.Second, true, "subscribed to localhost:8090 as source maestro-cli")
+	assertContains(t, renderDashboard(t, d, 0, 0), "Loading...")
+
+	d.update(testFleet())
+	d.changes.observeEvent(&clients.ResourceBundleStatusEvent{
+		Time:         time.Now(),
+		ID:           "bundle-2",
+		ConsumerName: "cluster1",
+		Conditions:   []metav1.Condition{{Type: "Applied", Status: metav1.ConditionTrue}},
+	})
+
+	assertContains(t, renderDashboard(t, d, 0, 0),
+		"refresh every 5s - 2 consumers, 2 resource bundles",
+		"Spec events: queue depth 3, oldest unreconciled 1m15s",
+		"Status stream: subscribed to localhost:8090",
+		"CONSUMER   BUNDLES   APPLIED   AVAILABLE   FAILED   PENDING   DELETING",
+		">   cluster1   2         1         0           0        1         0",
+		"bundle-2   cluster1   Applied=True   stream",
+		"enter bundles",
+	)
+
+	// the selection moves to cluster2 and stays on the last consumer
+	d.handleKey(keyDown)
+	d.handleKey(keyDown)
+	assertContains(t, renderDashboard(t, d, 0, 0), ">   cluster2")
+
+	d.handleKey(keyUp)
+	d.handleKey(keyEnter)
+	assertContains(t, renderDashboard(t, d, 0, 0),
+		"Resource bundles of consumer cluster1:",
+		">   bundle-2   api",
+		"<none>",
+		"esc back",
+	)
+
+	d.handleKey(keyDown)
+	d.handleKey(keyEnter)
+	assertContains(t, renderDashboard(t, d, 0, 0),
+		"bundle-1",
+		"Applied   True",
+		"deployment/default/web   Available   False    MinimumReplicasUnavailable",
+	)
+
+	// the refresh keeps the view, and the bundle view reports a deleted bundle
+	f := testFleet()
+	f.Bundles["cluster1"] = f.Bundles["cluster1"][:1]
+	d.update(f)
+	assertContains(t, renderDashboard(t, d, 0, 0), "Resource bundle bundle-1 is not found")
+
+	d.handleKey(keyBack)
+	d.handleKey(keyBack)
+	if d.mode != viewFleet {
+		t.Errorf("mode = %v, want the fleet view", d.mode)
+	}
+	if !d.handleKey(keyEnter) || d.handleKey(keyQuit) {
+		t.Errorf("handleKey() should only close the dashboard with the quit key")
+	}
+}
+
+func TestDashboard_Screen(t *testing.T) {
+	f := testFleet()
+	for i := 3; i <= 40; i++ {
+		name := fmt.Sprintf("cluster%02d", i)
+		f.Consumers = append(f.Consumers, consumerSummary{ID: name, Name: name})
+	}
+	d := newDashboard(time.Second, true, "disabled")
+	d.update(f)
+	d.refreshErr = fmt.Errorf("connection refused")
+	for i := 0; i < 30; i++ {
+		d.handleKey(keyDown)
+	}
+
+	out := renderDashboard(t, d, 40, 20)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 20 {
+		t.Errorf("render() lines = %d, want the height of the screen", len(lines))
+	}
+	for _, line := range lines {
+		if len([]rune(line)) > 40 {
+			t.Errorf("render() line %q is wider than the screen", line)
+		}
+	}
+	// the selected consumer is visible
+	assertContains(t, out, "Refresh failed: connection refused", ">   cluster31", "up/down select")
+}
+
+func TestDashboard_Once(t *testing.T) {
+	f := testFleet()
+	f.Queue, f.QueueErr = nil, fmt.Errorf("connection refused")
+	d := newDashboard(time.Second, false, "")
+	d.update(f)
+
+	out := renderDashboard(t, d, 0, 0)
+	assertContains(t, out, "Spec events: unavailable: connection refused", "cluster1   2")
+	for _, notWant := range []string{"refresh every", "Status stream", "Recent status changes", ">", "q quit"} {
+		if strings.Contains(out, notWant) {
+			t.Errorf("render() output should not contain %q:\n%s", notWant, out)
+		}
+	}
+}
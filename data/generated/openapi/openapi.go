@@ -77,7 +77,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _openapiYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5b\x6d\x8f\xdb\xb8\x11\xfe\xbe\xbf\x62\x80\xb6\xf0\xe5\xe0\xb7\xf4\xae\x40\x2b\x24\x07\x24\x77\x97\x22\x87\x5c\x92\xee\x26\x6d\x81\xa2\xf0\xd2\xe4\xc8\xe2\xad\x44\x2a\x24\xb5\xbb\x4e\xdb\xff\x5e\x90\xd4\xbb\x65\xad\xec\xdb\x8d\xdd\xad\xf7\x4b\x2c\x6a\x38\x9c\x21\x9f\x79\x34\x43\x32\x32\x45\x41\x52\x1e\xc0\x37\xd3\xf9\x74\x7e\xc6\x45\x28\x83\x33\x00\xc3\x4d\x8c\x01\x24\x04\xb5\x51\x12\x2e\x50\x5d\x73\x8a\xf0\xe2\xfd\xeb\x33\x00\x86\x9a\x2a\x9e\x1a\x2e\xc5\x36\x91\x6b\x54\xda\xbd\x9e\x4f\xe7\xd3\xa7\x67\x1a\x95\x6d\xb1\x9a\x27\x90\xa9\x38\x80\xc8\x98\x34\x98\xcd\x62\x49\x49\x1c\x49\x6d\x82\x3f\xce\xe7\xf3\x33\x80\x96\x76\x9a\x29\x85\xc2\x00\x93\x09\xe1\xa2\xd9\x5d\x07\xb3\x19\x49\xf9\xd4\xba\xa0\x23\x1e\x9a\x29\x95\xc9\xa6\x8a\x9f\x09\x17\xf0\x55\xaa\x24\xcb\xa8\x6d\x79\x02\xde\x9a\x6e\x65\xda\x90\x15\xde\xa5\xf2\xc2\x90\x15\x17\xab\x42\x51\x4a\x4c\xe4\x7c\xb3\x1a\x66\xf9\x84\xcc\xae\x9f\xce\x14\x6a\x99\x29\x8a\x93\x65\x26\x58\x8c\x4e\x06\x60\x85\xc6\xff\x00\xd0\x59\x92\x10\xb5\x0e\xe0\x1c\x4d\xa6\x84\x06\x02\x31\xd7\x06\x64\x08\x45\x5f\xc8\xfb\x16\x3d\x90\x66\x8a\x9b\x75\xa1\xc1\x3a\xf1\x12\x89\x42\x15\xc0\x3f\xfe\x99\x37\x2a\xd4\xa9\x14\xba\x18\xd0\xfe\x8d\x7e\x3f\x9f\x8f\xaa\xc7\x96\x43\x2f\xe0\xa7\x8b\x77\x6f\x81\x28\x45\xd6\x1d\x83\x83\x5c\xfe\x82\xd4\xe8\x5a\x77\x2a\x85\x41\x61\xea\x1a\x01\x48\x9a\xc6\x9c\x12\xab\x73\xf6\x8b\x96\xa2\xf9\x16\x40\xd3\x08\x13\xd2\x6e\x05\xf8\xad\xc2\x30\x80\xd1\x6f\x66\x54\x26\xa9\x14\x28\x8c\x9e\x79\x59\x3d\x3b\xcf\x4d\x79\xe9\x2c\x79\xc3\xb5\x19\x55\x4e\x7d\x3b\x7f\xda\xe3\x54\x66\x22\x30\xf2\x0a\x05\x70\x0d\x5c\x5c\x93\x98\xb3\x43\xb8\xf0\xa3\x52\x52\x35\xac\xfe\x66\xbb\xd5\x1f\x05\xc9\x4c\x24\x15\xff\x8c\x0c\x8c\x84\x14\x55\x28\x55\x02\x32\x45\xe5\xcc\x3a\x06\x0f\xfe\xd0\x07\xa6\x8f\x02\x6f\x53\xa4\x06\x19\xa0\xed\x07\x92\xba\x30\x3e\xfc\xdc\xa7\x44\x91\x04\x4d\xce\x44\xe0\x82\xa7\xab\x73\x25\x37\x4b\xc9\x0a\x47\x43\x85\x35\xff\xbc\x83\x30\x12\x45\xa3\xc1\xe2\x52\x31\x54\x2f\xd7\x83\xe5\x43\x8e\x31\xd3\x95\x38\x17\x01\x44\x48\x98\x23\x3e\xff\x27\x48\x82\x01\xfc\x7d\xf2\xae\x80\xd6\xe4\xf5\x0f\x67\xdb\x27\xdb\xac\x53\x0c\x40\x1b\xc5\xc5\x6a\x00\xd9\xcd\xfe\xc5\xd9\x7f\xb6\x33\xde\x9f\xd1\x00\xd9\x20\x9a\xe5\x1a\xca\x18\x7d\x18\xaa\x3b\x6f\x8d\x18\xca\x4c\xb0\xc6\xb8\x5f\x14\x9f\x4d\x7a\x3b\x51\xdb\x11\x78\xf0\xed\x76\x0f\xde\xca\x0d\xc4\xde\x70\x13\x81\x4e\x91\xf2\x90\x23\x03\xce\x00\x6f\xb9\x3e\xcc\xb7\xf2\xff\x97\xa6\x39\x7b\x40\xa6\xb3\x13\x17\xa3\xc1\x0d\x0e\xfb\xc1\x35\x6f\xd2\xd8\xaf\x27\xb0\x1e\x0c\xb6\x09\xcc\xdb\xc6\x40\x67\x94\xa2\xd6\x61\x16\xc7\xeb\x3a\x9c\x7b\x20\xf0\x57\x4b\x1b\x6e\x3e\x3c\x04\xf4\xf1\x60\xe0\xc4\x80\x27\x06\xfc\xe2\x0c\xe8\x42\xc9\x16\x76\xdd\xf1\x7c\x38\x4f\xf6\x66\xc4\x76\x92\x46\xa5\xd0\x59\x52\xea\x19\x56\x8a\x96\x9d\xbe\x68\x0d\x5a\x8c\x7a\xc8\xe2\xf3\xfb\xdc\x86\x53\xd9\x79\x14\xcc\xf4\x68\xf2\x99\x1d\x0b\xcf\x1d\x4b\xcf\x9d\x8b\xcf\xdd\xcb\xcf\x1d\x0b\xd0\x54\xea\x4d\xa2\xf9\x5e\x21\x71\xd9\x93\xc0\x9b\x32\xda\x77\xa3\x98\x4f\x19\x6a\xf3\x52\xb2\x9a\x5c\x03\x13\x45\xfc\x02\x23\x86\x94\x22\xb6\x1f\x57\xc8\x02\x30\x2a\xab\xc8\xbd\x03\x1c\xfd\xd0\xe8\x06\xc6\x10\x3e\x19\xf5\x92\x64\x0f\xb9\xf8\x39\x3b\x08\xa4\xdb\xb6\x9f\x92\xcb\x13\x85\xef\xe3\xc1\x9f\x7a\xd0\x5d\x84\x2b\x89\x15\x12\xb6\xfe\x5f\x49\x24\x5f\x08\xc8\xb6\x7d\x7d\x80\xda\x90\xb5\x49\xa5\x89\xb0\x4d\x73\x87\x71\x69\x6b\x52\x38\x68\xcb\xae\xcc\xcb\x1e\x7e\xaf\xae\xc4\xc3\x81\x37\xe9\x3a\xa9\xef\xc4\x1f\xc7\x58\x9c\x96\xe8\x7c\x3c\x55\xe9\x91\x7c\x36\x53\x62\x68\xb4\xc1\x09\x1f\x53\xe6\x92\x38\xf1\x40\x19\x9c\xd7\xcf\xaa\x75\x3d\xb2\x4c\xee\xbd\x9d\x95\x73\xef\x46\x7f\x56\x37\x84\xe7\xb2\xdc\xdb\xce\xbd\xbc\x43\x13\xde\x29\xd7\xfb\xc2\x56\x9f\xb8\xfa\x28\x9c\x78\x84\x09\xeb\xc6\x37\xc6\x11\x8f\x4d\x52\x8f\x22\x41\xbd\xfb\xc0\x65\xbf\x8f\xcd\x8e\x27\x2d\xd5\xee\xc1\xe9\x88\xe5\xc4\x8c\xf7\xeb\xc1\x23\x60\xc6\x3d\xcf\x56\x8e\x84\x61\xf6\x3c\x52\xa9\xde\xd8\x6e\x05\xef\x5c\x58\xfd\x05\xb1\xe4\xc4\x93\x6b\xf5\x67\xc9\x91\x31\xe9\x59\xcd\x6e\x0c\x60\xe9\xc4\xf2\x46\xff\xf0\x4a\xaa\x84\x98\x00\x7e\xfa\xdb\x87\xb3\xc2\xc1\x5c\xe9\x3b\x77\x08\x72\x8e\x21\x2a\x14\x14\x9b\xda\xfd\x09\x49\xde\x94\x2a\x0b\x75\xc3\xeb\x3c\xc7\x59\x7d\x9e\x36\x8e\xb7\xed\xdf\x15\x17\x77\x0b\x45\x76\x82\xfa\x84\xde\xf0\x6a\xa3\x77\xa0\x6d\x83\x06\x4e\xc9\x0a\x37\x85\xb8\x30\xb8\xaa\x21\x49\xf3\xcf\x03\xa4\x8c\x34\x24\xbe\x4b\xac\xac\x2c\x6a\x5f\x14\x6b\x69\xed\xd1\xda\x54\x7b\xb4\x83\xd7\x1e\xdd\x28\xb5\x67\x6e\x30\xf1\x61\xeb\x40\x58\xe8\x25\x71\xfc\x2e\xec\x47\x60\x01\xde\x16\x04\xaa\x0b\x0f\x1d\x13\xdd\x3d\xd5\x36\xd2\x18\x36\x43\xa6\x73\xba\xad\xff\x64\x23\xe6\xb6\x88\x96\xcc\xba\x68\xc2\xac\xa3\x83\x73\xbd\x8e\x91\x1d\xdc\xaf\x9f\xc1\xed\xe4\xb3\x9b\xf9\x2e\xc3\xdc\x51\x63\xa3\xbd\x43\x74\x30\xa1\x34\x6f\x72\x1d\x68\x7d\xdd\x45\x97\x21\x8b\x56\xb0\xf0\x62\x70\x8f\xe2\x4a\x77\x87\x6c\x3b\xc2\xc0\x6f\x7a\x22\x5b\x10\x33\x48\x37\x40\x98\x53\x9f\xad\x7f\x27\x86\x27\xf5\x33\xf7\xbc\x2a\xbe\x1f\x65\x79\x2e\x77\x3f\xca\x12\x34\x84\x11\x43\xba\x54\xb5\xd6\x0b\x20\x21\x82\x87\xa8\xcd\xaf\xc1\xe2\x16\xd5\xde\xa9\x85\xf4\x1f\xdf\x5d\x8c\x59\x50\x29\x42\xbe\x7a\x00\x9b\xb4\x21\x26\xeb\xd4\x5b\x13\xdd\xbc\xdd\xfd\x58\x98\xa1\xeb\x62\x67\x51\x50\x74\xfa\xb8\x27\x3d\x6c\x75\x79\x9b\xd3\x5d\x24\xd1\x03\xff\x98\x2c\x31\x1e\xba\xe6\xce\x29\xc6\xb8\x85\x21\x89\xdf\x6f\x19\xbf\x77\xbc\x6d\xcc\xd1\xd3\xa5\x3f\x46\xb7\xf3\xc7\x1e\x2a\xeb\x17\x42\xf6\x5a\xc5\xe6\x4d\x92\x9d\x97\xae\x27\x00\x37\x31\xbb\x45\x7c\x97\xad\xbf\xae\x6d\xce\x1d\xf3\xbb\x4d\x00\x6d\xf1\xf9\x6e\xe0\xb4\x96\xab\x9d\xc2\x57\xe9\x87\xbf\xef\x59\x56\xab\x5c\x04\x90\x12\x13\xe5\x8f\x8d\x42\xe5\x43\x84\xb6\xac\x72\xff\xe9\x85\x4a\xc5\xda\xf9\x5f\x7d\x67\xb9\x5d\x72\x6c\xc0\xa7\x9e\xa6\x7a\x1b\x6a\x49\xa2\xb5\xe2\x53\x86\x6a\xdd\x65\xc6\x7b\xb2\x42\x10\x59\xb2\x44\x55\xd9\xe2\x6f\x60\xdd\x44\x28\x1a\x0d\x78\x4b\x11\x99\xae\xd5\x85\x76\x94\x7a\x02\xda\x6d\x68\xfb\x33\xcd\x30\x24\x59\x6c\x02\x78\x5a\x36\x25\x5c\xf0\x24\x4b\xaa\xa6\x6a\x1e\x42\x12\x6b\xaf\xbf\x9e\x66\x7b\x2f\x6b\x43\xf7\x7a\xf9\x33\xb9\xb5\xea\x37\x1c\xd5\xb6\x52\x57\xee\xe2\xd9\x9e\x1e\xe4\xff\x79\xac\xe1\xc3\xbc\xcf\x07\x77\x01\xa6\xe5\x85\x6b\xdb\xe2\x47\x97\x92\x96\x77\xff\x9e\x94\x36\x5c\xe4\x4b\xa3\xdd\xa9\xaf\x57\x0c\x54\x71\x83\x8a\x93\xa9\x03\x9d\x5e\x0b\x43\x6e\xed\x1c\x98\x88\xeb\x0a\xcc\xc0\xab\xea\x5e\xf3\x84\xc7\x44\xd9\xd9\x31\xad\x2e\x08\x8b\x9b\x08\x15\x2e\x80\xc6\x24\xd3\x68\x5b\x89\x80\x8b\xbf\xbc\x71\x5f\x5e\x4c\x50\x98\x71\xa9\x28\xd3\xc5\x09\xb4\x75\x55\x17\x2a\x6c\x5d\x0d\xc4\x18\xc5\x97\x99\x41\x0d\x33\xa0\x32\xce\x12\xd1\x94\x22\x94\xca\x4c\x98\x29\x94\xea\x5e\x49\x05\x78\x4b\x92\x34\xc6\x31\x70\x01\xee\x76\x50\xbe\x86\x8a\xe3\x35\x5a\x52\xac\xf7\xd5\x7e\x27\x83\x40\xa6\x51\x59\xe5\x95\x8b\x86\x28\xb7\x2f\xe0\x04\x2e\x93\xf5\x65\x70\x56\xbe\xbc\xbc\xbc\xd4\x9f\xe2\x9a\x17\xbe\x33\xc4\xfc\x0a\x61\x94\xac\x7f\x37\xaa\x8b\x56\xfd\x3e\x6c\x4e\x3a\x50\x22\x80\xc4\x5a\xc2\x12\xfd\xde\x02\x32\x90\x36\xb0\x62\x77\xe4\x52\x5c\xf9\x9c\xee\xe1\xa4\xce\x96\x25\x0c\xb4\x27\x3c\x74\xc7\xd5\x97\xa1\x94\xcf\x97\x44\x5d\x8e\xb7\xfa\x54\xef\xbb\xf0\x5c\x39\xbd\xc2\x35\x3c\x87\x51\x28\xe5\x08\x88\x60\x9d\x32\xd7\x24\xce\xd0\x4a\x2d\x89\xda\x32\x0b\xaf\xfd\xf2\xd5\x91\x25\x46\xc6\x92\xf4\x35\x67\xc8\xc6\x20\x15\x70\x2f\xe3\xb5\x71\x0d\x98\xa4\x66\x3d\xb6\x6d\xd5\x46\xd9\xc6\x5a\x9a\x88\x18\xd7\x62\x17\x04\x22\xa2\x21\x45\x95\x70\x6d\xeb\x03\x3b\x41\x1a\x11\x6e\x78\x1c\xc3\xb2\x5a\x67\x1f\xdd\xc8\xa6\x43\xb9\x34\xbf\x71\xd6\x0c\xd1\xbc\xf1\x01\x62\xd4\xaf\xee\x72\x7d\xef\x51\x5a\x28\x1e\x16\xa8\xcb\xcc\xec\x1c\xac\xad\x30\xdd\x11\xc0\xe5\xaa\xba\xd7\x1e\xb7\x45\xa0\x0d\x08\x45\xa2\x69\x37\xfa\xde\xa9\xfd\xc6\x84\x05\x11\x6c\x01\x21\x57\xda\xc0\x70\x23\xc6\xbe\xc7\xdb\x5e\x9b\xee\x2b\x22\x84\x04\xbc\x4d\x63\x4e\xb9\xf1\x2e\x78\x02\x73\x88\x2f\xc8\x65\x30\xd0\xfd\x45\xc9\x26\xce\x7d\xdb\xfd\xc0\x3c\x73\xf6\x68\x77\x6a\x92\x24\x64\xa2\xd1\xfa\x6f\x39\xaf\xb8\xe0\xed\x47\xb3\xab\xb4\xc4\x8d\x40\x05\x78\xe5\x5f\xcb\xd0\x12\xd1\x44\x1b\x95\x51\x93\x29\xab\x51\xb8\xc4\xc9\x65\x9e\xda\xae\x06\x3c\x2b\xdf\x7e\x37\x7d\xe6\xd4\x7e\x07\x42\x1a\xb7\x3d\x54\x29\x7c\xa6\x4d\x21\xf4\x35\x24\x48\x84\x76\xa8\x70\xf2\x4e\x21\x94\x6a\xca\x3e\x3f\x7a\x20\x07\x1e\xd5\x84\x46\x70\x51\x63\x45\x6b\xfb\x0a\x0d\x70\x36\x76\x9b\x94\x63\x48\x63\x22\xbe\xe2\xcc\xd9\x78\xc5\x05\x7b\xe2\x7e\x79\xf2\x84\xaf\xca\xe1\xf4\x93\x06\xba\xca\xdf\x92\x26\x4e\x61\x93\xda\x27\x93\x0a\x3a\xbe\xfb\x73\xce\xc6\x6e\x40\x3b\xde\x94\x33\xff\xaf\x1d\x70\x9c\x13\xf5\xd7\xcd\x5e\x68\x68\xf4\xc6\xbd\x79\xde\xb8\xb3\x50\x0d\xde\x0b\x98\xff\x06\x00\x00\xff\xff\x71\x4d\x52\xfa\xb0\x3f\x00\x00")
+var _openapiYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x5c\x6f\x8f\xdc\xb6\xd1\x7f\xbf\x9f\x62\x80\xe7\x29\x36\x09\x6e\xff\xb8\x4e\x81\x66\x11\x07\xb0\x9d\xb8\x70\x90\xc4\xee\x5d\xd2\x14\x28\x8a\x3b\xae\x34\x5a\x31\x96\x48\x85\x1c\x9e\x6f\xdd\xf6\xbb\x17\x24\x25\xad\xa4\x95\xb4\xda\xbb\xb3\x6f\xed\x6a\xdf\xdc\x2d\x77\x38\x9c\xa1\x66\x7e\x33\xe4\x90\x92\x19\x0a\x96\xf1\x15\x3c\x9e\x2f\xe7\xcb\x09\x17\x91\x5c\x4d\x00\x88\x53\x82\x2b\x48\x19\x6a\x52\x12\x2e\x50\x5d\xf3\x00\xe1\xe9\xeb\x97\x13\x80\x10\x75\xa0\x78\x46\x5c\x8a\x2e\x92\x6b\x54\xda\xfd\xbc\x9c\x2f\xe7\x8f\x26\x1a\x95\x6d\xb1\x9c\x67\x60\x54\xb2\x82\x98\x28\x5b\x2d\x16\x89\x0c\x58\x12\x4b\x4d\xab\x3f\x2f\x97\xcb\x09\x40\x83\x7b\x60\x94\x42\x41\x10\xca\x94\x71\x51\xef\xae\x57\x8b\x05\xcb\xf8\xdc\xaa\xa0\x63\x1e\xd1\x3c\x90\xe9\x3e\x8b\x1f\x19\x17\xf0\x59\xa6\x64\x68\x02\xdb\xf2\x39\x78\x69\xda\x99\x69\x62\x1b\x3c\xc4\xf2\x82\xd8\x86\x8b\x4d\xc1\x28\x63\x14\x3b\xdd\x2c\x87\x45\x3e\x21\x8b\xeb\x47\x0b\x85\x5a\x1a\x15\xe0\x6c\x6d\x44\x98\xa0\xa3\x01\xd8\x20\xf9\x7f\x00\xb4\x49\x53\xa6\xb6\x2b\x38\x47\x32\x4a\x68\x60\x90\x70\x4d\x20\x23\x28\xfa\x42\xde\xb7\xe8\x81\x81\x51\x9c\xb6\x05\x07\xab\xc4\x33\x64\x0a\xd5\x0a\xfe\xf1\xcf\xbc\x51\xa1\xce\xa4\xd0\xc5\x80\xf6\x33\xfd\xe3\x72\x39\xdd\x7d\x6d\x28\xf4\x14\xbe\xbf\x78\xf5\x13\x30\xa5\xd8\xb6\x65\x70\x90\xeb\xdf\x30\x20\x5d\xe9\x1e\x48\x41\x28\xa8\xca\x11\x80\x65\x59\xc2\x03\x66\x79\x2e\x7e\xd3\x52\xd4\x7f\x05\xd0\x41\x8c\x29\x6b\xb6\x02\xfc\xbf\xc2\x68\x05\xd3\xff\x5b\x04\x32\xcd\xa4\x40\x41\x7a\xe1\x69\xf5\xe2\x3c\x17\xe5\x99\x93\xe4\x07\xae\x69\xba\x53\xea\xcb\xe5\xa3\x1e\xa5\x0c\xc5\x40\xf2\x0d\x0a\xe0\x1a\xb8\xb8\x66\x09\x0f\x1f\x42\x85\xef\x94\x92\xaa\x26\xf5\xe3\x6e\xa9\x7f\x11\xcc\x50\x2c\x15\x7f\x87\x21\x90\x84\x0c\x55\x24\x55\x0a\x32\x43\xe5\xc4\x3a\x05\x0d\xfe\xd4\x67\x4c\xbf\x08\xbc\xc9\x30\x20\x0c\x01\x6d\x3f\x90\x81\x73\xe3\x87\x9f\xfb\x8c\x29\x96\x22\xe5\x48\xe4\x9d\xa7\xad\xf3\x8e\x6e\x91\xb1\x0d\x4e\x87\x12\x6b\xfe\xee\x08\x62\x64\x2a\x88\x07\x93\x4b\x15\xa2\x7a\xb6\x1d\x4c\x1f\x71\x4c\x42\xbd\x23\xe7\x62\x05\x31\xb2\x10\x55\xde\x04\x20\x58\x8a\x2b\xf8\xfb\xec\x55\x61\x5a\xb3\x97\xdf\x4e\xba\x27\x9b\xb6\x19\xae\x40\x93\xe2\x62\xe3\x9a\x33\x8b\xdb\x4d\x24\xfb\x56\x6d\x67\xca\x08\xa0\x18\x21\x50\xe8\xf8\x82\x8c\x80\x35\x41\x65\xd2\x62\x3a\xff\x9e\x95\xe3\xbd\x12\xc9\xd6\x31\x09\x73\x86\x0a\x7f\x37\xa8\x09\xb8\x06\x6d\xb2\x4c\x2a\xc2\xf0\x0c\x8c\x46\xd8\x9c\xbf\x7e\xee\x5c\xc5\xac\x13\xae\x63\xd7\xab\x31\xd8\xbc\xe4\xfb\x73\x8c\x55\x56\x0e\x14\x98\xb5\x55\x26\x42\xd7\xf3\xad\x34\x49\x38\x5b\xef\xb1\xb0\xd4\xca\xc1\xb4\x1d\x57\x48\x8a\x6d\x08\xe0\x1a\x32\x1b\xeb\x34\x61\x38\x3f\x0e\xa3\x9d\x0c\xcf\x64\x58\xa1\xab\x4d\xc6\x79\x63\xfc\x90\x11\x2b\x29\x6d\x77\xae\x30\x5c\x01\x29\x83\x93\x1e\xbf\xea\xf7\xaa\x76\x9f\x1a\x0e\xc8\xd3\xde\x90\xd3\x83\xce\x3f\xf7\x4c\x75\xfb\xf4\x3e\x7c\xf4\xa9\x61\x78\x0f\x02\xfe\xcd\x1b\x15\x97\xc2\x23\xa0\x3e\x1d\x08\x1c\x83\xe6\x03\x6a\xf0\x65\xb7\x06\x3f\x49\x2b\x98\x36\x29\x2a\x78\xcb\x29\x06\x9d\x61\xc0\x23\x8e\xa1\x83\x69\xc0\x1b\xae\x49\x9f\x86\x1a\x5f\x75\xab\xd1\xc4\x2c\x96\x28\x64\xe1\xf6\x84\xc4\xef\x4d\x5d\x9e\x0a\x30\x5d\xd9\x4b\x1e\xcd\xc4\xa6\x2d\xbe\x9c\x86\x66\x07\xe0\x36\x54\xdb\x73\x23\xe0\x77\x83\x6a\xbb\xcb\x85\x80\x6b\x10\x92\x40\x23\x01\xc9\x7a\x38\xf9\x88\x12\x35\xaf\xdc\x74\xc0\x2a\x6c\xf1\x2f\x1e\xfe\xa7\x7b\x29\xf6\x17\xa4\xfd\x64\x05\xd6\x5b\xe0\xe1\x71\xf1\xfd\xc8\x35\x58\xd3\x75\x22\x69\x44\x58\x1b\xf7\x54\x22\xdf\x18\x3e\x4e\x31\x7c\x34\x2d\xb6\x11\x45\x78\xf8\xb1\x80\xf0\x27\xb5\x7e\xe4\xe1\xfb\x5d\x82\x31\x0a\xe2\xde\x35\x98\xc9\xec\xf2\xe6\xd4\x56\x60\xdc\xaf\xb7\x8a\x94\xe7\xd2\xce\x03\x30\x85\x40\xcc\x62\x47\xa4\x64\xea\x98\xd8\xad\xb5\x72\x65\xa6\x49\x2a\x0c\xf7\x16\x0b\x25\x63\x56\x6c\x3b\x5a\x6d\x97\x20\x15\x18\xa1\x91\x20\x45\x26\xb4\xe3\x90\x30\xb2\x0a\xe4\x64\xf3\xde\xc5\x60\xc9\xf6\xa4\x16\x85\xbf\x64\x5e\x40\xf5\xf1\x2f\x0e\x97\xe3\xe2\x70\x5c\x1c\x8e\xd1\xfd\xd3\x8b\xee\x47\xad\x10\xb9\x86\x35\x5a\x6f\x0e\x31\x41\xc2\x10\xa4\x72\x88\x5b\x40\x79\x20\x45\x94\xf0\xe0\x63\xcc\x5b\x5c\xe8\xb5\xaa\x8d\x8b\xc5\x13\xce\xca\x06\xaf\x2b\x21\x37\xd1\xfd\x74\xcb\x35\x1f\x9d\x5f\xfd\x6a\x5d\xd8\xb3\x7f\x62\x27\xd2\xe7\x5a\x96\x17\x97\x0e\x60\xa5\x4d\xc0\xca\x9c\xa4\x16\xf7\x72\x67\x99\xdf\x7d\x45\xfa\xe5\x70\x57\xcd\x07\x05\x6d\x82\x00\xb5\x8e\x4c\x92\x6c\xcf\x0a\x7f\x2d\x92\xc4\xaa\x02\xf5\xf8\x30\x86\xc5\x31\x2c\x8e\x8b\xde\xa1\xc1\xc3\xfb\xd1\x09\x06\x8f\x0f\x05\xb7\xcd\x6d\xbc\x62\xad\x78\xd4\x29\x8a\xb2\xd3\x07\x3d\x3e\x51\x8c\xfa\x90\xe7\x26\x9e\xe7\x32\x8c\x27\x26\xc6\x13\x13\xf7\xe9\xec\x47\x9e\x99\x38\xf2\xd4\xc4\xd1\xe7\x26\x8e\x3f\x39\x71\xe4\xd9\x89\xd6\x43\x0e\xcf\x15\x32\x42\x60\x20\xf0\x6d\xe9\xed\xf7\xbb\xd1\x53\xf8\xef\xa9\xec\xec\x14\xf2\xdc\xba\xe0\xef\xe7\x2c\x7c\x48\x2c\x1c\xb7\x68\x46\x08\x7f\x4f\xdb\x1a\xa5\xbb\x7e\xb2\x15\xef\x06\xcc\x3d\x8c\x4a\x9d\x49\xe1\xa0\xa2\x6e\x41\xfd\x01\xaa\xb9\xa5\x3d\x3c\x70\x19\xb7\x15\xfa\x46\xfc\xf8\x98\xce\xff\x8c\x95\xdb\xbb\x4a\xde\x5e\x25\xf5\xa5\x34\x60\xe2\x3d\x65\x70\x45\xa9\x2e\x38\xd1\x4c\xee\xb5\x9d\x95\x73\xaf\xc6\xf4\xce\x38\x67\x72\x6d\xab\x9b\x83\x63\xae\x37\xe6\x7a\x23\x56\x8f\x09\xeb\x87\xa9\xb2\x9d\x44\x82\x7a\xb8\x48\x74\xbb\x60\x73\x64\xe9\x66\xb7\x7b\xd0\x52\xb3\x19\x91\x71\x44\xc6\x31\x8b\xbd\x45\x29\xe6\x44\x10\xe6\xd6\x15\x98\xe6\xea\x99\x99\x90\xd3\x31\xe5\x14\xd7\x01\x14\x06\x52\x85\xfa\x0c\x28\x46\x48\xa5\x76\x2d\x28\x08\x22\xae\x34\x7d\xd0\x4a\x4b\x55\xa0\x87\xac\xb6\x3c\xb5\x72\x9c\x3b\x31\xf6\x0a\x2e\x3d\xea\xbc\xf4\xc8\x04\x76\xc7\xfc\x0c\x34\x17\x01\xfa\x63\x8b\xc4\x93\xdd\x63\x1e\xe1\xf6\x7f\x17\x6e\xc7\xba\xd1\xe0\xba\x11\x17\x2b\x7f\x5c\x69\xb2\x13\xd8\x9f\xaf\x66\x01\x49\xd5\x35\x89\xee\x98\xb3\x3f\xc3\xeb\x00\xad\x86\x71\x20\x23\xd7\xb8\xe1\xd7\x28\xf6\xf8\xec\xd6\xf0\x11\x4b\x74\xb5\x34\xdf\x36\x81\x7b\x87\xb7\x0f\x89\xed\xf0\xe0\x76\x62\x07\xbe\xd8\x02\x8c\x40\x2a\x60\x11\xa1\xaa\xe8\x41\x3c\xc5\x33\xe0\x02\xce\x5f\x3c\x87\xc7\x8f\x1f\x7f\x05\xd6\xf0\x19\xdd\xaf\x6a\xf6\xe3\xf9\xae\xc0\xee\x0d\xcc\xec\xb0\x83\x14\x77\xf8\x77\x37\xc5\xd7\x18\x49\x85\x27\xa2\xf3\xce\x7e\x57\x93\x5d\x64\xbc\xb0\x4c\x8b\xd0\x97\x87\xc6\x49\x95\x77\x4c\x94\x4d\x2a\x02\xe0\x0a\xd6\x8e\x2c\x6f\xf4\x5f\x5e\xe4\xc3\x7d\xff\xeb\xcf\x93\x42\xd2\x9c\xe9\x2b\x17\x10\xcf\x31\x42\x85\x22\xc0\x3a\x77\x1f\x2d\xf3\xa6\x4c\x59\xd4\x23\x5e\x8d\xc4\x3c\xec\xbd\x7a\x60\x3f\x6f\xb8\x38\x4c\x14\x5b\x37\xee\x23\xb2\x11\xf3\x48\xd9\x06\x0d\x6c\xf1\x64\x9f\x88\x0b\xc2\x4d\x25\xa8\x5a\x18\x39\x4c\x45\x92\x58\x72\x88\xac\x34\xa0\x8a\x95\x5b\x49\x2b\x5f\xad\x4c\x95\xaf\x76\xf0\xca\x57\x37\x4a\xe5\x3b\x27\x4c\xb5\xfb\xee\xd0\xb5\xe0\xcb\x92\xe4\x55\xd4\x9f\xfb\x15\xa8\xdc\x30\x81\xdd\x39\x9c\x96\x89\x6e\x9f\x6a\x80\x40\x86\x38\xc8\xf6\x15\xb2\xbd\x60\xd2\x41\x5a\x06\xd9\x4b\x1e\x1e\xe8\xe0\x54\xaf\xda\xc8\x11\xea\x57\x93\xb1\xa3\x74\x76\x33\xdf\x26\x98\x4b\x3d\x6b\xed\x2d\xa4\x83\x23\x65\xfd\x0a\xc1\x03\x3d\x5f\x07\xba\x43\x1e\x5a\xed\xba\xce\xa0\x1e\xc5\x7b\x80\x5a\x68\x9b\x1e\x06\x05\x76\x5f\x32\xba\x73\x74\x81\x62\x3f\xfa\x7e\x98\xe5\xbb\x28\xf7\xc3\x2c\x45\x62\xb6\x14\xd0\xc6\xaa\xf1\xbc\x00\x52\x26\x78\x84\x9a\xee\x62\x8b\x1d\xac\xbd\x52\x97\xd2\xc7\xd5\x63\x84\xb9\xb4\x87\xf2\xf9\xe6\x3d\xc8\xa4\x89\x91\xd1\x07\x84\xd9\x7f\x25\xd0\xa7\x82\x0c\x6d\x37\x8a\x8a\xad\xbc\x56\x1d\x6f\x09\x0f\x9d\x2a\x77\x29\xdd\x06\x12\x3d\xe6\x9f\xb0\x35\x26\x43\x9f\xb9\xfd\xb0\x30\xe4\xd6\x0c\x59\xf2\xba\x63\xfc\xde\xf1\xba\x90\xa3\xa7\x4b\xbf\x8f\x76\xe3\xc7\x2d\x58\x56\x8f\x62\xde\xea\x29\xd6\xb7\x14\x8e\x7e\x74\x3d\x0e\xb8\x6f\xb3\x1d\xe4\xc7\x14\xdd\xda\x0a\x8c\x47\xe6\x77\xfb\x06\xd4\xa1\xf3\x61\xc3\xd9\x7b\x5c\x95\xbd\x9a\xfb\xcf\x88\xdb\x2d\xb1\x95\xb4\xcf\x02\xdd\x4a\xf3\x20\x87\x8d\x92\x26\x6b\x99\xa5\xe6\x53\xed\x84\xac\x06\x3f\x0f\x3e\x03\x04\x47\x61\xd2\x3a\xbf\x19\x28\xd4\xd4\x68\xda\xa8\x2c\x68\x34\xe5\xbb\x8f\x55\x45\x1b\xd1\xe7\x88\x21\xfd\x64\x37\x1a\xbd\xe3\x36\x1a\x7d\xac\x6b\x34\x66\x46\x55\x92\xf1\xe2\x9c\xfd\xa5\x13\xe0\x90\x40\x25\xf5\x00\x9b\x68\x49\x82\xda\x53\xa0\x8c\x6d\x13\xc9\xc2\xcb\x98\xe9\x78\x35\x19\x66\xc2\x9f\x2e\xa8\x54\x94\xb4\x72\x36\xb7\x94\x76\x13\xef\x42\xd3\xee\x7c\x15\x17\x2b\x77\x6f\x7c\xd2\x71\xb3\x8d\x87\xfe\x15\x87\x96\xf1\xa4\xe7\x30\x46\x73\xd1\xbf\xf7\x14\xfc\x5d\x85\xba\x14\xbe\xad\x22\x49\x75\x87\xa3\xeb\x26\x58\x5e\x69\x43\x9d\x5f\x9c\x77\x88\x09\x4c\x84\xf9\x3e\x87\xde\xbb\x83\x6e\x12\x72\x05\x16\x69\xa8\xb8\x03\xed\x6e\x86\xd1\xee\x36\xbc\xbb\x3d\x66\xd5\xa9\xdd\xd7\x87\xf5\xb6\xed\x76\x7e\xee\x4a\x6e\x4c\xef\x40\x85\x18\x7a\xde\x3b\x1d\x6b\x29\x13\x64\x62\xb2\x4b\x2a\x23\x66\x12\xaa\x6f\xa1\xb4\x6d\xac\x54\x17\xe7\x7e\xea\x2a\x4b\xe3\xde\x89\x7b\xcd\x36\x08\xc2\xa4\x6b\x54\xbb\x07\xe9\x4b\x14\x6f\x63\x14\xb5\x06\xbc\x09\x10\x43\x5d\xa9\x43\xd9\x51\xaa\xcb\xee\x76\xb5\x9a\x9e\x59\xaa\xf5\xa8\x6c\x4a\xb9\xe0\xa9\x49\x77\x4d\x6d\x5a\x56\x37\x17\x8a\x4d\xbd\x77\x83\xb4\xfc\x91\xdd\x58\xf6\x7b\x8a\x6a\x20\x99\x9b\xc5\x2d\x35\x58\x2e\xcb\xc6\x52\x87\x65\x9f\x0e\xee\xc0\x7d\x43\x0b\xd7\xd6\xa1\x47\x1b\x93\x6e\xe3\xbf\xc8\x1f\x8d\x37\x72\xcf\x18\x02\xc5\x09\x15\x67\xfe\x15\x0d\x7a\x2b\x88\xdd\xf8\xbd\x58\xae\x6b\xb7\x51\x2b\xdb\x38\x29\x4f\x98\x02\x92\x40\x8d\x2e\x08\x97\x6f\x63\x54\x78\x09\x41\xc2\x8c\xf6\x2f\xc3\x10\x70\xf1\xd7\x1f\xdc\x7a\x03\x53\x14\x15\xbf\x31\xba\x38\xf1\x6a\x55\x2d\x77\x80\xed\x36\x39\x30\x22\xc5\xd7\x86\x50\xc3\x02\x02\x99\x98\x54\xd4\xa9\x58\x10\x48\x23\x68\x0e\x25\xbb\x17\x52\x01\xde\xb0\x34\x4b\xfc\xf6\xa3\xbb\x8d\x90\x3f\x43\xc5\xf1\x1a\x2d\x6a\x57\xfb\x6a\xe7\xd8\xc0\xc0\x68\x54\x96\xf9\xa4\xb2\x38\x52\xce\xcd\x1d\xc1\x55\xba\xbd\x5a\x4d\xca\x1f\xaf\xae\xae\xf4\xef\x49\x45\x0b\xdf\x19\x12\xfe\x06\x61\x9a\x6e\xff\x30\xad\x92\x4e\x6a\x6f\xe3\x68\x4c\x3a\x04\x4c\x00\x4b\xb4\x84\x35\xfa\x52\x01\x86\x20\x05\x28\x4c\x6a\xef\x9e\x98\xdf\x42\x49\x6d\xd6\xa5\x19\x68\x9f\xe6\x79\x50\xba\x8a\xa4\x7c\xb2\x66\xea\xea\xac\x53\xa7\x6a\xdf\x4b\xd7\x55\xcf\xdf\xe0\x16\x9e\xc0\x34\x92\x72\xea\xc0\xab\x8d\xe6\x9a\x25\x06\x2d\xd5\x9a\xa9\x8e\x59\x78\x19\xe5\xaf\x1b\xd9\x59\x96\x98\x12\x64\x4a\x5e\xf3\xd0\xde\xbd\x95\x0a\xb8\xa7\xf1\xdc\xb8\x06\x4c\x33\xda\xba\x22\xe8\x0e\xfe\xf6\x9e\x25\xc5\x8c\x5c\x8b\x7d\x20\x10\x33\x0d\x19\xaa\x94\x6b\xcd\xa5\x00\x92\xa0\xd1\x5e\x5a\x4c\x12\x58\x57\x33\x11\xff\x42\x91\xf9\xd0\x40\x94\xdf\x70\xa9\xbb\x68\xde\xf8\x1e\x7c\xd4\x71\xb6\xcf\xec\xbe\xbd\xb4\x60\x3c\xcc\x51\xd7\x86\x8e\x76\xd6\x86\x9b\x1e\x69\xc0\xe5\x53\x75\x3f\x7b\xbb\x2d\x1c\x6d\x80\x2b\x32\x1d\xb4\x5b\xdf\x2b\x75\xbb\x31\xe1\x92\x89\xf0\xd2\x97\xdd\x61\xb8\x10\x67\xbe\xc7\x4f\xbd\x32\xdd\x97\x47\x08\x09\x78\x63\xab\x8d\x9c\xbc\x0a\x1e\xc0\x92\xa4\x02\x2e\x83\x0d\xdd\x5f\xcc\xaa\xdb\xb9\x6f\xbb\x1f\x33\x37\x4e\x1e\x0d\x0c\x02\x99\xa6\x6c\xa6\xd1\xea\x4f\x18\x96\x27\x20\xfc\x68\x40\x0e\x1b\x9b\x8e\x0a\xf0\xc2\xff\x2c\x23\x0b\x44\x33\x4d\xca\x04\x64\x94\xe5\x28\x5c\xd6\xe9\x52\x63\x6d\x9f\x06\x7c\x5d\xfe\xfa\xcd\xfc\x6b\xc7\xf6\x1b\x10\x92\xdc\xa6\xf8\x8e\xe1\xd7\x9a\x0a\xa2\x2f\xf2\x17\x19\x59\xab\x70\xf4\x8e\x21\x94\x6c\xca\x3e\xdf\x79\x43\x5e\x79\xab\x66\x41\x0c\x17\x15\x54\x04\x92\xb0\x41\x02\x1e\x9e\xb9\xd2\xcc\x19\x64\x09\x13\x9f\xe5\x6f\x64\xb2\xe5\x8a\xcf\xdd\x7f\x1e\x3c\xe1\xb3\x72\x38\xfd\x79\xcd\xba\xca\xff\x65\x90\x3a\x86\x75\x68\x9f\xcd\x76\xa6\xe3\xbb\x3f\xe1\xe1\x99\x1b\xd0\x8e\x37\xe7\xa1\xff\x6b\x07\x3c\xcb\x81\xfa\x8b\x7a\x2f\xa4\x20\xfe\xc1\xfd\xf2\xa4\x76\x46\x7a\x37\x78\xaf\xc1\xfc\x77\x00\x76\xcb\x30\x12\xdb\x5e\x00\x00")
 
 func openapiYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -92,7 +92,7 @@ func openapiYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "openapi.yaml", size: 16304, mode: os.FileMode(493), modTime: time.Unix(1765460770, 0)}
+	info := bindataFileInfo{name: "openapi.yaml", size: 24283, mode: os.FileMode(493), modTime: time.Unix(1792374971, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
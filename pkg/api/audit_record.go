@@ -0,0 +1,43 @@
+package api
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+	// AuditActionPurge is the hard delete of a resource after its deletion is confirmed by the agent.
+	AuditActionPurge AuditAction = "purge"
+)
+
+// AuditRecord records a mutation of a resource bundle or a consumer.
+// The records are immutable, so they do not embed the Meta.
+type AuditRecord struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Actor and Groups are the identity that makes the mutation.
+	Actor  string         `json:"actor"`
+	Groups pq.StringArray `json:"groups,omitempty" gorm:"type:text[]"`
+	// Source is the API that the mutation comes from, it is rest, grpc or maestro.
+	Source       string      `json:"source"`
+	Action       AuditAction `json:"action"`
+	ResourceType string      `json:"resource_type"`
+	ResourceID   string      `json:"resource_id"`
+	Version      int32       `json:"version,omitempty"`
+	// PayloadHash is the sha256 of the resource spec (or consumer) after the mutation.
+	PayloadHash string `json:"payload_hash,omitempty"`
+}
+
+type AuditRecordList []*AuditRecord
+
+func (r *AuditRecord) BeforeCreate(tx *gorm.DB) error {
+	r.ID = NewID()
+	return nil
+}
@@ -30,7 +30,10 @@ func (l ConsumerList) Index() ConsumerIndex {
 }
 
 func (d *Consumer) BeforeCreate(tx *gorm.DB) error {
-	d.ID = NewID()
+	// generate a new ID if it doesn't exist
+	if d.ID == "" {
+		d.ID = NewID()
+	}
 
 	if d.Name == "" {
 		d.Name = d.ID
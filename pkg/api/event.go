@@ -20,6 +20,10 @@ type Event struct {
 	SourceID       string     // primary key of MyTable
 	EventType      EventType  // Add|Update|Delete
 	ReconciledDate *time.Time `json:"gorm:null"`
+	// TraceParent and TraceState are the W3C trace context of the request that created the event,
+	// they are used to resume the trace when the event is processed.
+	TraceParent string
+	TraceState  string
 }
 
 type EventList []*Event
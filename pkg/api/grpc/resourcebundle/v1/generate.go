@@ -0,0 +1,5 @@
+// Package v1 contains the gRPC API to get, list and watch the resource bundles on the Maestro gRPC server.
+package v1
+
+// After making changes to the resourcebundle.proto, run "go generate" in this directory to update the generated code.
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative resourcebundle.proto
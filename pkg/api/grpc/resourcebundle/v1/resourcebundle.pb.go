@@ -0,0 +1,530 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: resourcebundle.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchEvent_Type int32
+
+const (
+	// The resource bundle status is changed.
+	WatchEvent_MODIFIED WatchEvent_Type = 0
+	// The resource bundle is deleted by the agent.
+	WatchEvent_DELETED WatchEvent_Type = 1
+)
+
+// Enum value maps for WatchEvent_Type.
+var (
+	WatchEvent_Type_name = map[int32]string{
+		0: "MODIFIED",
+		1: "DELETED",
+	}
+	WatchEvent_Type_value = map[string]int32{
+		"MODIFIED": 0,
+		"DELETED":  1,
+	}
+)
+
+func (x WatchEvent_Type) Enum() *WatchEvent_Type {
+	p := new(WatchEvent_Type)
+	*p = x
+	return p
+}
+
+func (x WatchEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_resourcebundle_proto_enumTypes[0].Descriptor()
+}
+
+func (WatchEvent_Type) Type() protoreflect.EnumType {
+	return &file_resourcebundle_proto_enumTypes[0]
+}
+
+func (x WatchEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchEvent_Type.Descriptor instead.
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_resourcebundle_proto_rawDescGZIP(), []int{5, 0}
+}
+
+// ResourceBundle is a resource bundle in the same JSON representation as the REST API.
+type ResourceBundle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the resource bundle.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The consumer name of the resource bundle.
+	ConsumerName string `protobuf:"bytes,2,opt,name=consumer_name,json=consumerName,proto3" json:"consumer_name,omitempty"`
+	// The version of the resource bundle.
+	Version int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// The resource bundle in JSON, it has the same representation as the REST API.
+	Data          []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceBundle) Reset() {
+	*x = ResourceBundle{}
+	mi := &file_resourcebundle_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceBundle) ProtoMessage() {}
+
+func (x *ResourceBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcebundle_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceBundle.ProtoReflect.Descriptor instead.
+func (*ResourceBundle) Descriptor() ([]byte, []int) {
+	return file_resourcebundle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResourceBundle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResourceBundle) GetConsumerName() string {
+	if x != nil {
+		return x.ConsumerName
+	}
+	return ""
+}
+
+func (x *ResourceBundle) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ResourceBundle) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the resource bundle.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_resourcebundle_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcebundle_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_resourcebundle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The source of the resource bundles.
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// The search in the same syntax as the REST API, it is combined with the source.
+	Search string `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	// The maximum number of resource bundles in the response, the server default is used if it is 0.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// The continue token from the previous response to get the next page.
+	Continue      string `protobuf:"bytes,4,opt,name=continue,proto3" json:"continue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_resourcebundle_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcebundle_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_resourcebundle_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetContinue() string {
+	if x != nil {
+		return x.Continue
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*ResourceBundle      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// The continue token to get the next page, it is empty if there are no more resource bundles.
+	Continue string `protobuf:"bytes,2,opt,name=continue,proto3" json:"continue,omitempty"`
+	// The total number of the resource bundles that match the request.
+	Total         int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_resourcebundle_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcebundle_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_resourcebundle_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListResponse) GetItems() []*ResourceBundle {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListResponse) GetContinue() string {
+	if x != nil {
+		return x.Continue
+	}
+	return ""
+}
+
+func (x *ListResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The source of the resource bundles.
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// Watch the resource bundles of the given consumer only, all consumers if it is empty.
+	ConsumerName  string `protobuf:"bytes,2,opt,name=consumer_name,json=consumerName,proto3" json:"consumer_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_resourcebundle_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcebundle_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_resourcebundle_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetConsumerName() string {
+	if x != nil {
+		return x.ConsumerName
+	}
+	return ""
+}
+
+type WatchEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           WatchEvent_Type        `protobuf:"varint,1,opt,name=type,proto3,enum=io.openshift.maestro.resourcebundle.v1.WatchEvent_Type" json:"type,omitempty"`
+	ResourceBundle *ResourceBundle        `protobuf:"bytes,2,opt,name=resource_bundle,json=resourceBundle,proto3" json:"resource_bundle,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_resourcebundle_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_resourcebundle_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_resourcebundle_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchEvent) GetType() WatchEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WatchEvent_MODIFIED
+}
+
+func (x *WatchEvent) GetResourceBundle() *ResourceBundle {
+	if x != nil {
+		return x.ResourceBundle
+	}
+	return nil
+}
+
+var File_resourcebundle_proto protoreflect.FileDescriptor
+
+const file_resourcebundle_proto_rawDesc = "" +
+	"\n" +
+	"\x14resourcebundle.proto\x12&io.openshift.maestro.resourcebundle.v1\"s\n" +
+	"\x0eResourceBundle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rconsumer_name\x18\x02 \x01(\tR\fconsumerName\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\"\x1c\n" +
+	"\n" +
+	"GetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
+	"\vListRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06search\x18\x02 \x01(\tR\x06search\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1a\n" +
+	"\bcontinue\x18\x04 \x01(\tR\bcontinue\"\x8e\x01\n" +
+	"\fListResponse\x12L\n" +
+	"\x05items\x18\x01 \x03(\v26.io.openshift.maestro.resourcebundle.v1.ResourceBundleR\x05items\x12\x1a\n" +
+	"\bcontinue\x18\x02 \x01(\tR\bcontinue\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"K\n" +
+	"\fWatchRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12#\n" +
+	"\rconsumer_name\x18\x02 \x01(\tR\fconsumerName\"\xdd\x01\n" +
+	"\n" +
+	"WatchEvent\x12K\n" +
+	"\x04type\x18\x01 \x01(\x0e27.io.openshift.maestro.resourcebundle.v1.WatchEvent.TypeR\x04type\x12_\n" +
+	"\x0fresource_bundle\x18\x02 \x01(\v26.io.openshift.maestro.resourcebundle.v1.ResourceBundleR\x0eresourceBundle\"!\n" +
+	"\x04Type\x12\f\n" +
+	"\bMODIFIED\x10\x00\x12\v\n" +
+	"\aDELETED\x10\x012\xf8\x02\n" +
+	"\x15ResourceBundleService\x12s\n" +
+	"\x03Get\x122.io.openshift.maestro.resourcebundle.v1.GetRequest\x1a6.io.openshift.maestro.resourcebundle.v1.ResourceBundle\"\x00\x12s\n" +
+	"\x04List\x123.io.openshift.maestro.resourcebundle.v1.ListRequest\x1a4.io.openshift.maestro.resourcebundle.v1.ListResponse\"\x00\x12u\n" +
+	"\x05Watch\x124.io.openshift.maestro.resourcebundle.v1.WatchRequest\x1a2.io.openshift.maestro.resourcebundle.v1.WatchEvent\"\x000\x01BDZBgithub.com/openshift-online/maestro/pkg/api/grpc/resourcebundle/v1b\x06proto3"
+
+var (
+	file_resourcebundle_proto_rawDescOnce sync.Once
+	file_resourcebundle_proto_rawDescData []byte
+)
+
+func file_resourcebundle_proto_rawDescGZIP() []byte {
+	file_resourcebundle_proto_rawDescOnce.Do(func() {
+		file_resourcebundle_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_resourcebundle_proto_rawDesc), len(file_resourcebundle_proto_rawDesc)))
+	})
+	return file_resourcebundle_proto_rawDescData
+}
+
+var file_resourcebundle_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_resourcebundle_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_resourcebundle_proto_goTypes = []any{
+	(WatchEvent_Type)(0),   // 0: io.openshift.maestro.resourcebundle.v1.WatchEvent.Type
+	(*ResourceBundle)(nil), // 1: io.openshift.maestro.resourcebundle.v1.ResourceBundle
+	(*GetRequest)(nil),     // 2: io.openshift.maestro.resourcebundle.v1.GetRequest
+	(*ListRequest)(nil),    // 3: io.openshift.maestro.resourcebundle.v1.ListRequest
+	(*ListResponse)(nil),   // 4: io.openshift.maestro.resourcebundle.v1.ListResponse
+	(*WatchRequest)(nil),   // 5: io.openshift.maestro.resourcebundle.v1.WatchRequest
+	(*WatchEvent)(nil),     // 6: io.openshift.maestro.resourcebundle.v1.WatchEvent
+}
+var file_resourcebundle_proto_depIdxs = []int32{
+	1, // 0: io.openshift.maestro.resourcebundle.v1.ListResponse.items:type_name -> io.openshift.maestro.resourcebundle.v1.ResourceBundle
+	0, // 1: io.openshift.maestro.resourcebundle.v1.WatchEvent.type:type_name -> io.openshift.maestro.resourcebundle.v1.WatchEvent.Type
+	1, // 2: io.openshift.maestro.resourcebundle.v1.WatchEvent.resource_bundle:type_name -> io.openshift.maestro.resourcebundle.v1.ResourceBundle
+	2, // 3: io.openshift.maestro.resourcebundle.v1.ResourceBundleService.Get:input_type -> io.openshift.maestro.resourcebundle.v1.GetRequest
+	3, // 4: io.openshift.maestro.resourcebundle.v1.ResourceBundleService.List:input_type -> io.openshift.maestro.resourcebundle.v1.ListRequest
+	5, // 5: io.openshift.maestro.resourcebundle.v1.ResourceBundleService.Watch:input_type -> io.openshift.maestro.resourcebundle.v1.WatchRequest
+	1, // 6: io.openshift.maestro.resourcebundle.v1.ResourceBundleService.Get:output_type -> io.openshift.maestro.resourcebundle.v1.ResourceBundle
+	4, // 7: io.openshift.maestro.resourcebundle.v1.ResourceBundleService.List:output_type -> io.openshift.maestro.resourcebundle.v1.ListResponse
+	6, // 8: io.openshift.maestro.resourcebundle.v1.ResourceBundleService.Watch:output_type -> io.openshift.maestro.resourcebundle.v1.WatchEvent
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_resourcebundle_proto_init() }
+func file_resourcebundle_proto_init() {
+	if File_resourcebundle_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_resourcebundle_proto_rawDesc), len(file_resourcebundle_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_resourcebundle_proto_goTypes,
+		DependencyIndexes: file_resourcebundle_proto_depIdxs,
+		EnumInfos:         file_resourcebundle_proto_enumTypes,
+		MessageInfos:      file_resourcebundle_proto_msgTypes,
+	}.Build()
+	File_resourcebundle_proto = out.File
+	file_resourcebundle_proto_goTypes = nil
+	file_resourcebundle_proto_depIdxs = nil
+}
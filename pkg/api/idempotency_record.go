@@ -0,0 +1,20 @@
+package api
+
+import (
+	"time"
+)
+
+// IdempotencyRecord stores the result of a request with an idempotency key, the result is replayed
+// when the request is retried with the same key until the record expires.
+type IdempotencyRecord struct {
+	// Key is the idempotency key scoped by the API and the client, e.g. grpc:<source>:<key>.
+	Key string `gorm:"primaryKey"`
+	// RequestHash is the hash of the request, a retry must send the same request.
+	RequestHash string
+	// Completed is false while the first request is in progress.
+	Completed bool
+	// Result is the response of the first request.
+	Result    []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
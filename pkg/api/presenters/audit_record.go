@@ -0,0 +1,24 @@
+package presenters
+
+import (
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+// AuditRecordList is the REST representation of a page of the audit records.
+type AuditRecordList struct {
+	Kind  string             `json:"kind"`
+	Page  int32              `json:"page"`
+	Size  int32              `json:"size"`
+	Total int32              `json:"total"`
+	Items []*api.AuditRecord `json:"items"`
+}
+
+func PresentAuditRecordList(records api.AuditRecordList, page int, total int64) AuditRecordList {
+	return AuditRecordList{
+		Kind:  *ObjectKind(records),
+		Page:  int32(page),
+		Size:  int32(len(records)),
+		Total: int32(total),
+		Items: records,
+	}
+}
@@ -17,6 +17,8 @@ func ObjectKind(i interface{}) *string {
 		result = "ResourceBundle"
 	case api.ResourceList, *api.ResourceList, []api.Resource, []*api.Resource:
 		result = "ResourceBundleList"
+	case api.AuditRecordList, *api.AuditRecordList, []api.AuditRecord, []*api.AuditRecord:
+		result = "AuditRecordList"
 	case errors.ServiceError, *errors.ServiceError:
 		result = "Error"
 	}
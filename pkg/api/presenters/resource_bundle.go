@@ -1,10 +1,75 @@
 package presenters
 
 import (
+	"encoding/json"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/constants"
+	"github.com/openshift-online/maestro/pkg/util"
 )
 
+// ConvertResourceBundle converts a resource bundle from the openapi representation to a resource, the
+// resource payload is the CloudEvent of the given request action, as if the bundle was published by gRPC.
+func ConvertResourceBundle(bundle openapi.ResourceBundle, action cetypes.EventAction) (*api.Resource, error) {
+	data := map[string]interface{}{
+		"manifests": bundle.Manifests,
+	}
+	if len(bundle.ManifestConfigs) > 0 {
+		data["manifestConfigs"] = bundle.ManifestConfigs
+	}
+	if bundle.DeleteOption != nil {
+		data["deleteOption"] = bundle.DeleteOption
+	}
+
+	resource := &api.Resource{
+		Meta: api.Meta{
+			ID: util.NilToEmptyString(bundle.Id),
+		},
+		Name:         util.NilToEmptyString(bundle.Name),
+		Source:       constants.DefaultSourceID,
+		ConsumerName: util.NilToEmptyString(bundle.ConsumerName),
+	}
+	if bundle.Version != nil {
+		resource.Version = *bundle.Version
+	}
+
+	evt := cloudevents.NewEvent()
+	evt.SetID(uuid.New().String())
+	evt.SetSource(resource.Source)
+	evt.SetType(cetypes.CloudEventsType{
+		CloudEventsDataType: workpayload.ManifestBundleEventDataType,
+		SubResource:         cetypes.SubResourceSpec,
+		Action:              action,
+	}.String())
+	evt.SetExtension(cetypes.ExtensionResourceID, resource.ID)
+	evt.SetExtension(cetypes.ExtensionResourceVersion, resource.Version)
+	evt.SetExtension(cetypes.ExtensionClusterName, resource.ConsumerName)
+	if bundle.Metadata != nil {
+		metadata, err := json.Marshal(bundle.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata: %v", err)
+		}
+		evt.SetExtension(cetypes.ExtensionWorkMeta, string(metadata))
+	}
+	if err := evt.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return nil, fmt.Errorf("failed to set cloudevent data: %v", err)
+	}
+
+	payload, err := api.CloudEventToJSONMap(&evt)
+	if err != nil {
+		return nil, err
+	}
+	resource.Payload = payload
+	return resource, nil
+}
+
 // PresentResourceBundle converts a resource from the API to the openapi representation.
 func PresentResourceBundle(resource *api.Resource) (*openapi.ResourceBundle, error) {
 	manifestWrapper, err := api.DecodeManifestBundle(resource.Payload)
@@ -23,6 +23,13 @@ type StatusEvent struct {
 	Status          datatypes.JSONMap
 	StatusEventType StatusEventType // Update|Delete
 	ReconciledDate  *time.Time      `json:"gorm:null"`
+	// TraceParent and TraceState are the W3C trace context of the status update that created the event,
+	// they are used to resume the trace when the event is broadcast to the subscribers.
+	TraceParent string
+	TraceState  string
+	// SequenceNumber is assigned by the database when the event is created, it orders the status events for
+	// the subscribers that resume their subscriptions.
+	SequenceNumber int64 `gorm:"->"`
 }
 
 type StatusEventList []*StatusEvent
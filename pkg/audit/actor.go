@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// The sources of the mutations recorded in the audit log.
+const (
+	SourceREST    = "rest"
+	SourceGRPC    = "grpc"
+	SourceMaestro = "maestro"
+)
+
+const (
+	// AnonymousUser is the actor of the requests that do not carry an identity.
+	AnonymousUser = "system:anonymous"
+	// MaestroUser is the actor of the mutations made by maestro itself, e.g. the hard delete of a
+	// resource after its deletion is confirmed by the agent.
+	MaestroUser = "system:maestro"
+)
+
+// The headers that carry the identity of a REST request, they are set by the authenticating proxy
+// in front of the maestro REST API. Any client can set them, so they are only honoured if the proxy
+// is trusted, see ActorMiddleware.
+const (
+	userHeader   = "X-Forwarded-User"
+	groupsHeader = "X-Forwarded-Groups"
+)
+
+// Actor is the identity that makes a mutation.
+type Actor struct {
+	User   string
+	Groups []string
+	// Source is the API that the mutation comes from, it is rest, grpc or maestro.
+	Source string
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of the given context that carries the actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor in the given context, maestro itself is returned as the actor
+// if the context does not carry one.
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return Actor{User: MaestroUser, Source: SourceMaestro}
+}
+
+// ActorMiddleware returns a middleware that sets the authenticated identity of the REST request to the
+// request context as the actor. The identity is, in order:
+//   - the actor that an authenticating middleware in front of it has set to the request context
+//   - the X-Forwarded-User and X-Forwarded-Groups headers, only if trustForwardedHeaders is set, i.e. the
+//     REST API is only reachable through an authenticating proxy that sets them
+//   - the common name and organizations of the verified TLS client certificate
+//
+// The request is anonymous otherwise.
+func ActorMiddleware(trustForwardedHeaders bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor, ok := r.Context().Value(actorKey{}).(Actor)
+			if !ok {
+				actor = restActor(r, trustForwardedHeaders)
+			}
+			next.ServeHTTP(w, r.WithContext(WithActor(r.Context(), actor)))
+		})
+	}
+}
+
+func restActor(r *http.Request, trustForwardedHeaders bool) Actor {
+	actor := Actor{User: AnonymousUser, Source: SourceREST}
+	if trustForwardedHeaders {
+		if user := strings.TrimSpace(r.Header.Get(userHeader)); user != "" {
+			actor.User = user
+			for _, group := range strings.Split(r.Header.Get(groupsHeader), ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					actor.Groups = append(actor.Groups, group)
+				}
+			}
+			return actor
+		}
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		if cert := r.TLS.VerifiedChains[0][0]; cert.Subject.CommonName != "" {
+			actor.User = cert.Subject.CommonName
+			actor.Groups = cert.Subject.Organization
+		}
+	}
+	return actor
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestActorMiddleware(t *testing.T) {
+	clientCert := &x509.Certificate{Subject: pkix.Name{CommonName: "alice", Organization: []string{"admins"}}}
+
+	tests := []struct {
+		name                  string
+		trustForwardedHeaders bool
+		ctxActor              *Actor
+		headers               map[string]string
+		tls                   *tls.ConnectionState
+		want                  Actor
+	}{
+		{
+			name: "anonymous",
+			want: Actor{User: AnonymousUser, Source: SourceREST},
+		},
+		{
+			name:    "forwarded headers are ignored without a trusted proxy",
+			headers: map[string]string{userHeader: "mallory", groupsHeader: "admins"},
+			want:    Actor{User: AnonymousUser, Source: SourceREST},
+		},
+		{
+			name:                  "forwarded headers of a trusted proxy",
+			trustForwardedHeaders: true,
+			headers:               map[string]string{userHeader: " bob ", groupsHeader: "dev, ops,"},
+			want:                  Actor{User: "bob", Groups: []string{"dev", "ops"}, Source: SourceREST},
+		},
+		{
+			name: "verified client certificate",
+			tls:  &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{clientCert}}},
+			want: Actor{User: "alice", Groups: []string{"admins"}, Source: SourceREST},
+		},
+		{
+			name:    "unverified client certificate",
+			headers: map[string]string{userHeader: "mallory"},
+			tls:     &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}},
+			want:    Actor{User: AnonymousUser, Source: SourceREST},
+		},
+		{
+			name:                  "identity of an authenticating middleware",
+			trustForwardedHeaders: true,
+			ctxActor:              &Actor{User: "carol", Source: SourceREST},
+			headers:               map[string]string{userHeader: "mallory"},
+			want:                  Actor{User: "carol", Source: SourceREST},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Actor
+			handler := ActorMiddleware(tt.trustForwardedHeaders)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ActorFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/api/maestro/v1/consumers", nil)
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+			r.TLS = tt.tls
+			if tt.ctxActor != nil {
+				r = r.WithContext(WithActor(context.Background(), *tt.ctxActor))
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActorMiddleware() actor = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+// Sink writes the audit records to a destination other than the database.
+type Sink interface {
+	Write(record *api.AuditRecord) error
+}
+
+var _ Sink = &jsonSink{}
+
+// jsonSink writes each audit record as a JSON line.
+type jsonSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONSink returns a sink that writes the audit records as JSON lines to the given writer.
+func NewJSONSink(w io.Writer) Sink {
+	return &jsonSink{encoder: json.NewEncoder(w)}
+}
+
+// NewFileSink returns a JSON sink that appends the audit records to the given file, the records
+// are written to the stdout if the file is "-".
+func NewFileSink(path string) (Sink, error) {
+	if path == "-" {
+		return NewJSONSink(os.Stdout), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file %s: %v", path, err)
+	}
+	return NewJSONSink(file), nil
+}
+
+func (s *jsonSink) Write(record *api.AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.encoder.Encode(record)
+}
+
+// PayloadHash returns the hex encoded sha256 of the JSON encoding of the given payload, an empty
+// string is returned if there is no payload.
+func PayloadHash(payload interface{}) (string, error) {
+	if payload == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
@@ -16,6 +16,9 @@ import (
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 )
 
+// NewMaestroGRPCSourceWorkClient creates a ManifestWork client for the given source. The works are got and listed
+// with the RESTful APIs of the given apiClient, if the apiClient is nil, they are got and listed with the
+// ResourceBundleService of the maestro gRPC server instead, so the client only requires the gRPC connection.
 func NewMaestroGRPCSourceWorkClient(
 	ctx context.Context,
 	logger logging.Logger,
@@ -27,7 +30,15 @@ func NewMaestroGRPCSourceWorkClient(
 		return nil, fmt.Errorf("source id is required")
 	}
 
-	watcherStore := newRESTFulAPIWatcherStore(ctx, logger, apiClient, sourceID)
+	var lister resourceBundleLister = &restResourceBundleLister{apiClient: apiClient}
+	if apiClient == nil {
+		if opts == nil || opts.Dialer == nil {
+			return nil, fmt.Errorf("the gRPC options are required")
+		}
+		lister = &grpcResourceBundleLister{sourceID: sourceID, dialer: opts.Dialer}
+	}
+
+	watcherStore := newRESTFulAPIWatcherStore(ctx, logger, lister, sourceID)
 
 	cloudEventsClient, err := ceclients.NewCloudEventSourceClient(
 		ctx,
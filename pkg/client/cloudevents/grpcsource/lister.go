@@ -0,0 +1,146 @@
+package grpcsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc"
+
+	rbv1 "github.com/openshift-online/maestro/pkg/api/grpc/resourcebundle/v1"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	maestrologger "github.com/openshift-online/maestro/pkg/logger"
+)
+
+// resourceBundleLister gets and lists the resource bundles from the maestro server.
+type resourceBundleLister interface {
+	// Get returns the resource bundle with the given id, it returns false if the resource bundle is not found.
+	Get(ctx context.Context, id string) (*openapi.ResourceBundle, bool, error)
+	// ListPage returns one page of the resource bundles with the given search.
+	ListPage(ctx context.Context, search string, page, size int32) (*openapi.ResourceBundleList, error)
+}
+
+// restResourceBundleLister gets and lists the resource bundles with the maestro RESTful APIs.
+type restResourceBundleLister struct {
+	apiClient *openapi.APIClient
+}
+
+var _ resourceBundleLister = &restResourceBundleLister{}
+
+func (l *restResourceBundleLister) Get(ctx context.Context, id string) (*openapi.ResourceBundle, bool, error) {
+	req := l.apiClient.DefaultAPI.ApiMaestroV1ResourceBundlesIdGet(ctx, id)
+	if operationID := maestrologger.GetOperationID(ctx); operationID != "" {
+		req = req.XOperationID(operationID)
+	}
+
+	rb, resp, err := req.Execute()
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return rb, true, nil
+}
+
+func (l *restResourceBundleLister) ListPage(ctx context.Context, search string, page, size int32) (*openapi.ResourceBundleList, error) {
+	req := l.apiClient.DefaultAPI.ApiMaestroV1ResourceBundlesGet(ctx).
+		Search(search).
+		Page(page).
+		Size(size)
+
+	if operationID := maestrologger.GetOperationID(ctx); operationID != "" {
+		req = req.XOperationID(operationID)
+	}
+
+	rbs, _, err := req.Execute()
+	return rbs, err
+}
+
+// grpcResourceBundleLister gets and lists the resource bundles with the ResourceBundleService of the maestro
+// gRPC server, so that a source client does not require the RESTful APIs.
+type grpcResourceBundleLister struct {
+	sourceID string
+	dialer   *grpc.GRPCDialer
+}
+
+var _ resourceBundleLister = &grpcResourceBundleLister{}
+
+// client returns a ResourceBundleService client, the dialer caches the connection and creates a new one after the
+// cloudevents client reconnects, so the connection is resolved for each request.
+func (l *grpcResourceBundleLister) client() (rbv1.ResourceBundleServiceClient, error) {
+	conn, err := l.dialer.Dial()
+	if err != nil {
+		return nil, err
+	}
+	return rbv1.NewResourceBundleServiceClient(conn), nil
+}
+
+func (l *grpcResourceBundleLister) Get(ctx context.Context, id string) (*openapi.ResourceBundle, bool, error) {
+	client, err := l.client()
+	if err != nil {
+		return nil, false, err
+	}
+
+	resp, err := client.Get(ctx, &rbv1.GetRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	rb, err := toOpenAPIResourceBundle(resp)
+	if err != nil {
+		return nil, false, err
+	}
+	return rb, true, nil
+}
+
+func (l *grpcResourceBundleLister) ListPage(ctx context.Context, search string, page, size int32) (*openapi.ResourceBundleList, error) {
+	client, err := l.client()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.List(ctx, &rbv1.ListRequest{
+		Source:   l.sourceID,
+		Search:   search,
+		Limit:    size,
+		Continue: strconv.Itoa(int(page)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rbs := &openapi.ResourceBundleList{
+		Kind:  "ResourceBundleList",
+		Page:  page,
+		Size:  int32(len(resp.Items)),
+		Total: int32(resp.Total),
+		Items: []openapi.ResourceBundle{},
+	}
+	for _, item := range resp.Items {
+		rb, err := toOpenAPIResourceBundle(item)
+		if err != nil {
+			return nil, err
+		}
+		rbs.Items = append(rbs.Items, *rb)
+	}
+	return rbs, nil
+}
+
+func toOpenAPIResourceBundle(bundle *rbv1.ResourceBundle) (*openapi.ResourceBundle, error) {
+	rb := &openapi.ResourceBundle{}
+	if err := json.Unmarshal(bundle.Data, rb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource bundle %s: %v", bundle.Id, err)
+	}
+	return rb, nil
+}
@@ -9,7 +9,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift-online/maestro/pkg/api/openapi"
-	maestrologger "github.com/openshift-online/maestro/pkg/logger"
 )
 
 // MaxListPageSize is the maximum size of one page, default is 400.
@@ -20,6 +19,10 @@ var MaxListPageSize int32 = 400
 
 // PageList assists client code in breaking large list queries into multiple smaller chunks of PageSize or smaller.
 func PageList(ctx context.Context, logger logging.Logger, client *openapi.APIClient, search string, opts metav1.ListOptions) (*openapi.ResourceBundleList, string, error) {
+	return pageList(ctx, logger, &restResourceBundleLister{apiClient: client}, search, opts)
+}
+
+func pageList(ctx context.Context, logger logging.Logger, lister resourceBundleLister, search string, opts metav1.ListOptions) (*openapi.ResourceBundleList, string, error) {
 	items := []openapi.ResourceBundle{}
 
 	page, err := page(opts)
@@ -27,8 +30,6 @@ func PageList(ctx context.Context, logger logging.Logger, client *openapi.APICli
 		return nil, "", err
 	}
 
-	operationID := maestrologger.GetOperationID(ctx)
-
 	limit := opts.Limit
 	if limit < 0 {
 		return nil, "", fmt.Errorf("limit cannot be less than 0")
@@ -40,16 +41,7 @@ func PageList(ctx context.Context, logger logging.Logger, client *openapi.APICli
 	offset := (page - 1) * pageSize
 	for {
 		logger.Debug(ctx, "list works with search=%s, page=%d, size=%d", search, page, pageSize)
-		req := client.DefaultAPI.ApiMaestroV1ResourceBundlesGet(ctx).
-			Search(search).
-			Page(page).
-			Size(pageSize)
-
-		if len(operationID) > 0 {
-			req = req.XOperationID(operationID)
-		}
-
-		rbs, _, err := req.Execute()
+		rbs, err := lister.ListPage(ctx, search, page, pageSize)
 		if err != nil {
 			return nil, "", err
 		}
@@ -3,7 +3,6 @@ package grpcsource
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -20,14 +19,11 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/common"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/store"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/utils"
-
-	"github.com/openshift-online/maestro/pkg/api/openapi"
-	maestrologger "github.com/openshift-online/maestro/pkg/logger"
 )
 
 // RESTFulAPIWatcherStore implements the WorkClientWatcherStore interface, it is
 // used to build a source work client. The work client uses this store to
-//   - get/list works from Maestro server via RESTfull APIs
+//   - get/list works from Maestro server via RESTfull APIs or the gRPC ResourceBundleService
 //   - receive the work status update and send the updated work to the watch channel
 type RESTFulAPIWatcherStore struct {
 	sync.RWMutex
@@ -35,8 +31,8 @@ type RESTFulAPIWatcherStore struct {
 	// the context for RESTful API request, it is passed with RESTful API client together
 	ctx context.Context
 
-	sourceID  string
-	apiClient *openapi.APIClient
+	sourceID string
+	lister   resourceBundleLister
 
 	watchers  map[string]*workWatcher
 	workQueue cache.Queue
@@ -47,13 +43,13 @@ type RESTFulAPIWatcherStore struct {
 var _ store.ClientWatcherStore[*workv1.ManifestWork] = &RESTFulAPIWatcherStore{}
 
 func newRESTFulAPIWatcherStore(ctx context.Context,
-	logger logging.Logger, apiClient *openapi.APIClient, sourceID string) *RESTFulAPIWatcherStore {
+	logger logging.Logger, lister resourceBundleLister, sourceID string) *RESTFulAPIWatcherStore {
 	s := &RESTFulAPIWatcherStore{
-		ctx:       ctx,
-		logger:    logger,
-		sourceID:  sourceID,
-		apiClient: apiClient,
-		watchers:  make(map[string]*workWatcher),
+		ctx:      ctx,
+		logger:   logger,
+		sourceID: sourceID,
+		lister:   lister,
+		watchers: make(map[string]*workWatcher),
 		workQueue: cache.NewFIFO(func(obj interface{}) (string, error) {
 			work, ok := obj.(*workv1.ManifestWork)
 			if !ok {
@@ -90,7 +86,7 @@ func (m *RESTFulAPIWatcherStore) GetWatcher(ctx context.Context, namespace strin
 	}
 
 	// for watch, we need list all works with the search condition from maestro server
-	rbs, _, err := PageList(ctx, m.logger, m.apiClient, strings.Join(searches, " and "), metav1.ListOptions{})
+	rbs, _, err := pageList(ctx, m.logger, m.lister, strings.Join(searches, " and "), metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -128,17 +124,8 @@ func (m *RESTFulAPIWatcherStore) HandleReceivedResource(ctx context.Context, wor
 func (m *RESTFulAPIWatcherStore) Get(ctx context.Context, namespace, name string) (*workv1.ManifestWork, bool, error) {
 	id := utils.UID(m.sourceID, common.ManifestWorkGR.String(), namespace, name)
 
-	req := m.apiClient.DefaultAPI.ApiMaestroV1ResourceBundlesIdGet(ctx, id)
-	if operationID := maestrologger.GetOperationID(ctx); operationID != "" {
-		req = req.XOperationID(operationID)
-	}
-
-	rb, resp, err := req.Execute()
-	if err != nil {
-		if resp != nil && resp.StatusCode == http.StatusNotFound {
-			return nil, false, nil
-		}
-
+	rb, found, err := m.lister.Get(ctx, id)
+	if err != nil || !found {
 		return nil, false, err
 	}
 
@@ -170,7 +157,7 @@ func (m *RESTFulAPIWatcherStore) List(ctx context.Context, namespace string, opt
 		searches = append(searches, labelSearch)
 	}
 
-	rbs, nextPage, err := PageList(ctx, m.logger, m.apiClient, strings.Join(searches, " and "), opts)
+	rbs, nextPage, err := pageList(ctx, m.logger, m.lister, strings.Join(searches, " and "), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +221,7 @@ func (m *RESTFulAPIWatcherStore) Sync() error {
 	search := ToSyncSearch(m.sourceID, namespaces)
 
 	// for sync, we need list all works with the search condition from maestro server
-	rbs, _, err := PageList(m.ctx, m.logger, m.apiClient, search, metav1.ListOptions{})
+	rbs, _, err := pageList(m.ctx, m.logger, m.lister, search, metav1.ListOptions{})
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/services"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 // SourceClient is an interface for publishing resource events to consumers
@@ -31,6 +33,9 @@ type SourceClient interface {
 	Subscribe(ctx context.Context, handlers ...cegeneric.ResourceHandler[*api.Resource])
 	Resync(ctx context.Context, consumers []string) error
 	SubscribedChan() <-chan struct{}
+	// Subscribed returns true if the client is subscribed to the message broker. It is false from a
+	// disconnect of the client until the client reconnects and subscribes again.
+	Subscribed() bool
 }
 
 type SourceClientImpl struct {
@@ -39,27 +44,87 @@ type SourceClientImpl struct {
 	ResourceService        services.ResourceService
 	sourceID               string
 	transport              ceoptions.CloudEventTransport
+	subscribedChan         chan struct{}
+	connected              atomic.Bool
+	subscribed             atomic.Bool
 }
 
 func NewSourceClient(sourceOptions *ceoptions.CloudEventsSourceOptions, resourceService services.ResourceService) (SourceClient, error) {
 	ctx := context.Background()
 	codec := NewCodec(sourceOptions.SourceID)
-	ceSourceClient, err := ceclients.NewCloudEventSourceClient[*api.Resource](ctx, sourceOptions,
+	client := &SourceClientImpl{
+		Codec:           codec,
+		ResourceService: resourceService,
+		sourceID:        sourceOptions.SourceID,
+		subscribedChan:  make(chan struct{}, 1),
+	}
+
+	// the cloudevents source client does not expose its connection state, it closes the transport on each
+	// disconnect and connects the transport again on each reconnect, so these calls track the connection state
+	client.transport = &connectionTrackingTransport{
+		CloudEventTransport: sourceOptions.CloudEventsTransport,
+		onConnect: func() {
+			// a new connection is not subscribed until the client subscribes on it
+			client.subscribed.Store(false)
+			client.connected.Store(true)
+		},
+		onDisconnect: func() {
+			klog.FromContext(ctx).Info("the source client is disconnected from the message broker")
+			client.connected.Store(false)
+			client.subscribed.Store(false)
+		},
+	}
+	options := *sourceOptions
+	options.CloudEventsTransport = client.transport
+
+	ceSourceClient, err := ceclients.NewCloudEventSourceClient[*api.Resource](ctx, &options,
 		resourceService, ResourceStatusHashGetter, codec)
 	if err != nil {
 		return nil, err
 	}
+	client.CloudEventSourceClient = ceSourceClient
 
 	// register resource resync metrics for cloud event source client
 	cemetrics.RegisterSourceCloudEventsMetrics(prometheus.DefaultRegisterer)
 
-	return &SourceClientImpl{
-		Codec:                  codec,
-		CloudEventSourceClient: ceSourceClient,
-		ResourceService:        resourceService,
-		sourceID:               sourceOptions.SourceID,
-		transport:              sourceOptions.CloudEventsTransport,
-	}, nil
+	go client.watchSubscription()
+
+	return client, nil
+}
+
+// connectionTrackingTransport is a cloudevents transport that calls onConnect when it is connected and
+// onDisconnect when it is closed.
+type connectionTrackingTransport struct {
+	ceoptions.CloudEventTransport
+	onConnect    func()
+	onDisconnect func()
+}
+
+func (t *connectionTrackingTransport) Connect(ctx context.Context) error {
+	if err := t.CloudEventTransport.Connect(ctx); err != nil {
+		return err
+	}
+	t.onConnect()
+	return nil
+}
+
+func (t *connectionTrackingTransport) Close(ctx context.Context) error {
+	t.onDisconnect()
+	return t.CloudEventTransport.Close(ctx)
+}
+
+// watchSubscription relays the subscribed signals of the cloudevents source client and records
+// that the client has subscribed to the message broker, the signals are sent on the first
+// subscription and on each subscription after a reconnect.
+func (s *SourceClientImpl) watchSubscription() {
+	for range s.CloudEventSourceClient.SubscribedChan() {
+		s.subscribed.Store(true)
+		select {
+		case s.subscribedChan <- struct{}{}:
+		default:
+			// the previous signal is not consumed yet, that's ok - don't block
+		}
+	}
 }
 
 func (s *SourceClientImpl) OnCreate(ctx context.Context, id string) error {
@@ -95,6 +160,8 @@ func (s *SourceClientImpl) OnCreate(ctx context.Context, id string) error {
 		SubResource:         cetypes.SubResourceSpec,
 		Action:              cetypes.EventAction("create_request"),
 	}
+	// propagate the trace context to the agent
+	tracing.SetJSONMapTraceContext(ctx, resource.Payload)
 	if err := s.CloudEventSourceClient.Publish(ctx, eventType, resource); err != nil {
 		logger.Error(err, "Failed to publish resource")
 		return err
@@ -132,6 +199,8 @@ func (s *SourceClientImpl) OnUpdate(ctx context.Context, id string) error {
 		SubResource:         cetypes.SubResourceSpec,
 		Action:              cetypes.EventAction("update_request"),
 	}
+	// propagate the trace context to the agent
+	tracing.SetJSONMapTraceContext(ctx, resource.Payload)
 	if err := s.CloudEventSourceClient.Publish(ctx, eventType, resource); err != nil {
 		logger.Error(err, "Failed to publish resource")
 		return err
@@ -163,6 +232,8 @@ func (s *SourceClientImpl) OnDelete(ctx context.Context, id string) error {
 		SubResource:         cetypes.SubResourceSpec,
 		Action:              cetypes.EventAction("delete_request"),
 	}
+	// propagate the trace context to the agent
+	tracing.SetJSONMapTraceContext(ctx, resource.Payload)
 	if err := s.CloudEventSourceClient.Publish(ctx, eventType, resource); err != nil {
 		logger.Error(err, "Failed to publish resource")
 		return err
@@ -276,7 +347,11 @@ func (s *SourceClientImpl) sendResyncBatch(ctx context.Context, consumer string,
 }
 
 func (s *SourceClientImpl) SubscribedChan() <-chan struct{} {
-	return s.CloudEventSourceClient.SubscribedChan()
+	return s.subscribedChan
+}
+
+func (s *SourceClientImpl) Subscribed() bool {
+	return s.connected.Load() && s.subscribed.Load()
 }
 
 // ResourceStatusHashGetter returns a hash of the resource status.
@@ -178,3 +178,7 @@ func (s *SourceClientMock) Resync(ctx context.Context, consumers []string) error
 func (s *SourceClientMock) SubscribedChan() <-chan struct{} {
 	return nil
 }
+
+func (s *SourceClientMock) Subscribed() bool {
+	return true
+}
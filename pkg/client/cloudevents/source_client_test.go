@@ -3,6 +3,8 @@ package cloudevents
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"gorm.io/gorm"
+	ceclients "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/clients"
 	ceoptions "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options"
 	cepayload "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/payload"
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
@@ -327,3 +330,52 @@ func TestResyncConsumerEventStructure(t *testing.T) {
 	Expect(list2.Hashes).To(HaveLen(1))
 	Expect(list2.Hashes[0].StatusHash).NotTo(BeEmpty())
 }
+
+// disconnectingTransport reports the errors of its errorChan to the client, it fails to connect while failConnect
+// is true.
+type disconnectingTransport struct {
+	mockTransport
+	errorChan   chan error
+	failConnect atomic.Bool
+}
+
+func (d *disconnectingTransport) Connect(_ context.Context) error {
+	if d.failConnect.Load() {
+		return fmt.Errorf("connection refused")
+	}
+	return nil
+}
+
+func (d *disconnectingTransport) ErrorChan() <-chan error { return d.errorChan }
+
+func TestSubscribedFollowsTheConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	// reconnect quickly
+	delayFn := ceclients.DelayFn
+	ceclients.DelayFn = func() time.Duration { return 10 * time.Millisecond }
+	defer func() { ceclients.DelayFn = delayFn }()
+
+	transport := &disconnectingTransport{errorChan: make(chan error)}
+	client, err := NewSourceClient(&ceoptions.CloudEventsSourceOptions{
+		CloudEventsTransport: transport,
+		SourceID:             "maestro",
+	}, &mockResourceService{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(client.Subscribed()).To(BeFalse())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.Subscribe(ctx)
+	Eventually(client.Subscribed, 5*time.Second, 10*time.Millisecond).Should(BeTrue())
+
+	// the client is not subscribed while it fails to reconnect
+	transport.failConnect.Store(true)
+	transport.errorChan <- fmt.Errorf("connection lost")
+	Eventually(client.Subscribed, 5*time.Second, 10*time.Millisecond).Should(BeFalse())
+	Consistently(client.Subscribed, 100*time.Millisecond, 10*time.Millisecond).Should(BeFalse())
+
+	// the client subscribes again once it reconnects
+	transport.failConnect.Store(false)
+	Eventually(client.Subscribed, 5*time.Second, 10*time.Millisecond).Should(BeTrue())
+}
@@ -0,0 +1,172 @@
+package grpcauthorizer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+func init() {
+	// Register the metrics:
+	prometheus.MustRegister(cacheRequestsCounterMetric)
+}
+
+const (
+	metricsReviewLabel = "review"
+	metricsResultLabel = "result"
+
+	tokenReview  = "token"
+	accessReview = "access"
+
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+// Description of the authorizer cache requests counter metric:
+var cacheRequestsCounterMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: "grpc_authorizer",
+		Name:      "cache_requests_total",
+		Help:      "Number of token and access reviews looked up in the gRPC authorizer cache, labeled by review and result (hit or miss).",
+	},
+	[]string{metricsReviewLabel, metricsResultLabel},
+)
+
+// CacheConfig configures the decision cache of a gRPC authorizer. The cache is a security trade-off: a revoked token
+// or permission is still accepted until its cached decision expires, so it is disabled by default.
+type CacheConfig struct {
+	// AllowTTL is how long an authenticated token or an allowed access review is cached, 0 to disable.
+	AllowTTL time.Duration
+	// DenyTTL is how long a denied access review is cached, 0 to disable.
+	DenyTTL time.Duration
+	// MaxSize is the maximum number of the cached tokens and the cached access reviews respectively.
+	MaxSize int
+}
+
+// Enabled returns true if any decision is cached.
+func (c CacheConfig) Enabled() bool {
+	return c.MaxSize > 0 && (c.AllowTTL > 0 || c.DenyTTL > 0)
+}
+
+type tokenReviewResult struct {
+	user   string
+	groups []string
+}
+
+// CachingGRPCAuthorizer caches the decisions of another gRPC authorizer, so that the repeated requests of a client
+// do not hit the kube-apiserver or the policy file. The errors are never cached, and a failed token review is not
+// cached because it cannot be distinguished from a transient failure. The cached tokens are stored as hashes, and a
+// token is not cached beyond its expiration time.
+type CachingGRPCAuthorizer struct {
+	delegate GRPCAuthorizer
+	config   CacheConfig
+	clock    cache.Clock
+
+	tokens   *cache.LRUExpireCache
+	accesses *cache.LRUExpireCache
+}
+
+var _ GRPCAuthorizer = &CachingGRPCAuthorizer{}
+
+// NewCachingGRPCAuthorizer creates a gRPC authorizer that caches the decisions of the given authorizer.
+func NewCachingGRPCAuthorizer(delegate GRPCAuthorizer, config CacheConfig) GRPCAuthorizer {
+	return newCachingGRPCAuthorizer(delegate, config, realClock{})
+}
+
+func newCachingGRPCAuthorizer(delegate GRPCAuthorizer, config CacheConfig, clock cache.Clock) *CachingGRPCAuthorizer {
+	return &CachingGRPCAuthorizer{
+		delegate: delegate,
+		config:   config,
+		clock:    clock,
+		tokens:   cache.NewLRUExpireCacheWithClock(config.MaxSize, clock),
+		accesses: cache.NewLRUExpireCacheWithClock(config.MaxSize, clock),
+	}
+}
+
+// TokenReview returns the cached user and groups of the token, or reviews the token with the delegated authorizer.
+func (c *CachingGRPCAuthorizer) TokenReview(ctx context.Context, token string) (user string, groups []string, err error) {
+	if c.config.AllowTTL <= 0 {
+		return c.delegate.TokenReview(ctx, token)
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(hash[:])
+	if value, ok := c.tokens.Get(key); ok {
+		cacheRequestsCounterMetric.WithLabelValues(tokenReview, cacheHit).Inc()
+		result := value.(tokenReviewResult)
+		return result.user, result.groups, nil
+	}
+
+	cacheRequestsCounterMetric.WithLabelValues(tokenReview, cacheMiss).Inc()
+	user, groups, err = c.delegate.TokenReview(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ttl := c.config.AllowTTL
+	if expiration, ok := tokenExpiration(token); ok {
+		ttl = min(ttl, expiration.Sub(c.clock.Now()))
+	}
+	if ttl > 0 {
+		c.tokens.Add(key, tokenReviewResult{user: user, groups: groups}, ttl)
+	}
+	return user, groups, nil
+}
+
+// tokenExpiration returns the expiration time of a JWT token, it returns false if the token is not a JWT or it does
+// not expire. The token is already reviewed by the delegated authorizer, so its signature is not verified again.
+func tokenExpiration(token string) (time.Time, bool) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return time.Time{}, false
+	}
+	expiration, err := claims.GetExpirationTime()
+	if err != nil || expiration == nil {
+		return time.Time{}, false
+	}
+	return expiration.Time, true
+}
+
+// AccessReview returns the cached decision of the access review, or reviews the access with the delegated authorizer.
+func (c *CachingGRPCAuthorizer) AccessReview(ctx context.Context, action, resourceType, resource, user string, groups []string) (allowed bool, err error) {
+	key := accessReviewKey(action, resourceType, resource, user, groups)
+	if value, ok := c.accesses.Get(key); ok {
+		cacheRequestsCounterMetric.WithLabelValues(accessReview, cacheHit).Inc()
+		return value.(bool), nil
+	}
+
+	cacheRequestsCounterMetric.WithLabelValues(accessReview, cacheMiss).Inc()
+	allowed, err = c.delegate.AccessReview(ctx, action, resourceType, resource, user, groups)
+	if err != nil {
+		return false, err
+	}
+
+	ttl := c.config.DenyTTL
+	if allowed {
+		ttl = c.config.AllowTTL
+	}
+	if ttl > 0 {
+		c.accesses.Add(key, allowed, ttl)
+	}
+	return allowed, nil
+}
+
+// accessReviewKey builds the cache key of an access review, the groups are sorted so that the key does not
+// depend on their order.
+func accessReviewKey(action, resourceType, resource, user string, groups []string) string {
+	sortedGroups := append([]string{}, groups...)
+	sort.Strings(sortedGroups)
+	// the null character cannot be a part of the names, so the key is unambiguous
+	return strings.Join(append([]string{action, resourceType, resource, user}, sortedGroups...), "\x00")
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
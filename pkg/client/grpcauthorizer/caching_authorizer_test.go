@@ -0,0 +1,173 @@
+package grpcauthorizer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// countingAuthorizer allows the user "allowed" only, and counts the reviews.
+type countingAuthorizer struct {
+	tokenReviews  int
+	accessReviews int
+	err           error
+}
+
+func (c *countingAuthorizer) TokenReview(ctx context.Context, token string) (string, []string, error) {
+	c.tokenReviews++
+	if token == "invalid" {
+		return "", nil, fmt.Errorf("token not authenticated")
+	}
+	return "user-" + token, []string{"group"}, nil
+}
+
+func (c *countingAuthorizer) AccessReview(ctx context.Context, action, resourceType, resource, user string, groups []string) (bool, error) {
+	c.accessReviews++
+	return user == "allowed", c.err
+}
+
+func TestCachingGRPCAuthorizerAccessReview(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	delegate := &countingAuthorizer{}
+	authorizer := newCachingGRPCAuthorizer(delegate, CacheConfig{
+		AllowTTL: time.Minute,
+		DenyTTL:  10 * time.Second,
+		MaxSize:  10,
+	}, clock)
+
+	review := func(user string, groups ...string) bool {
+		allowed, err := authorizer.AccessReview(context.Background(), "pub", "source", "maestro", user, groups)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return allowed
+	}
+
+	hits := testutil.ToFloat64(cacheRequestsCounterMetric.WithLabelValues(accessReview, cacheHit))
+	if !review("allowed", "a", "b") || !review("allowed", "b", "a") {
+		t.Fatalf("expected allowed")
+	}
+	if delegate.accessReviews != 1 {
+		t.Errorf("expected the allowed review is cached regardless of the group order, but got %d reviews", delegate.accessReviews)
+	}
+	if testutil.ToFloat64(cacheRequestsCounterMetric.WithLabelValues(accessReview, cacheHit))-hits != 1 {
+		t.Errorf("expected one cache hit")
+	}
+
+	if review("denied") || review("denied") {
+		t.Fatalf("expected denied")
+	}
+	if delegate.accessReviews != 2 {
+		t.Errorf("expected the denied review is cached, but got %d reviews", delegate.accessReviews)
+	}
+
+	// the denied review expires before the allowed review
+	clock.now = clock.now.Add(30 * time.Second)
+	review("allowed", "a", "b")
+	review("denied")
+	if delegate.accessReviews != 3 {
+		t.Errorf("expected the denied review is expired, but got %d reviews", delegate.accessReviews)
+	}
+
+	// the errors are not cached
+	delegate.err = fmt.Errorf("failed")
+	for i := 0; i < 2; i++ {
+		if _, err := authorizer.AccessReview(context.Background(), "sub", "source", "maestro", "allowed", nil); err == nil {
+			t.Fatalf("expected an error")
+		}
+	}
+	if delegate.accessReviews != 5 {
+		t.Errorf("expected the errors are not cached, but got %d reviews", delegate.accessReviews)
+	}
+}
+
+func TestCachingGRPCAuthorizerTokenReview(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	delegate := &countingAuthorizer{}
+	authorizer := newCachingGRPCAuthorizer(delegate, CacheConfig{AllowTTL: time.Minute, MaxSize: 1}, clock)
+
+	for i := 0; i < 2; i++ {
+		user, _, err := authorizer.TokenReview(context.Background(), "token1")
+		if err != nil || user != "user-token1" {
+			t.Fatalf("unexpected review result %q, %v", user, err)
+		}
+	}
+	if delegate.tokenReviews != 1 {
+		t.Errorf("expected the token is cached, but got %d reviews", delegate.tokenReviews)
+	}
+
+	// the failed token reviews are not cached
+	for i := 0; i < 2; i++ {
+		if _, _, err := authorizer.TokenReview(context.Background(), "invalid"); err == nil {
+			t.Fatalf("expected an error")
+		}
+	}
+	if delegate.tokenReviews != 3 {
+		t.Errorf("expected the failed token reviews are not cached, but got %d reviews", delegate.tokenReviews)
+	}
+
+	// the cache is bounded, token2 evicts token1
+	if _, _, err := authorizer.TokenReview(context.Background(), "token2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := authorizer.TokenReview(context.Background(), "token1"); err != nil {
+		t.Fatal(err)
+	}
+	if delegate.tokenReviews != 5 {
+		t.Errorf("expected token1 is evicted, but got %d reviews", delegate.tokenReviews)
+	}
+}
+
+func TestCachingGRPCAuthorizerTokenExpiration(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	delegate := &countingAuthorizer{}
+	authorizer := newCachingGRPCAuthorizer(delegate, CacheConfig{AllowTTL: time.Minute, MaxSize: 10}, clock)
+
+	newToken := func(expiration time.Time) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "client", "exp": expiration.Unix()}).
+			SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	// the token is cached until it expires in 10 seconds instead of the allow TTL
+	token := newToken(clock.now.Add(10 * time.Second))
+	for i := 0; i < 2; i++ {
+		if _, _, err := authorizer.TokenReview(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if delegate.tokenReviews != 1 {
+		t.Errorf("expected the token is cached, but got %d reviews", delegate.tokenReviews)
+	}
+
+	clock.now = clock.now.Add(11 * time.Second)
+	if _, _, err := authorizer.TokenReview(context.Background(), token); err != nil {
+		t.Fatal(err)
+	}
+	if delegate.tokenReviews != 2 {
+		t.Errorf("expected the expired token is reviewed again, but got %d reviews", delegate.tokenReviews)
+	}
+
+	// an expired token is never cached
+	for i := 0; i < 2; i++ {
+		if _, _, err := authorizer.TokenReview(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if delegate.tokenReviews != 4 {
+		t.Errorf("expected the expired token is not cached, but got %d reviews", delegate.tokenReviews)
+	}
+}
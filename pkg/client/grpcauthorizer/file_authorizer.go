@@ -0,0 +1,252 @@
+package grpcauthorizer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// PolicyKind is the kind of the gRPC authorization policy file, it distinguishes the policy file from a kubeconfig.
+const PolicyKind = "GRPCAuthorizationPolicy"
+
+// defaultPolicyReloadInterval is the minimum interval between two checks of the policy file for changes.
+const defaultPolicyReloadInterval = 5 * time.Second
+
+// Policy is the gRPC authorization policy that is loaded from a local YAML file, e.g.
+//
+//	kind: GRPCAuthorizationPolicy
+//	rules:
+//	- users: ["system:serviceaccount:maestro:grpc-client"]
+//	  actions: ["pub", "sub"]
+//	  resourceType: source
+//	  resources: ["*"]
+//	- groups: ["agents"]
+//	  actions: ["*"]
+//	  resourceType: cluster
+//	  resources: ["cluster-*"]
+//
+// A request is allowed if any rule matches it.
+type Policy struct {
+	Kind  string       `json:"kind"`
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule allows the users and groups to perform the actions on the resources of the resource type.
+// The users, groups, actions and resources support the shell file name patterns, e.g. "cluster-*", "*" matches
+// any name, and the resource type supports "*" for both "source" and "cluster".
+type PolicyRule struct {
+	Users        []string `json:"users,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	Actions      []string `json:"actions"`
+	ResourceType string   `json:"resourceType"`
+	Resources    []string `json:"resources"`
+}
+
+// IsPolicyFile returns true if the given file is a gRPC authorization policy file. Both the policy file and the
+// kubeconfig are YAML files, an error is returned if the file cannot be read or parsed.
+func IsPolicyFile(file string) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+
+	typeMeta := struct {
+		Kind string `json:"kind"`
+	}{}
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return false, fmt.Errorf("failed to parse the gRPC authorizer config %s: %v", file, err)
+	}
+	return typeMeta.Kind == PolicyKind, nil
+}
+
+// LoadPolicy loads and validates the gRPC authorization policy from the given file.
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse the policy file %s: %v", file, err)
+	}
+	if policy.Kind != PolicyKind {
+		return nil, fmt.Errorf("the kind of the policy file %s must be %s", file, PolicyKind)
+	}
+
+	for i, rule := range policy.Rules {
+		if len(rule.Users) == 0 && len(rule.Groups) == 0 {
+			return nil, fmt.Errorf("rule %d: users or groups must be specified", i)
+		}
+		if len(rule.Actions) == 0 {
+			return nil, fmt.Errorf("rule %d: actions must be specified", i)
+		}
+		if len(rule.Resources) == 0 {
+			return nil, fmt.Errorf("rule %d: resources must be specified", i)
+		}
+		switch rule.ResourceType {
+		case "source", "cluster", "*":
+		default:
+			return nil, fmt.Errorf("rule %d: unsupported resource type: %q", i, rule.ResourceType)
+		}
+		for _, patterns := range [][]string{rule.Users, rule.Groups, rule.Actions, rule.Resources} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return nil, fmt.Errorf("rule %d: invalid pattern %q: %v", i, pattern, err)
+				}
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// FileGRPCAuthorizer is a gRPC authorizer that authorizes requests with a local policy file, it does not
+// require Kubernetes. The policy file is not watched, an access review checks if the file is changed, at most
+// once per reload interval, and reloads it before the review. If the changed file is invalid, the error is
+// logged and the last valid policy is kept.
+type FileGRPCAuthorizer struct {
+	file           string
+	reloadInterval time.Duration
+
+	mu          sync.RWMutex
+	policy      *Policy
+	modTime     time.Time
+	size        int64
+	lastChecked time.Time
+}
+
+var _ GRPCAuthorizer = &FileGRPCAuthorizer{}
+
+// NewFileGRPCAuthorizer creates a gRPC authorizer with the given policy file.
+func NewFileGRPCAuthorizer(file string) (GRPCAuthorizer, error) {
+	return newFileGRPCAuthorizer(file, defaultPolicyReloadInterval)
+}
+
+func newFileGRPCAuthorizer(file string, reloadInterval time.Duration) (*FileGRPCAuthorizer, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := LoadPolicy(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileGRPCAuthorizer{
+		file:           file,
+		reloadInterval: reloadInterval,
+		policy:         policy,
+		modTime:        info.ModTime(),
+		size:           info.Size(),
+		lastChecked:    time.Now(),
+	}, nil
+}
+
+// TokenReview is not supported by the policy file, use the mtls authentication with this authorizer.
+func (f *FileGRPCAuthorizer) TokenReview(ctx context.Context, token string) (user string, groups []string, err error) {
+	return "", nil, fmt.Errorf("token review is not supported by the policy file authorizer")
+}
+
+// AccessReview checks if the given user or groups are allowed to perform the given action on the given resource
+// by the rules of the policy file.
+func (f *FileGRPCAuthorizer) AccessReview(ctx context.Context, action, resourceType, resource, user string, groups []string) (allowed bool, err error) {
+	logger := klog.FromContext(ctx).WithValues(
+		"action", action,
+		"resourceType", resourceType,
+		"resource", resource,
+		"user", user,
+		"groups", groups,
+	)
+
+	logger.V(4).Info("AccessReview")
+	if action != "pub" && action != "sub" {
+		return false, fmt.Errorf("unsupported action: %s", action)
+	}
+
+	if resourceType != "source" && resourceType != "cluster" {
+		return false, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+
+	if resource == "" {
+		return false, fmt.Errorf("resource cannot be empty")
+	}
+
+	for _, rule := range f.currentPolicy(ctx).Rules {
+		if rule.ResourceType != "*" && rule.ResourceType != resourceType {
+			continue
+		}
+		if !matchAny(rule.Actions, action) || !matchAny(rule.Resources, resource) {
+			continue
+		}
+		if (user != "" && matchAny(rule.Users, user)) || matchAnyOf(rule.Groups, groups) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// currentPolicy returns the current policy, it reloads the policy file if the file is changed since the last check.
+func (f *FileGRPCAuthorizer) currentPolicy(ctx context.Context) *Policy {
+	f.mu.RLock()
+	policy := f.policy
+	recentlyChecked := time.Since(f.lastChecked) < f.reloadInterval
+	f.mu.RUnlock()
+	if recentlyChecked {
+		return policy
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastChecked = time.Now()
+	info, err := os.Stat(f.file)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "failed to check the policy file, keep the current policy", "file", f.file)
+		return f.policy
+	}
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.policy
+	}
+
+	reloaded, err := LoadPolicy(f.file)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "failed to reload the policy file, keep the current policy", "file", f.file)
+		return f.policy
+	}
+
+	klog.FromContext(ctx).Info("the policy file is reloaded", "file", f.file, "rules", len(reloaded.Rules))
+	f.policy = reloaded
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return f.policy
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAnyOf(patterns []string, names []string) bool {
+	for _, name := range names {
+		if matchAny(patterns, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package grpcauthorizer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testPolicy = `
+kind: GRPCAuthorizationPolicy
+rules:
+- users: ["grpc-client"]
+  actions: ["pub", "sub"]
+  resourceType: source
+  resources: ["maestro-*"]
+- groups: ["agents"]
+  actions: ["*"]
+  resourceType: cluster
+  resources: ["cluster1"]
+`
+
+func writePolicy(t *testing.T, file, policy string) {
+	t.Helper()
+	if err := os.WriteFile(file, []byte(policy), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileGRPCAuthorizerAccessReview(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy(t, file, testPolicy)
+
+	authorizer, err := NewFileGRPCAuthorizer(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name         string
+		action       string
+		resourceType string
+		resource     string
+		user         string
+		groups       []string
+		allowed      bool
+		expectErr    bool
+	}{
+		{
+			name:         "user matches the source pattern",
+			action:       "pub",
+			resourceType: "source",
+			resource:     "maestro-1",
+			user:         "grpc-client",
+			allowed:      true,
+		},
+		{
+			name:         "user does not match the source pattern",
+			action:       "sub",
+			resourceType: "source",
+			resource:     "other",
+			user:         "grpc-client",
+		},
+		{
+			name:         "group matches the cluster",
+			action:       "sub",
+			resourceType: "cluster",
+			resource:     "cluster1",
+			user:         "agent",
+			groups:       []string{"system:authenticated", "agents"},
+			allowed:      true,
+		},
+		{
+			name:         "group does not match the cluster",
+			action:       "pub",
+			resourceType: "cluster",
+			resource:     "cluster2",
+			groups:       []string{"agents"},
+		},
+		{
+			name:         "user is not allowed on the cluster",
+			action:       "pub",
+			resourceType: "cluster",
+			resource:     "cluster1",
+			user:         "grpc-client",
+		},
+		{
+			name:         "unsupported action",
+			action:       "delete",
+			resourceType: "source",
+			resource:     "maestro-1",
+			user:         "grpc-client",
+			expectErr:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			allowed, err := authorizer.AccessReview(context.Background(), c.action, c.resourceType, c.resource, c.user, c.groups)
+			if c.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, but got %v", c.expectErr, err)
+			}
+			if allowed != c.allowed {
+				t.Errorf("expected allowed %v, but got %v", c.allowed, allowed)
+			}
+		})
+	}
+}
+
+func TestFileGRPCAuthorizerReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy(t, file, testPolicy)
+
+	authorizer, err := newFileGRPCAuthorizer(file, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	review := func() bool {
+		allowed, err := authorizer.AccessReview(context.Background(), "sub", "cluster", "cluster2", "", []string{"agents"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return allowed
+	}
+
+	if review() {
+		t.Fatalf("expected cluster2 is not allowed")
+	}
+
+	// allow all clusters, the modification time is changed explicitly in case the file system has a coarse resolution
+	writePolicy(t, file, `
+kind: GRPCAuthorizationPolicy
+rules:
+- groups: ["agents"]
+  actions: ["*"]
+  resourceType: "*"
+  resources: ["*"]
+`)
+	if err := os.Chtimes(file, time.Now(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if !review() {
+		t.Fatalf("expected cluster2 is allowed after the policy is reloaded")
+	}
+
+	// an invalid policy is ignored
+	writePolicy(t, file, "kind: GRPCAuthorizationPolicy\nrules:\n- actions: [\"*\"]\n")
+	if err := os.Chtimes(file, time.Now(), time.Now().Add(2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if !review() {
+		t.Fatalf("expected the last valid policy is kept")
+	}
+}
+
+func TestIsPolicyFile(t *testing.T) {
+	dir := t.TempDir()
+	policyFile := filepath.Join(dir, "policy.yaml")
+	writePolicy(t, policyFile, testPolicy)
+	kubeconfigFile := filepath.Join(dir, "kubeconfig")
+	writePolicy(t, kubeconfigFile, "apiVersion: v1\nkind: Config\nclusters: []\n")
+
+	if isPolicy, err := IsPolicyFile(policyFile); err != nil || !isPolicy {
+		t.Errorf("expected a policy file, but got %v, %v", isPolicy, err)
+	}
+	if isPolicy, err := IsPolicyFile(kubeconfigFile); err != nil || isPolicy {
+		t.Errorf("expected not a policy file, but got %v, %v", isPolicy, err)
+	}
+
+	// a broken policy file is not taken for a kubeconfig
+	brokenFile := filepath.Join(dir, "broken.yaml")
+	writePolicy(t, brokenFile, "kind: GRPCAuthorizationPolicy\nrules: [\n")
+	if _, err := IsPolicyFile(brokenFile); err == nil {
+		t.Errorf("expected an error for the broken policy file")
+	}
+	if _, err := IsPolicyFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("expected an error for the missing policy file")
+	}
+}
@@ -0,0 +1,450 @@
+package grpcauthorizer
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultUsernameClaim = "sub"
+	defaultGroupsClaim   = "groups"
+
+	// minJWKSRefreshInterval limits how often the JWKS of an issuer is refreshed when a token is signed by an
+	// unknown key, so that tokens with random key IDs cannot flood the issuer.
+	minJWKSRefreshInterval = 30 * time.Second
+
+	// tokenLeeway is the allowed clock skew when validating the exp, nbf and iat claims.
+	tokenLeeway = 30 * time.Second
+)
+
+// supportedSigningMethods are the asymmetric signing methods that can be verified with a JWKS.
+var supportedSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// OIDCConfig is the configuration of the OIDC token authentication, it is loaded from a YAML file, e.g.
+//
+//	issuers:
+//	- issuerURL: https://idp.example.com
+//	  audiences: ["maestro"]
+//	  usernameClaim: sub
+//	  usernamePrefix: "oidc:"
+//	  groupsClaim: groups
+//	  groupsPrefix: "oidc:"
+type OIDCConfig struct {
+	Issuers []OIDCIssuer `json:"issuers"`
+}
+
+// OIDCIssuer is an OIDC issuer whose tokens are verified locally.
+type OIDCIssuer struct {
+	// IssuerURL must match the iss claim of the token, it is also used to discover the JWKS URL.
+	IssuerURL string `json:"issuerURL"`
+	// JWKSURL is the URL of the issuer signing keys, it is discovered from the issuer if not specified.
+	JWKSURL string `json:"jwksURL,omitempty"`
+	// CAFile is the CA bundle to verify the issuer, the system roots are used if not specified.
+	CAFile string `json:"caFile,omitempty"`
+	// Audiences are the accepted aud claims, the token must have at least one of them.
+	Audiences []string `json:"audiences"`
+	// UsernameClaim is the claim mapped to the user, defaults to "sub".
+	UsernameClaim  string `json:"usernameClaim,omitempty"`
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+	// GroupsClaim is the claim mapped to the groups, defaults to "groups".
+	GroupsClaim  string `json:"groupsClaim,omitempty"`
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+}
+
+// LoadOIDCConfig loads and validates the OIDC configuration from the given file.
+func LoadOIDCConfig(file string) (*OIDCConfig, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &OIDCConfig{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse the OIDC config file %s: %v", file, err)
+	}
+	if len(config.Issuers) == 0 {
+		return nil, fmt.Errorf("no issuers in the OIDC config file %s", file)
+	}
+
+	issuerURLs := map[string]bool{}
+	for i, issuer := range config.Issuers {
+		if !strings.HasPrefix(issuer.IssuerURL, "https://") {
+			return nil, fmt.Errorf("issuer %d: the issuer URL must be an https URL", i)
+		}
+		if issuerURLs[issuer.IssuerURL] {
+			return nil, fmt.Errorf("issuer %d: duplicate issuer URL %s", i, issuer.IssuerURL)
+		}
+		issuerURLs[issuer.IssuerURL] = true
+		if len(issuer.Audiences) == 0 {
+			return nil, fmt.Errorf("issuer %d: audiences must be specified", i)
+		}
+		if issuer.UsernameClaim == "" {
+			config.Issuers[i].UsernameClaim = defaultUsernameClaim
+		}
+		if issuer.GroupsClaim == "" {
+			config.Issuers[i].GroupsClaim = defaultGroupsClaim
+		}
+	}
+
+	return config, nil
+}
+
+// OIDCGRPCAuthorizer is a gRPC authorizer that verifies the JWTs of the configured OIDC issuers locally
+// against the issuer JWKS, and maps the token claims to the user and groups. The tokens of the other
+// issuers and the access reviews are delegated to the given authorizer.
+type OIDCGRPCAuthorizer struct {
+	delegate  GRPCAuthorizer
+	verifiers map[string]*oidcVerifier
+}
+
+var _ GRPCAuthorizer = &OIDCGRPCAuthorizer{}
+
+// NewOIDCGRPCAuthorizer creates a gRPC authorizer that verifies the OIDC tokens of the configured issuers.
+// The signing keys of the issuers are prefetched in the background, so that the first tokens do not wait
+// for them.
+func NewOIDCGRPCAuthorizer(config *OIDCConfig, delegate GRPCAuthorizer) (GRPCAuthorizer, error) {
+	verifiers := map[string]*oidcVerifier{}
+	for _, issuer := range config.Issuers {
+		client, err := newIssuerHTTPClient(issuer.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the http client of issuer %s: %v", issuer.IssuerURL, err)
+		}
+		verifiers[issuer.IssuerURL] = &oidcVerifier{issuer: issuer, client: client}
+	}
+
+	for _, verifier := range verifiers {
+		go verifier.prefetch(context.Background())
+	}
+
+	return &OIDCGRPCAuthorizer{
+		delegate:  delegate,
+		verifiers: verifiers,
+	}, nil
+}
+
+// TokenReview verifies the given token if it is issued by a configured issuer, and returns the user and groups
+// in its claims. Otherwise, the token is reviewed by the delegated authorizer.
+func (o *OIDCGRPCAuthorizer) TokenReview(ctx context.Context, token string) (user string, groups []string, err error) {
+	unverified, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		// not a JWT, e.g. an opaque token
+		return o.delegate.TokenReview(ctx, token)
+	}
+
+	issuerURL, err := unverified.Claims.GetIssuer()
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid iss claim: %v", err)
+	}
+
+	verifier, ok := o.verifiers[issuerURL]
+	if !ok {
+		return o.delegate.TokenReview(ctx, token)
+	}
+
+	return verifier.verify(ctx, token)
+}
+
+// AccessReview is delegated to the given authorizer.
+func (o *OIDCGRPCAuthorizer) AccessReview(ctx context.Context, action, resourceType, resource, user string, groups []string) (allowed bool, err error) {
+	return o.delegate.AccessReview(ctx, action, resourceType, resource, user, groups)
+}
+
+// oidcVerifier verifies the tokens of an OIDC issuer, the issuer signing keys are cached and refreshed
+// when a token is signed by an unknown key.
+type oidcVerifier struct {
+	issuer OIDCIssuer
+	client *http.Client
+
+	// refreshes shares one fetch of the signing keys between the concurrent refreshes
+	refreshes singleflight.Group
+	// jwksURL is only accessed by the fetches, which never run concurrently
+	jwksURL string
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+func (v *oidcVerifier) verify(ctx context.Context, token string) (string, []string, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, kid)
+	},
+		jwt.WithIssuer(v.issuer.IssuerURL),
+		jwt.WithValidMethods(supportedSigningMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(tokenLeeway),
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("token not authenticated: %v", err)
+	}
+
+	audiences, err := claims.GetAudience()
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid aud claim: %v", err)
+	}
+	if !containsAny(v.issuer.Audiences, audiences) {
+		return "", nil, fmt.Errorf("token not authenticated: the audiences %v are not accepted", audiences)
+	}
+
+	user, ok := claims[v.issuer.UsernameClaim].(string)
+	if !ok || user == "" {
+		return "", nil, fmt.Errorf("the claim %s is not found in the token", v.issuer.UsernameClaim)
+	}
+
+	var groups []string
+	switch value := claims[v.issuer.GroupsClaim].(type) {
+	case nil:
+	case string:
+		groups = append(groups, v.issuer.GroupsPrefix+value)
+	case []interface{}:
+		for _, group := range value {
+			name, ok := group.(string)
+			if !ok {
+				return "", nil, fmt.Errorf("the claim %s must be a string array", v.issuer.GroupsClaim)
+			}
+			groups = append(groups, v.issuer.GroupsPrefix+name)
+		}
+	default:
+		return "", nil, fmt.Errorf("the claim %s must be a string array", v.issuer.GroupsClaim)
+	}
+
+	return v.issuer.UsernamePrefix + user, groups, nil
+}
+
+// key returns the signing key of the given key ID, the JWKS is refreshed if the key is unknown.
+func (v *oidcVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+
+	if err := v.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("failed to fetch the signing keys of issuer %s: %v", v.issuer.IssuerURL, err)
+	}
+
+	if key, ok := v.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("signing key %q of issuer %s is not found", kid, v.issuer.IssuerURL)
+}
+
+// prefetch fetches the signing keys of the issuer, a failure is only logged, the keys are fetched again for
+// the first token.
+func (v *oidcVerifier) prefetch(ctx context.Context) {
+	if err := v.refresh(ctx); err != nil {
+		klog.FromContext(ctx).Error(err, "failed to prefetch the signing keys", "issuer", v.issuer.IssuerURL)
+	}
+}
+
+// refresh fetches the signing keys of the issuer, unless they were fetched within the min refresh interval.
+// The keys are fetched without holding the lock, the concurrent refreshes wait for the same fetch, and only
+// a successful fetch delays the next one.
+func (v *oidcVerifier) refresh(ctx context.Context) error {
+	_, err, _ := v.refreshes.Do(v.issuer.IssuerURL, func() (interface{}, error) {
+		v.mu.RLock()
+		recentlyRefreshed := time.Since(v.lastRefresh) < minJWKSRefreshInterval
+		v.mu.RUnlock()
+		if recentlyRefreshed {
+			return nil, nil
+		}
+
+		// the fetch is shared, it is not canceled with the context of the request that started it, the http
+		// client has its own timeout
+		keys, err := v.fetchKeys(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		klog.FromContext(ctx).V(4).Info("the signing keys are refreshed", "issuer", v.issuer.IssuerURL, "keys", len(keys))
+
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.keys = keys
+		v.lastRefresh = time.Now()
+		return nil, nil
+	})
+	return err
+}
+
+// lookup returns the key of the given key ID, a token without key ID can be verified only if the issuer has
+// exactly one key.
+func (v *oidcVerifier) lookup(kid string) (crypto.PublicKey, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+func (v *oidcVerifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	if v.jwksURL == "" {
+		v.jwksURL = v.issuer.JWKSURL
+	}
+	if v.jwksURL == "" {
+		discovery := struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}{}
+		if err := v.getJSON(ctx, strings.TrimSuffix(v.issuer.IssuerURL, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, err
+		}
+		if discovery.Issuer != v.issuer.IssuerURL {
+			return nil, fmt.Errorf("the discovered issuer %s does not match", discovery.Issuer)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, fmt.Errorf("the jwks_uri is not found in the discovery document")
+		}
+		v.jwksURL = discovery.JWKSURI
+	}
+
+	jwks := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := v.getJSON(ctx, v.jwksURL, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "ignore the invalid signing key", "issuer", v.issuer.IssuerURL, "kid", jwk.Kid)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (v *oidcVerifier) getJSON(ctx context.Context, url string, into interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+// jsonWebKey is a public JSON Web Key (RFC 7517), only the RSA and EC keys are supported.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func newIssuerHTTPClient(caFile string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		certPool := x509.NewCertPool()
+		if ok := certPool.AppendCertsFromPEM(caPEM); !ok {
+			return nil, fmt.Errorf("failed to append the CA file %s to cert pool", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS12}
+	}
+	return &http.Client{Transport: transport, Timeout: 10 * time.Second}, nil
+}
+
+func containsAny(accepted, values []string) bool {
+	for _, value := range values {
+		for _, a := range accepted {
+			if a == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package grpcauthorizer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeTokenAuthorizer reviews every token as the user "delegated".
+type fakeTokenAuthorizer struct {
+	MockGRPCAuthorizer
+}
+
+func (f *fakeTokenAuthorizer) TokenReview(ctx context.Context, token string) (string, []string, error) {
+	return "delegated", []string{"delegated-group"}, nil
+}
+
+func newTestIssuer(t *testing.T, key *rsa.PrivateKey) (*httptest.Server, string) {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   server.URL,
+			"jwks_uri": server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "key1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return server, caFile
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key1"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestOIDCGRPCAuthorizerTokenReview(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, caFile := newTestIssuer(t, key)
+	authorizer, err := NewOIDCGRPCAuthorizer(&OIDCConfig{
+		Issuers: []OIDCIssuer{{
+			IssuerURL:      server.URL,
+			CAFile:         caFile,
+			Audiences:      []string{"maestro"},
+			UsernameClaim:  defaultUsernameClaim,
+			UsernamePrefix: "oidc:",
+			GroupsClaim:    defaultGroupsClaim,
+		}},
+	}, &fakeTokenAuthorizer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    server.URL,
+			"sub":    "workload1",
+			"aud":    []string{"maestro"},
+			"groups": []string{"publishers"},
+			"iat":    now.Unix(),
+			"exp":    now.Add(time.Hour).Unix(),
+		}
+	}
+
+	cases := []struct {
+		name           string
+		token          func() string
+		expectedUser   string
+		expectedGroups []string
+		expectErr      bool
+	}{
+		{
+			name:           "valid token",
+			token:          func() string { return signToken(t, key, validClaims()) },
+			expectedUser:   "oidc:workload1",
+			expectedGroups: []string{"publishers"},
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = now.Add(-time.Hour).Unix()
+				return signToken(t, key, claims)
+			},
+			expectErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "other"
+				return signToken(t, key, claims)
+			},
+			expectErr: true,
+		},
+		{
+			name:      "signed by an unknown key",
+			token:     func() string { return signToken(t, otherKey, validClaims()) },
+			expectErr: true,
+		},
+		{
+			name: "token of another issuer is delegated",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://kubernetes.default.svc"
+				return signToken(t, otherKey, claims)
+			},
+			expectedUser:   "delegated",
+			expectedGroups: []string{"delegated-group"},
+		},
+		{
+			name:           "opaque token is delegated",
+			token:          func() string { return "opaque" },
+			expectedUser:   "delegated",
+			expectedGroups: []string{"delegated-group"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			user, groups, err := authorizer.TokenReview(context.Background(), c.token())
+			if c.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, but got %v", c.expectErr, err)
+			}
+			if user != c.expectedUser {
+				t.Errorf("expected user %q, but got %q", c.expectedUser, user)
+			}
+			if !reflect.DeepEqual(groups, c.expectedGroups) {
+				t.Errorf("expected groups %v, but got %v", c.expectedGroups, groups)
+			}
+		})
+	}
+}
+
+func TestOIDCVerifierRefresh(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fetches, failing atomic.Int32
+	failing.Store(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if failing.Load() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "key1",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	verifier := &oidcVerifier{issuer: OIDCIssuer{IssuerURL: "https://idp.example.com", JWKSURL: server.URL}, client: server.Client()}
+
+	// the prefetch fails, it does not delay the next fetch
+	verifier.prefetch(context.Background())
+	if _, err := verifier.key(context.Background(), "key1"); err == nil {
+		t.Fatalf("expected an error while the issuer is failing")
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Fatalf("expected 2 fetches, but got %d", got)
+	}
+
+	failing.Store(0)
+	if _, err := verifier.key(context.Background(), "key1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the keys were fetched successfully, an unknown key does not fetch them again within the refresh interval
+	if _, err := verifier.key(context.Background(), "key2"); err == nil {
+		t.Fatalf("expected an error for the unknown key")
+	}
+	if got := fetches.Load(); got != 3 {
+		t.Errorf("expected 3 fetches, but got %d", got)
+	}
+}
+
+func TestLoadOIDCConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "oidc.yaml")
+	if err := os.WriteFile(file, []byte("issuers:\n- issuerURL: https://idp.example.com\n  audiences: [maestro]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadOIDCConfig(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Issuers[0].UsernameClaim != "sub" || config.Issuers[0].GroupsClaim != "groups" {
+		t.Errorf("unexpected default claims %v", config.Issuers[0])
+	}
+
+	if err := os.WriteFile(file, []byte("issuers:\n- issuerURL: http://idp.example.com\n  audiences: [maestro]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOIDCConfig(file); err == nil {
+		t.Errorf("expected an error for the http issuer")
+	}
+}
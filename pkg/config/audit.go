@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type AuditConfig struct {
+	LogFile string `json:"log_file"`
+	// TrustForwardedHeaders takes the actor of a REST request from the X-Forwarded-User and X-Forwarded-Groups
+	// headers, it must only be set if the REST API is only reachable through an authenticating proxy that sets them.
+	TrustForwardedHeaders bool `json:"trust_forwarded_headers"`
+}
+
+func NewAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		LogFile:               "",
+		TrustForwardedHeaders: false,
+	}
+}
+
+func (c *AuditConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.LogFile, "audit-log-file", c.LogFile, "The file that the audit records are appended to as JSON lines in addition to the database, use '-' for the stdout. Disabled if empty")
+	fs.BoolVar(&c.TrustForwardedHeaders, "audit-trust-forwarded-headers", c.TrustForwardedHeaders, "Take the actor of a REST request from the X-Forwarded-User and X-Forwarded-Groups headers. Only enable it if the REST API is only reachable through an authenticating proxy that sets them")
+}
+
+func (c *AuditConfig) ReadFiles() error {
+	return nil
+}
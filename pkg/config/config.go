@@ -20,6 +20,8 @@ type ApplicationConfig struct {
 	EventServer   *EventServerConfig   `json:"event_server"`
 	Database      *DatabaseConfig      `json:"database"`
 	MessageBroker *MessageBrokerConfig `json:"message_broker"`
+	Audit         *AuditConfig         `json:"audit"`
+	Idempotency   *IdempotencyConfig   `json:"idempotency"`
 }
 
 func NewApplicationConfig() *ApplicationConfig {
@@ -31,6 +33,8 @@ func NewApplicationConfig() *ApplicationConfig {
 		EventServer:   NewEventServerConfig(),
 		Database:      NewDatabaseConfig(),
 		MessageBroker: NewMessageBrokerConfig(),
+		Audit:         NewAuditConfig(),
+		Idempotency:   NewIdempotencyConfig(),
 	}
 }
 
@@ -43,6 +47,8 @@ func (c *ApplicationConfig) AddFlags(flagset *pflag.FlagSet) {
 	c.EventServer.AddFlags(flagset)
 	c.Database.AddFlags(flagset)
 	c.MessageBroker.AddFlags(flagset)
+	c.Audit.AddFlags(flagset)
+	c.Idempotency.AddFlags(flagset)
 }
 
 func (c *ApplicationConfig) ReadFiles() []string {
@@ -17,6 +17,8 @@ type EventServerConfig struct {
 	ConsistentHashConfig         *ConsistentHashConfig `json:"consistent_hash_config"`
 	UndeliveredResourceThreshold int                   `json:"undelivered_resource_threshold"`
 	StaleDeleteEventThreshold    int                   `json:"stale_delete_event_threshold"`
+	StatusEventRetention         int                   `json:"status_event_retention"`
+	StatusResumeWindow           int                   `json:"status_resume_window"`
 }
 
 // ConsistentHashConfig contains the configuration for the consistent hashing algorithm.
@@ -33,6 +35,8 @@ func NewEventServerConfig() *EventServerConfig {
 		ConsistentHashConfig:         NewConsistentHashConfig(),
 		UndeliveredResourceThreshold: 600,
 		StaleDeleteEventThreshold:    3600,
+		StatusEventRetention:         3600,
+		StatusResumeWindow:           60,
 	}
 }
 
@@ -58,6 +62,8 @@ func (c *EventServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.SubscriptionType, "subscription-type", c.SubscriptionType, "Sets the subscription type for resource status updates from message broker, Options: \"shared\" (only one instance receives resource status message, MQTT feature ensures exclusivity) or \"broadcast\" (all instances receive messages, hashed to determine processing instance)")
 	fs.IntVar(&c.UndeliveredResourceThreshold, "undelivered-resource-threshold", c.UndeliveredResourceThreshold, "Seconds a resource can have no status (NULL) before being re-published to the message broker. Set to 0 to disable. Default: 600 (10 minutes)")
 	fs.IntVar(&c.StaleDeleteEventThreshold, "stale-delete-event-threshold", c.StaleDeleteEventThreshold, "Seconds a resource can remain soft-deleted with an unreconciled delete event before that event is retired (the agent is assumed gone). Set to 0 to disable. Default: 3600 (1 hour)")
+	fs.IntVar(&c.StatusEventRetention, "status-event-retention", c.StatusEventRetention, "Seconds a status event is kept after it is created, a gRPC subscriber can resume within this window after it reconnects. Set to 0 to delete the events once they are dispatched, the gRPC subscribers cannot resume then. Default: 3600 (1 hour)")
+	fs.IntVar(&c.StatusResumeWindow, "status-resume-window", c.StatusResumeWindow, "Seconds before the status event of a resume cursor whose status events are replayed to a resumed gRPC subscriber as well, it must be longer than the longest status update transaction plus the clock skew between the Maestro instances. Default: 60 (1 minute)")
 	c.ConsistentHashConfig.AddFlags(fs)
 }
 
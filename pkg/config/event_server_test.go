@@ -25,6 +25,8 @@ func TestEventServerConfig(t *testing.T) {
 				},
 				UndeliveredResourceThreshold: 600,
 				StaleDeleteEventThreshold:    3600,
+				StatusEventRetention:         3600,
+				StatusResumeWindow:           60,
 			},
 		},
 		{
@@ -41,6 +43,8 @@ func TestEventServerConfig(t *testing.T) {
 				},
 				UndeliveredResourceThreshold: 600,
 				StaleDeleteEventThreshold:    3600,
+				StatusEventRetention:         3600,
+				StatusResumeWindow:           60,
 			},
 		},
 		{
@@ -60,6 +64,8 @@ func TestEventServerConfig(t *testing.T) {
 				},
 				UndeliveredResourceThreshold: 600,
 				StaleDeleteEventThreshold:    3600,
+				StatusEventRetention:         3600,
+				StatusResumeWindow:           60,
 			},
 		},
 	}
@@ -15,7 +15,12 @@ type GRPCServerConfig struct {
 	BrokerTLSCertFile       string        `json:"grpc_broker_tls_cert_file"`
 	BrokerTLSKeyFile        string        `json:"grpc_broker_tls_key_file"`
 	GRPCAuthNType           string        `json:"grpc_authn_type"`
+	BrokerGRPCAuthNType     string        `json:"grpc_broker_authn_type"`
 	GRPCAuthorizerConfig    string        `json:"grpc_authorizer_config"`
+	GRPCOIDCConfig          string        `json:"grpc_oidc_config"`
+	AuthorizerCacheAllowTTL time.Duration `json:"grpc_authorizer_cache_allow_ttl"`
+	AuthorizerCacheDenyTTL  time.Duration `json:"grpc_authorizer_cache_deny_ttl"`
+	AuthorizerCacheSize     int           `json:"grpc_authorizer_cache_size"`
 	ClientCAFile            string        `json:"grpc_client_ca_file"`
 	BrokerClientCAFile      string        `json:"grpc_broker_client_ca_file"`
 	ServerBindPort          string        `json:"server_bind_port"`
@@ -33,6 +38,10 @@ type GRPCServerConfig struct {
 	PermitPingWithoutStream bool          `json:"permit_ping_without_stream"`
 	HeartbeatCheckInterval  time.Duration `json:"heartbeatCheckInterval"`
 	HeartbeatDisable        bool          `json:"heartbeat_disable"`
+	SubscriberBufferSize    int           `json:"subscriber_buffer_size"`
+	SubscriberPolicy        string        `json:"subscriber_policy"`
+	SubscriberTimeout       time.Duration `json:"subscriber_timeout"`
+	EnableReflection        bool          `json:"enable_reflection"`
 }
 
 func NewGRPCServerConfig() *GRPCServerConfig {
@@ -61,9 +70,18 @@ func (s *GRPCServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.BrokerTLSCertFile, "grpc-broker-tls-cert-file", "", "The path to the broker tls.crt file")
 	fs.StringVar(&s.BrokerTLSKeyFile, "grpc-broker-tls-key-file", "", "The path to the broker tls.key file")
 	fs.StringVar(&s.GRPCAuthNType, "grpc-authn-type", "mock", "Specify the gRPC authentication type (e.g., mock, mtls or token)")
-	fs.StringVar(&s.GRPCAuthorizerConfig, "grpc-authorizer-config", "", "Path to the gRPC authorizer configuration file")
+	fs.StringVar(&s.BrokerGRPCAuthNType, "grpc-broker-authn-type", "mock", "Specify the gRPC broker authentication type (e.g., mock, mtls or token), the agents are not authorized with mock")
+	fs.StringVar(&s.GRPCAuthorizerConfig, "grpc-authorizer-config", "", "Path to the gRPC authorizer configuration file, either a kubeconfig or a GRPCAuthorizationPolicy file")
+	fs.StringVar(&s.GRPCOIDCConfig, "grpc-oidc-config", "", "Path to the OIDC configuration file, the tokens of the configured issuers are verified locally with the token authentication type")
+	fs.DurationVar(&s.AuthorizerCacheAllowTTL, "grpc-authorizer-cache-allow-ttl", 0, "Duration to cache the authenticated tokens and the allowed access reviews of the gRPC authorizer, a revoked token or permission is accepted until it expires, 0 to disable")
+	fs.DurationVar(&s.AuthorizerCacheDenyTTL, "grpc-authorizer-cache-deny-ttl", 0, "Duration to cache the denied access reviews of the gRPC authorizer, a granted permission is denied until it expires, 0 to disable")
+	fs.IntVar(&s.AuthorizerCacheSize, "grpc-authorizer-cache-size", 10000, "Maximum number of the cached tokens and access reviews of the gRPC authorizer respectively")
 	fs.StringVar(&s.ClientCAFile, "grpc-client-ca-file", "", "The path to the client ca file, must specify if using mtls authentication type")
 	fs.StringVar(&s.BrokerClientCAFile, "grpc-broker-client-ca-file", "", "The path to the broker client ca file")
 	fs.DurationVar(&s.HeartbeatCheckInterval, "heartbeat-check-interval", 10*time.Second, "Duration the server send heartbeat messages")
 	fs.BoolVar(&s.HeartbeatDisable, "heartbeat-disable", false, "Disable heartbeat messages from server to clients")
+	fs.IntVar(&s.SubscriberBufferSize, "grpc-subscriber-buffer-size", 100, "Number of resource status events buffered for each status subscriber")
+	fs.StringVar(&s.SubscriberPolicy, "grpc-subscriber-policy", "block", "Policy for the status subscribers whose buffer is full (block, drop-oldest or disconnect)")
+	fs.DurationVar(&s.SubscriberTimeout, "grpc-subscriber-timeout", 30*time.Second, "Duration the buffer of a status subscriber can stay full before it is disconnected with the disconnect policy")
+	fs.BoolVar(&s.EnableReflection, "grpc-enable-reflection", false, "Enable the gRPC server reflection on the gRPC server and broker, e.g. for grpcurl")
 }
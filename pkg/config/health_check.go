@@ -8,6 +8,7 @@ type HealthCheckConfig struct {
 	BindPort           string `json:"bind_port"`
 	EnableHTTPS        bool   `json:"enable_https"`
 	HeartbeartInterval int    `json:"heartbeat_interval"`
+	MaxEventQueueLag   int    `json:"max_event_queue_lag"`
 }
 
 func NewHealthCheckConfig() *HealthCheckConfig {
@@ -15,6 +16,7 @@ func NewHealthCheckConfig() *HealthCheckConfig {
 		BindPort:           "8083",
 		EnableHTTPS:        false,
 		HeartbeartInterval: 15,
+		MaxEventQueueLag:   0,
 	}
 }
 
@@ -22,6 +24,7 @@ func (c *HealthCheckConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.BindPort, "health-check-server-bindport", c.BindPort, "Health check server bind port")
 	fs.BoolVar(&c.EnableHTTPS, "enable-health-check-https", c.EnableHTTPS, "Enable HTTPS for health check server")
 	fs.IntVar(&c.HeartbeartInterval, "heartbeat-interval", c.HeartbeartInterval, "Heartbeat interval for health check server")
+	fs.IntVar(&c.MaxEventQueueLag, "health-check-max-event-queue-lag", c.MaxEventQueueLag, "Seconds the oldest unreconciled resource event can wait before the server is reported as not ready. Set to 0 to disable. Default: 0 (disabled)")
 }
 
 func (c *HealthCheckConfig) ReadFiles() error {
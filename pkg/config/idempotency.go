@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type IdempotencyConfig struct {
+	KeyTTL time.Duration `json:"key_ttl"`
+}
+
+func NewIdempotencyConfig() *IdempotencyConfig {
+	return &IdempotencyConfig{
+		KeyTTL: 24 * time.Hour,
+	}
+}
+
+func (c *IdempotencyConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.KeyTTL, "idempotency-key-ttl", c.KeyTTL, "How long the result of a request with an idempotency key is stored and replayed on retry. Set to 0 to ignore the idempotency keys")
+}
+
+func (c *IdempotencyConfig) ReadFiles() error {
+	return nil
+}
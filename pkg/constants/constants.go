@@ -9,4 +9,28 @@ const (
 	// MinTokenLifeThreshold defines the minimum remaining lifetime (in seconds) of the access token before
 	// it should be refreshed.
 	MinTokenLifeThreshold = 60.0
+
+	// ExtensionIdempotencyKey is the CloudEvent extension that carries the idempotency key of a gRPC request.
+	ExtensionIdempotencyKey = "idempotencykey"
+	// IdempotencyKeyHeader is the HTTP header that carries the idempotency key of a REST request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// ExtensionDryRun is the CloudEvent extension that requests a dry-run of a gRPC request, the request is
+	// checked but not persisted.
+	ExtensionDryRun = "dryrun"
+	// DryRunResultMetadataKey is the gRPC response header that carries the would-be resource bundle (JSON)
+	// of a dry-run request.
+	DryRunResultMetadataKey = "maestro-dry-run-result-bin"
+	// DryRunQueryParam is the REST query parameter that requests a dry-run of a request.
+	DryRunQueryParam = "dryRun"
+
+	// ExtensionStatusSequence is the CloudEvent extension that carries the sequence number of a status event sent
+	// to the status subscribers, the sequence numbers increase monotonically across all sources.
+	ExtensionStatusSequence = "statussequence"
+	// ResumeCursorMetadataKey is the gRPC request header of a status subscription that carries the sequence number
+	// of the last status event received by the subscriber, the missed status events are replayed after it.
+	ResumeCursorMetadataKey = "maestro-resume-cursor"
+	// LabelSelectorMetadataKey is the gRPC request header of a status subscription that carries a label selector,
+	// only the status of the resources whose work meta labels match it is sent to the subscriber.
+	LabelSelectorMetadataKey = "maestro-label-selector"
 )
@@ -6,12 +6,14 @@ import (
 	"math"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/services"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 /*
@@ -170,6 +172,14 @@ func (km *KindControllerManager) handleEvent(ctx context.Context, id string) (bo
 		specEventReconcileDuration.WithLabelValues(string(event.EventType)).Observe(time.Since(startTime).Seconds())
 	}()
 
+	// resume the trace of the request that created the event, the handlers propagate it to the agent
+	reqContext, span := tracing.StartSpan(reqContext, "maestro.spec-event."+string(event.EventType),
+		event.TraceParent, event.TraceState,
+		attribute.String("maestro.event.id", event.ID),
+		attribute.String("maestro.resource.id", event.SourceID),
+	)
+	defer span.End()
+
 	source, found := km.controllers[event.Source]
 	if !found {
 		logger.Info("No controllers found", "source", event.Source)
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// IdempotencyPurger periodically deletes the expired idempotency records. Deleting the expired
+// records is safe to run on every Maestro instance, so it does not take an advisory lock.
+type IdempotencyPurger struct {
+	idempotency services.IdempotencyService
+}
+
+func NewIdempotencyPurger(idempotency services.IdempotencyService) *IdempotencyPurger {
+	return &IdempotencyPurger{
+		idempotency: idempotency,
+	}
+}
+
+func (p *IdempotencyPurger) Run(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	count, svcErr := p.idempotency.PurgeExpired(ctx)
+	if svcErr != nil {
+		logger.Error(svcErr, "Failed to purge expired idempotency records")
+		return
+	}
+
+	if count > 0 {
+		logger.V(4).Info("Purged expired idempotency records", "count", count)
+	}
+}
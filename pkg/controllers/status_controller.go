@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -12,6 +13,7 @@ import (
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/services"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 const StatusEventID ControllerHandlerContextKey = "status_event"
@@ -23,17 +25,22 @@ type StatusController struct {
 	statusEvents     services.StatusEventService
 	instanceDao      dao.InstanceDao
 	eventInstanceDao dao.EventInstanceDao
-	eventsQueue      workqueue.TypedRateLimitingInterface[string]
+	// eventRetention is how long the status events are kept, so that the resumed subscribers can replay them. The
+	// kept status events are deleted by the StatusEventPurger instead of once they are dispatched
+	eventRetention time.Duration
+	eventsQueue    workqueue.TypedRateLimitingInterface[string]
 }
 
 func NewStatusController(statusEvents services.StatusEventService,
 	instanceDao dao.InstanceDao,
-	eventInstanceDao dao.EventInstanceDao) *StatusController {
+	eventInstanceDao dao.EventInstanceDao,
+	eventRetention time.Duration) *StatusController {
 	return &StatusController{
 		controllers:      map[api.StatusEventType][]StatusHandlerFunc{},
 		statusEvents:     statusEvents,
 		instanceDao:      instanceDao,
 		eventInstanceDao: eventInstanceDao,
+		eventRetention:   eventRetention,
 		eventsQueue: workqueue.NewTypedRateLimitingQueueWithConfig(
 			workqueue.DefaultTypedControllerRateLimiter[string](),
 			workqueue.TypedRateLimitingQueueConfig[string]{
@@ -55,7 +62,9 @@ func (sc *StatusController) Run(ctx context.Context) {
 	defer sc.eventsQueue.ShutDown()
 
 	// use a jitter to avoid multiple instances syncing the events at the same time
-	go wait.JitterUntilWithContext(ctx, sc.syncStatusEvents, defaultEventsSyncPeriod, 0.25, true)
+	if sc.eventRetention <= 0 {
+		go wait.JitterUntilWithContext(ctx, sc.syncStatusEvents, defaultEventsSyncPeriod, 0.25, true)
+	}
 
 	go wait.JitterUntilWithContext(ctx, sc.reportNotificationQueueUsage, defaultNotificationQueueReportPeriod, 0.25, true)
 
@@ -128,6 +137,14 @@ func (sc *StatusController) handleStatusEvent(ctx context.Context, id string) er
 		statusEventReconcileDuration.WithLabelValues(string(statusEvent.StatusEventType)).Observe(time.Since(startTime).Seconds())
 	}()
 
+	// resume the trace of the status update that created the event, the handlers propagate it to the subscribers
+	reqContext, span := tracing.StartSpan(reqContext, "maestro.status-event."+string(statusEvent.StatusEventType),
+		statusEvent.TraceParent, statusEvent.TraceState,
+		attribute.String("maestro.event.id", statusEvent.ID),
+		attribute.String("maestro.resource.id", statusEvent.ResourceID),
+	)
+	defer span.End()
+
 	handlerFns, found := sc.controllers[statusEvent.StatusEventType]
 	if !found {
 		logger.Info("No handler functions found for status event", "statusEventType", statusEvent.StatusEventType)
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// StatusEventPurger periodically deletes the status events that are older than the retention. The status events are
+// deleted by their age only, so the events that an instance never dispatched, e.g. it became ready after the events
+// were created, are deleted as well. Deleting the old events is safe to run on every Maestro instance, so it does not
+// take an advisory lock.
+type StatusEventPurger struct {
+	statusEvents services.StatusEventService
+	retention    time.Duration
+}
+
+func NewStatusEventPurger(statusEvents services.StatusEventService, retention time.Duration) *StatusEventPurger {
+	return &StatusEventPurger{
+		statusEvents: statusEvents,
+		retention:    retention,
+	}
+}
+
+func (p *StatusEventPurger) Run(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	count, svcErr := p.statusEvents.DeleteEventsCreatedBefore(ctx, p.retention)
+	if svcErr != nil {
+		logger.Error(svcErr, "Failed to purge the status events older than the retention", "retention", p.retention)
+		return
+	}
+
+	if count > 0 {
+		logger.V(4).Info("Purged the status events older than the retention", "count", count, "retention", p.retention)
+	}
+}
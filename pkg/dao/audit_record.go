@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+// AuditRecordFilter filters the audit records, the zero value of a field matches all the records.
+type AuditRecordFilter struct {
+	Actor string
+	Since time.Time
+	Until time.Time
+	// Offset and Limit select a page of the matched records, which are ordered by the creation time.
+	// All the matched records are returned if the Limit is not positive.
+	Offset int
+	Limit  int
+}
+
+type AuditRecordDao interface {
+	Create(ctx context.Context, record *api.AuditRecord) (*api.AuditRecord, error)
+	// List returns the records that match the filter and the total number of the matched records.
+	List(ctx context.Context, filter AuditRecordFilter) (api.AuditRecordList, int64, error)
+}
+
+var _ AuditRecordDao = &sqlAuditRecordDao{}
+
+type sqlAuditRecordDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewAuditRecordDao(sessionFactory *db.SessionFactory) AuditRecordDao {
+	return &sqlAuditRecordDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlAuditRecordDao) Create(ctx context.Context, record *api.AuditRecord) (*api.AuditRecord, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(record).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return record, nil
+}
+
+func (d *sqlAuditRecordDao) List(ctx context.Context, filter AuditRecordFilter) (api.AuditRecordList, int64, error) {
+	g2 := (*d.sessionFactory).New(ctx).Model(&api.AuditRecord{})
+	if filter.Actor != "" {
+		g2 = g2.Where("actor = ?", filter.Actor)
+	}
+	if !filter.Since.IsZero() {
+		g2 = g2.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		g2 = g2.Where("created_at < ?", filter.Until)
+	}
+
+	var total int64
+	if err := g2.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	g2 = g2.Order("created_at").Offset(filter.Offset)
+	if filter.Limit > 0 {
+		g2 = g2.Limit(filter.Limit)
+	}
+	records := api.AuditRecordList{}
+	if err := g2.Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+	return records, total, nil
+}
@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+type IdempotencyRecordDao interface {
+	Get(ctx context.Context, key string) (*api.IdempotencyRecord, error)
+	// Reserve creates the record if its key does not exist or the existing record is expired,
+	// it returns false if the key is held by another record.
+	Reserve(ctx context.Context, record *api.IdempotencyRecord) (bool, error)
+	Complete(ctx context.Context, key string, result []byte, expiresAt time.Time) error
+	Delete(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+var _ IdempotencyRecordDao = &sqlIdempotencyRecordDao{}
+
+type sqlIdempotencyRecordDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewIdempotencyRecordDao(sessionFactory *db.SessionFactory) IdempotencyRecordDao {
+	return &sqlIdempotencyRecordDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlIdempotencyRecordDao) Get(ctx context.Context, key string) (*api.IdempotencyRecord, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var record api.IdempotencyRecord
+	if err := g2.Take(&record, "key = ?", key).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (d *sqlIdempotencyRecordDao) Reserve(ctx context.Context, record *api.IdempotencyRecord) (bool, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	// ON CONFLICT does not fail the transaction of the request, and it waits for the concurrent transaction
+	// that holds the same key, so only one of the concurrent requests can reserve the key.
+	result := g2.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"request_hash", "completed", "result", "created_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lt{Column: clause.Column{Table: "idempotency_records", Name: "expires_at"}, Value: time.Now()},
+		}},
+	}).Create(record)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (d *sqlIdempotencyRecordDao) Complete(ctx context.Context, key string, result []byte, expiresAt time.Time) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Model(&api.IdempotencyRecord{}).Where("key = ?", key).Updates(map[string]interface{}{
+		"completed":  true,
+		"result":     result,
+		"expires_at": expiresAt,
+	}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlIdempotencyRecordDao) Delete(ctx context.Context, key string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Where("key = ?", key).Delete(&api.IdempotencyRecord{}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlIdempotencyRecordDao) DeleteExpired(ctx context.Context) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Where("expires_at < ?", time.Now()).Delete(&api.IdempotencyRecord{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
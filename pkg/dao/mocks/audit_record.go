@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.AuditRecordDao = &auditRecordDaoMock{}
+
+type auditRecordDaoMock struct {
+	records api.AuditRecordList
+}
+
+func NewAuditRecordDao() *auditRecordDaoMock {
+	return &auditRecordDaoMock{}
+}
+
+func (d *auditRecordDaoMock) Create(ctx context.Context, record *api.AuditRecord) (*api.AuditRecord, error) {
+	d.records = append(d.records, record)
+	return record, nil
+}
+
+func (d *auditRecordDaoMock) List(ctx context.Context, filter dao.AuditRecordFilter) (api.AuditRecordList, int64, error) {
+	records := api.AuditRecordList{}
+	for _, record := range d.records {
+		if filter.Actor != "" && record.Actor != filter.Actor {
+			continue
+		}
+		if !filter.Since.IsZero() && record.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && !record.CreatedAt.Before(filter.Until) {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	total := int64(len(records))
+	if filter.Offset >= len(records) {
+		return api.AuditRecordList{}, total, nil
+	}
+	records = records[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(records) {
+		records = records[:filter.Limit]
+	}
+	return records, total, nil
+}
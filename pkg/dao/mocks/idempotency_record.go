@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.IdempotencyRecordDao = &idempotencyRecordDaoMock{}
+
+type idempotencyRecordDaoMock struct {
+	records map[string]*api.IdempotencyRecord
+}
+
+func NewIdempotencyRecordDao() *idempotencyRecordDaoMock {
+	return &idempotencyRecordDaoMock{records: map[string]*api.IdempotencyRecord{}}
+}
+
+func (d *idempotencyRecordDaoMock) Get(ctx context.Context, key string) (*api.IdempotencyRecord, error) {
+	record, ok := d.records[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return record, nil
+}
+
+func (d *idempotencyRecordDaoMock) Reserve(ctx context.Context, record *api.IdempotencyRecord) (bool, error) {
+	if found, ok := d.records[record.Key]; ok && !found.ExpiresAt.Before(time.Now()) {
+		return false, nil
+	}
+	d.records[record.Key] = record
+	return true, nil
+}
+
+func (d *idempotencyRecordDaoMock) Complete(ctx context.Context, key string, result []byte, expiresAt time.Time) error {
+	record, ok := d.records[key]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	record.Completed = true
+	record.Result = result
+	record.ExpiresAt = expiresAt
+	return nil
+}
+
+func (d *idempotencyRecordDaoMock) Delete(ctx context.Context, key string) error {
+	delete(d.records, key)
+	return nil
+}
+
+func (d *idempotencyRecordDaoMock) DeleteExpired(ctx context.Context) (int64, error) {
+	var count int64
+	for key, record := range d.records {
+		if record.ExpiresAt.Before(time.Now()) {
+			delete(d.records, key)
+			count++
+		}
+	}
+	return count, nil
+}
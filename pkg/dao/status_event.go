@@ -3,7 +3,9 @@ package dao
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-online/maestro/pkg/api"
@@ -20,6 +22,10 @@ type StatusEventDao interface {
 
 	DeleteAllReconciledEvents(ctx context.Context) error
 	DeleteAllEvents(ctx context.Context, eventIDs []string) error
+	DeleteEventsCreatedBefore(ctx context.Context, before time.Duration) (int64, error)
+	FindBySourceAfterSequence(ctx context.Context, source string, sequence int64, limit int) (api.StatusEventList, error)
+	FirstSequenceCreatedSince(ctx context.Context, source string, since time.Time) (int64, error)
+	GetBySequence(ctx context.Context, sequence int64) (*api.StatusEvent, error)
 	FindAllUnreconciledEvents(ctx context.Context) (api.StatusEventList, error)
 	GetNotificationQueueUsage(ctx context.Context) (*float64, error)
 }
@@ -109,6 +115,60 @@ func (d *sqlStatusEventDao) DeleteAllEvents(ctx context.Context, eventIDs []stri
 	return nil
 }
 
+// DeleteEventsCreatedBefore deletes the status events that were created longer than the given duration ago by the
+// clock of the database, whether they are dispatched or not.
+func (d *sqlStatusEventDao) DeleteEventsCreatedBefore(ctx context.Context, before time.Duration) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Unscoped().Omit(clause.Associations).
+		Where("created_at < now() - make_interval(secs => ?)", before.Seconds()).
+		Delete(&api.StatusEvent{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// FindBySourceAfterSequence returns the status events of a source whose sequence number is greater than the
+// given sequence, ordered by the sequence number.
+func (d *sqlStatusEventDao) FindBySourceAfterSequence(ctx context.Context, source string, sequence int64, limit int) (api.StatusEventList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	statusEvents := api.StatusEventList{}
+	if err := g2.Where("resource_source = ? AND sequence_number > ?", source, sequence).
+		Order("sequence_number").
+		Limit(limit).
+		Find(&statusEvents).Error; err != nil {
+		return nil, err
+	}
+	return statusEvents, nil
+}
+
+// FirstSequenceCreatedSince returns the lowest sequence number of the status events of a source that were created
+// at or after the given time.
+func (d *sqlStatusEventDao) FirstSequenceCreatedSince(ctx context.Context, source string, since time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var sequence *int64
+	if err := g2.Model(&api.StatusEvent{}).
+		Select("MIN(sequence_number)").
+		Where("resource_source = ? AND created_at >= ?", source, since).
+		Scan(&sequence).Error; err != nil {
+		return 0, err
+	}
+	if sequence == nil {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return *sequence, nil
+}
+
+func (d *sqlStatusEventDao) GetBySequence(ctx context.Context, sequence int64) (*api.StatusEvent, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var statusEvent api.StatusEvent
+	if err := g2.Take(&statusEvent, "sequence_number = ?", sequence).Error; err != nil {
+		return nil, err
+	}
+	return &statusEvent, nil
+}
+
 func (d *sqlStatusEventDao) FindAllUnreconciledEvents(ctx context.Context) (api.StatusEventList, error) {
 	g2 := (*d.sessionFactory).New(ctx)
 	statusEvents := api.StatusEventList{}
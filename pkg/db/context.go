@@ -57,3 +57,14 @@ func MarkForRollback(ctx context.Context, err error) {
 	transaction.SetRollbackFlag(true)
 	logger.Info("Marked transaction for rollback", "error", err)
 }
+
+// AfterCommit runs the given function once the transaction stored in the context is committed, the function
+// runs immediately if there is no transaction in the context
+func AfterCommit(ctx context.Context, fn func()) {
+	transaction, ok := dbContext.Transaction(ctx)
+	if !ok || transaction == nil {
+		fn()
+		return
+	}
+	transaction.AfterCommit(fn)
+}
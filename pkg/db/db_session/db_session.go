@@ -1,9 +1,38 @@
 package db_session
 
-import "sync"
+import (
+	"fmt"
+	"sync"
+)
 
 const (
 	disable = "disable"
 )
 
 var once sync.Once
+
+// listenerStates tracks the health of the database listeners by channel.
+type listenerStates struct {
+	mu     sync.RWMutex
+	states map[string]error
+}
+
+func (s *listenerStates) set(channel string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.states == nil {
+		s.states = map[string]error{}
+	}
+	s.states[channel] = err
+}
+
+// check returns nil if the listener for the given channel is started and its connection is healthy.
+func (s *listenerStates) check(channel string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	err, ok := s.states[channel]
+	if !ok {
+		return fmt.Errorf("the listener for channel %s is not started", channel)
+	}
+	return err
+}
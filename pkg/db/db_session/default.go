@@ -31,6 +31,8 @@ type Default struct {
 	// - to setup/close connection because GORM V2 removed gorm.Close()
 	// - to work with pq.CopyIn because connection returned by GORM V2 gorm.DB() in "not the same"
 	db *sql.DB
+
+	listeners listenerStates
 }
 
 var _ db.SessionFactory = &Default{}
@@ -138,12 +140,13 @@ func (f *Default) DirectDB() *sql.DB {
 	return f.db
 }
 
-func waitForNotification(ctx context.Context, l *pq.Listener, dbConfig *config.DatabaseConfig, channel string, callback func(id string)) {
+func waitForNotification(ctx context.Context, l *pq.Listener, dbConfig *config.DatabaseConfig, states *listenerStates, channel string, callback func(id string)) {
 	logger := klog.FromContext(ctx)
 	for {
 		select {
 		case <-ctx.Done():
 			logger.Info("Context cancelled, stopping channel monitor", "channel", channel)
+			states.set(channel, fmt.Errorf("the listener for channel %s is stopped", channel))
 			return
 		case n := <-l.Notify:
 			if n != nil {
@@ -152,28 +155,38 @@ func waitForNotification(ctx context.Context, l *pq.Listener, dbConfig *config.D
 			} else {
 				// nil notification means the connection was closed
 				logger.Info("recreate the listener for channel due to the connection loss", "channel", channel)
+				states.set(channel, fmt.Errorf("the listener for channel %s lost the connection", channel))
 				l.Close()
 				// recreate the listener
-				l = newListener(ctx, dbConfig, channel)
+				l = newListener(ctx, dbConfig, states, channel)
 			}
 		case <-time.After(10 * time.Second):
 			if err := l.Ping(); err != nil {
 				logger.Info("recreate the listener due to ping failed", "error", err)
+				states.set(channel, fmt.Errorf("the listener for channel %s failed to ping: %v", channel, err))
 				l.Close()
 				// recreate the listener
-				l = newListener(ctx, dbConfig, channel)
+				l = newListener(ctx, dbConfig, states, channel)
 			}
 		}
 	}
 }
 
-func newListener(ctx context.Context, dbConfig *config.DatabaseConfig, channel string) *pq.Listener {
+func newListener(ctx context.Context, dbConfig *config.DatabaseConfig, states *listenerStates, channel string) *pq.Listener {
 	logger := klog.FromContext(ctx)
 
 	plog := func(ev pq.ListenerEventType, err error) {
 		if err != nil {
 			logger.Error(err, "Listener: the state of the underlying database connection changes", "eventType", ev)
 		}
+
+		// track the state of the underlying database connection for the health check
+		switch ev {
+		case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+			states.set(channel, nil)
+		case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+			states.set(channel, fmt.Errorf("the listener for channel %s is disconnected: %v", channel, err))
+		}
 	}
 	connstr := dbConfig.ConnectionString(true)
 	// append the password to the connection string
@@ -192,19 +205,24 @@ func newListener(ctx context.Context, dbConfig *config.DatabaseConfig, channel s
 	if err != nil {
 		panic(err)
 	}
+	states.set(channel, nil)
 
 	return listener
 }
 
 func (f *Default) NewListener(ctx context.Context, channel string, callback func(id string)) *pq.Listener {
 	logger := klog.FromContext(ctx)
-	listener := newListener(ctx, f.config, channel)
+	listener := newListener(ctx, f.config, &f.listeners, channel)
 
 	logger.Info("Starting listener", "channel", channel)
-	go waitForNotification(ctx, listener, f.config, channel, callback)
+	go waitForNotification(ctx, listener, f.config, &f.listeners, channel, callback)
 	return listener
 }
 
+func (f *Default) CheckListener(channel string) error {
+	return f.listeners.check(channel)
+}
+
 func (f *Default) New(ctx context.Context) *gorm.DB {
 	conn := f.g2.Session(&gorm.Session{
 		Context: ctx,
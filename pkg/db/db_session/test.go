@@ -31,6 +31,8 @@ type Test struct {
 	db *sql.DB
 
 	wasDisconnected bool
+
+	listeners listenerStates
 }
 
 var _ db.SessionFactory = &Test{}
@@ -220,7 +222,11 @@ func (f *Test) ResetDB() {
 }
 
 func (f *Test) NewListener(ctx context.Context, channel string, callback func(id string)) *pq.Listener {
-	listener := newListener(ctx, f.config, channel)
-	go waitForNotification(ctx, listener, f.config, channel, callback)
+	listener := newListener(ctx, f.config, &f.listeners, channel)
+	go waitForNotification(ctx, listener, f.config, &f.listeners, channel, callback)
 	return listener
 }
+
+func (f *Test) CheckListener(channel string) error {
+	return f.listeners.check(channel)
+}
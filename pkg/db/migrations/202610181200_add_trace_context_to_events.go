@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addTraceContextToEvents() *gormigrate.Migration {
+	type Event struct {
+		TraceParent string
+		TraceState  string
+	}
+
+	type StatusEvent struct {
+		TraceParent string
+		TraceState  string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610181200",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&Event{}); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&StatusEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&Event{}, &StatusEvent{}} {
+				if err := tx.Migrator().DropColumn(model, "trace_state"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(model, "trace_parent"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+func addAuditRecords() *gormigrate.Migration {
+	type AuditRecord struct {
+		ID           string         `gorm:"primary_key"`
+		CreatedAt    time.Time      `gorm:"index"`
+		Actor        string         `gorm:"index"`
+		Groups       pq.StringArray `gorm:"type:text[]"`
+		Source       string
+		Action       string
+		ResourceType string
+		ResourceID   string `gorm:"index"`
+		Version      int32
+		PayloadHash  string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610181300",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AuditRecord{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&AuditRecord{})
+		},
+	}
+}
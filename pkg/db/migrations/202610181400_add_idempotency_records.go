@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addIdempotencyRecords() *gormigrate.Migration {
+	type IdempotencyRecord struct {
+		Key         string `gorm:"primaryKey"`
+		RequestHash string
+		Completed   bool
+		Result      []byte
+		CreatedAt   time.Time
+		ExpiresAt   time.Time `gorm:"index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610181400",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&IdempotencyRecord{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&IdempotencyRecord{})
+		},
+	}
+}
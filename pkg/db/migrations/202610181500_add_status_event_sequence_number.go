@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addStatusEventSequenceNumber numbers the status events, the existing events are numbered when the column is added.
+// The numbers follow the order of the inserts, not of the commits, so a resumed status subscription replays the events
+// created within a window before its cursor as well.
+func addStatusEventSequenceNumber() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610181500",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE status_events ADD COLUMN IF NOT EXISTS sequence_number BIGSERIAL;").Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_status_events_source_sequence ON status_events (resource_source, sequence_number);").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_status_events_source_sequence;").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE status_events DROP COLUMN IF EXISTS sequence_number;").Error
+		},
+	}
+}
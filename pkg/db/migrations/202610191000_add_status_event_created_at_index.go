@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// addStatusEventCreatedAtIndex indexes the status events by their creation time, the status events are purged by their
+// age and a resumed status subscription looks up the events created within a window before its cursor.
+func addStatusEventCreatedAtIndex() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_status_events_source_created_at ON status_events (resource_source, created_at);").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP INDEX IF EXISTS idx_status_events_source_created_at;").Error
+		},
+	}
+}
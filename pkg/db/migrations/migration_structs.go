@@ -35,6 +35,11 @@ var MigrationList = []*gormigrate.Migration{
 	addEventInstances(),
 	addLastHeartBeatAndReadyColumnInServerInstancesTable(),
 	alterEventInstances(),
+	addTraceContextToEvents(),
+	addAuditRecords(),
+	addIdempotencyRecords(),
+	addStatusEventSequenceNumber(),
+	addStatusEventCreatedAtIndex(),
 }
 
 // CleanUpDirtyData clean up the dirty data before migrating the tables.
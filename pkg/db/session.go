@@ -18,4 +18,6 @@ type SessionFactory interface {
 	Close() error
 	ResetDB()
 	NewListener(ctx context.Context, channel string, callback func(id string)) *pq.Listener
+	// CheckListener returns an error if the listener for the given channel is not started or not healthy.
+	CheckListener(channel string) error
 }
@@ -14,6 +14,7 @@ type Transaction struct {
 	rollbackFlag bool
 	tx           *sql.Tx
 	txid         int64
+	afterCommit  []func()
 }
 
 // Build Creates a new transaction object
@@ -48,7 +49,21 @@ func (tx *Transaction) Commit() error {
 	// do *not* call commit on the underlying transaction itself. Gorm does that.
 	err := tx.tx.Commit()
 	tx.tx = nil
-	return err
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range tx.afterCommit {
+		fn()
+	}
+	tx.afterCommit = nil
+	return nil
+}
+
+// AfterCommit registers a function to run once the transaction is committed, it never runs if the
+// transaction is rolled back or fails to commit.
+func (tx *Transaction) AfterCommit(fn func()) {
+	tx.afterCommit = append(tx.afterCommit, fn)
 }
 
 // rollback ends the transaction by rolling back
@@ -59,6 +74,7 @@ func (tx *Transaction) Rollback() error {
 	}
 	err := tx.tx.Rollback()
 	tx.tx = nil
+	tx.afterCommit = nil
 	return err
 }
 
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+type auditRecordHandler struct {
+	auditRecords services.AuditRecordService
+}
+
+func NewAuditRecordHandler(auditRecords services.AuditRecordService) *auditRecordHandler {
+	return &auditRecordHandler{
+		auditRecords: auditRecords,
+	}
+}
+
+// List returns the audit records filtered by the actor and the time range, the time range is
+// specified by the since (inclusive) and until (exclusive) parameters in RFC 3339 format.
+func (h auditRecordHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			query := r.URL.Query()
+
+			listArgs := services.NewListArguments(query)
+			if listArgs.Page < 1 {
+				return nil, errors.BadRequest("page must be a positive number")
+			}
+			filter := dao.AuditRecordFilter{
+				Actor:  strings.TrimSpace(query.Get("actor")),
+				Offset: (listArgs.Page - 1) * int(listArgs.Size),
+				Limit:  int(listArgs.Size),
+			}
+			var err error
+			if filter.Since, err = parseTimeParam(query.Get("since")); err != nil {
+				return nil, errors.BadRequest("invalid since: %s", err)
+			}
+			if filter.Until, err = parseTimeParam(query.Get("until")); err != nil {
+				return nil, errors.BadRequest("invalid until: %s", err)
+			}
+
+			records, total, svcErr := h.auditRecords.List(ctx, filter)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+			return presenters.PresentAuditRecordList(records, listArgs.Page, total), nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// parseTimeParam parses a RFC 3339 time, the zero time is returned if the value is empty.
+func parseTimeParam(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -16,16 +16,19 @@ import (
 var _ RestHandler = consumerHandler{}
 
 type consumerHandler struct {
-	consumer services.ConsumerService
-	resource services.ResourceService
-	generic  services.GenericService
+	consumer    services.ConsumerService
+	resource    services.ResourceService
+	generic     services.GenericService
+	idempotency services.IdempotencyService
 }
 
-func NewConsumerHandler(consumer services.ConsumerService, resource services.ResourceService, generic services.GenericService) *consumerHandler {
+func NewConsumerHandler(consumer services.ConsumerService, resource services.ResourceService, generic services.GenericService,
+	idempotency services.IdempotencyService) *consumerHandler {
 	return &consumerHandler{
-		consumer: consumer,
-		resource: resource,
-		generic:  generic,
+		consumer:    consumer,
+		resource:    resource,
+		generic:     generic,
+		idempotency: idempotency,
 	}
 }
 
@@ -36,7 +39,7 @@ func (h consumerHandler) Create(w http.ResponseWriter, r *http.Request) {
 		[]validate{
 			validateEmpty(&consumer, "Id", "id"),
 		},
-		func() (interface{}, *errors.ServiceError) {
+		idempotentAction(w, r, h.idempotency, "consumers", &consumer, func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
 			consumer := presenters.ConvertConsumer(consumer)
 			consumer, err := h.consumer.Create(ctx, consumer)
@@ -44,7 +47,7 @@ func (h consumerHandler) Create(w http.ResponseWriter, r *http.Request) {
 				return nil, err
 			}
 			return presenters.PresentConsumer(consumer), nil
-		},
+		}),
 		handleError,
 	}
 
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/audit"
+	"github.com/openshift-online/maestro/pkg/constants"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// IdempotentReplayedHeader is set to true on the response that is replayed for a retried request.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotentAction wraps the action of a create request with the Idempotency-Key header. The result of the
+// first successful request is stored and returned to the retries with the same key instead of running the
+// action again. The keys are scoped by the resource type and the actor of the request, e.g. the same key can be used
+// for different types, and a client cannot get the result of another client's request by reusing its key.
+func idempotentAction(w http.ResponseWriter, r *http.Request, idempotency services.IdempotencyService,
+	resourceType string, request interface{}, action httpAction) httpAction {
+	key := r.Header.Get(constants.IdempotencyKeyHeader)
+	if key == "" || idempotency == nil {
+		return action
+	}
+
+	return func() (interface{}, *errors.ServiceError) {
+		ctx := r.Context()
+		// the user is escaped, so that a user with a colon cannot take the key of another user
+		key := fmt.Sprintf("rest:%s:%s:%s", resourceType, url.QueryEscape(audit.ActorFromContext(ctx).User), key)
+		requestJson, err := json.Marshal(request)
+		if err != nil {
+			return nil, errors.GeneralError("Unable to marshal the request: %s", err)
+		}
+
+		replayed, svcErr := idempotency.Begin(ctx, key, requestJson)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		if replayed != nil {
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			return json.RawMessage(replayed.Result), nil
+		}
+
+		result, svcErr := action()
+		if svcErr != nil {
+			// the request is in a transaction, the key is released by the rollback as well,
+			// release it explicitly in case the failure does not roll back the transaction
+			if abortErr := idempotency.Abort(ctx, key); abortErr != nil {
+				klog.FromContext(ctx).Error(abortErr, "Unable to release the idempotency key", "idempotencyKey", key)
+			}
+			return nil, svcErr
+		}
+
+		resultJson, err := json.Marshal(result)
+		if err != nil {
+			return nil, errors.GeneralError("Unable to marshal the result: %s", err)
+		}
+		if svcErr := idempotency.Complete(ctx, key, resultJson); svcErr != nil {
+			return nil, svcErr
+		}
+		return result, nil
+	}
+}
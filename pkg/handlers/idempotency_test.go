@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/audit"
+	"github.com/openshift-online/maestro/pkg/constants"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+func TestIdempotentActionIsScopedByActor(t *testing.T) {
+	RegisterTestingT(t)
+
+	idempotency := services.NewIdempotencyService(mocks.NewIdempotencyRecordDao(), time.Hour)
+	request := map[string]string{"name": "cluster1"}
+
+	create := func(user string) (interface{}, http.Header) {
+		r := httptest.NewRequest(http.MethodPost, "/api/maestro/v1/consumers", nil)
+		r.Header.Set(constants.IdempotencyKeyHeader, "k1")
+		r = r.WithContext(audit.WithActor(r.Context(), audit.Actor{User: user, Source: audit.SourceREST}))
+		w := httptest.NewRecorder()
+
+		result, svcErr := idempotentAction(w, r, idempotency, "consumers", request, func() (interface{}, *errors.ServiceError) {
+			return map[string]string{"id": user}, nil
+		})()
+		Expect(svcErr).To(BeNil())
+		return result, w.Header()
+	}
+
+	// the same key of another user does not replay the result of the first user
+	result, header := create("alice")
+	Expect(result).To(Equal(map[string]string{"id": "alice"}))
+	Expect(header.Get(IdempotentReplayedHeader)).To(BeEmpty())
+
+	result, header = create("bob")
+	Expect(result).To(Equal(map[string]string{"id": "bob"}))
+	Expect(header.Get(IdempotentReplayedHeader)).To(BeEmpty())
+
+	// the retry of the first user replays its result
+	result, header = create("alice")
+	Expect(result).To(Equal(json.RawMessage(`{"id":"alice"}`)))
+	Expect(header.Get(IdempotentReplayedHeader)).To(Equal("true"))
+}
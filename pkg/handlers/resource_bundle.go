@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
@@ -26,14 +27,73 @@ func NewResourceBundleHandler(resource services.ResourceService, generic service
 	}
 }
 
+// Create checks the creation of a resource bundle and returns the would-be resource bundle, only the dry-run
+// is supported, the resource bundles are created by publishing them over gRPC.
 func (h resourceBundleHandler) Create(w http.ResponseWriter, r *http.Request) {
-	// not implemented
-	http.Error(w, "Not Implemented Yet", http.StatusNotImplemented)
+	var bundle openapi.ResourceBundle
+	cfg := &handlerConfig{
+		&bundle,
+		[]validate{
+			validateDryRun(r),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			if bundle.Id == nil || *bundle.Id == "" {
+				bundle.Id = openapi.PtrString(api.NewID())
+			}
+			resource, err := presenters.ConvertResourceBundle(bundle, cetypes.CreateRequestAction)
+			if err != nil {
+				return nil, errors.MalformedRequest("Invalid resource bundle: %s", err)
+			}
+			resource, serviceErr := h.resource.DryRunCreate(ctx, resource)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			return presentDryRunResourceBundle(resource)
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
 }
 
+// Patch checks the update of a resource bundle and returns the would-be resource bundle, only the dry-run
+// is supported, the resource bundles are updated by publishing them over gRPC.
 func (h resourceBundleHandler) Patch(w http.ResponseWriter, r *http.Request) {
-	// not implemented
-	http.Error(w, "Not Implemented Yet", http.StatusNotImplemented)
+	var bundle openapi.ResourceBundle
+	cfg := &handlerConfig{
+		&bundle,
+		[]validate{
+			validateDryRun(r),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			found, serviceErr := h.resource.Get(ctx, id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+
+			bundle.Id = openapi.PtrString(id)
+			bundle.ConsumerName = openapi.PtrString(found.ConsumerName)
+			if bundle.Version == nil || *bundle.Version == 0 {
+				// use the latest resource version, as the gRPC update does
+				bundle.Version = openapi.PtrInt32(found.Version)
+			}
+			resource, err := presenters.ConvertResourceBundle(bundle, cetypes.UpdateRequestAction)
+			if err != nil {
+				return nil, errors.MalformedRequest("Invalid resource bundle: %s", err)
+			}
+			resource, serviceErr = h.resource.DryRunUpdate(ctx, resource)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			return presentDryRunResourceBundle(resource)
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusOK)
 }
 
 func (h resourceBundleHandler) Get(w http.ResponseWriter, r *http.Request) {
@@ -108,6 +168,9 @@ func (h resourceBundleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
+			if isDryRun(r) {
+				return nil, h.resource.DryRunMarkAsDeleting(ctx, id)
+			}
 			err := h.resource.MarkAsDeleting(ctx, id)
 			if err != nil {
 				return nil, err
@@ -117,3 +180,11 @@ func (h resourceBundleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	handleDelete(w, r, cfg, http.StatusNoContent)
 }
+
+func presentDryRunResourceBundle(resource *api.Resource) (interface{}, *errors.ServiceError) {
+	rb, err := presenters.PresentResourceBundle(resource)
+	if err != nil {
+		return nil, errors.GeneralError("failed to present resource bundle: %s", err)
+	}
+	return rb, nil
+}
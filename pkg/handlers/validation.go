@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"net/http"
 	"reflect"
+	"strconv"
 
+	"github.com/openshift-online/maestro/pkg/constants"
 	"github.com/openshift-online/maestro/pkg/errors"
 )
 
@@ -37,3 +40,19 @@ func validateEmpty(i interface{}, fieldName string, field string) validate {
 		return nil
 	}
 }
+
+// validateDryRun rejects the request if it is not a dry-run, it is used by the requests that only support the dry-run.
+func validateDryRun(r *http.Request) validate {
+	return func() *errors.ServiceError {
+		if !isDryRun(r) {
+			return errors.NotImplemented("only the %s=true request is supported, use gRPC to publish the change", constants.DryRunQueryParam)
+		}
+		return nil
+	}
+}
+
+// isDryRun returns true if the request has the dryRun=true query parameter.
+func isDryRun(r *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get(constants.DryRunQueryParam))
+	return dryRun
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/audit"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+type AuditRecordService interface {
+	// Record records a mutation made by the actor in the given context, the payload is the
+	// resource spec (or consumer) after the mutation, it is nil for a deletion.
+	Record(ctx context.Context, action api.AuditAction, resourceType, resourceID string, version int32, payload interface{}) *errors.ServiceError
+	List(ctx context.Context, filter dao.AuditRecordFilter) (api.AuditRecordList, int64, *errors.ServiceError)
+}
+
+// NewAuditRecordService returns an audit record service that writes the records to the database and,
+// if the sink is not nil, to the sink.
+func NewAuditRecordService(auditRecordDao dao.AuditRecordDao, sink audit.Sink) AuditRecordService {
+	return &sqlAuditRecordService{
+		auditRecordDao: auditRecordDao,
+		sink:           sink,
+	}
+}
+
+var _ AuditRecordService = &sqlAuditRecordService{}
+
+type sqlAuditRecordService struct {
+	auditRecordDao dao.AuditRecordDao
+	sink           audit.Sink
+}
+
+func (s *sqlAuditRecordService) Record(ctx context.Context, action api.AuditAction, resourceType, resourceID string, version int32, payload interface{}) *errors.ServiceError {
+	payloadHash, err := audit.PayloadHash(payload)
+	if err != nil {
+		return errors.GeneralError("Unable to hash the payload of %s %s: %s", resourceType, resourceID, err)
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	record, err := s.auditRecordDao.Create(ctx, &api.AuditRecord{
+		Actor:        actor.User,
+		Groups:       actor.Groups,
+		Source:       actor.Source,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Version:      version,
+		PayloadHash:  payloadHash,
+	})
+	if err != nil {
+		return handleCreateError("AuditRecord", err)
+	}
+
+	if s.sink != nil {
+		// the record is written to the sink once the mutation is committed, so the sink never reports a
+		// mutation that is rolled back. The database is the source of truth, a failure of the sink does
+		// not fail the mutation
+		db.AfterCommit(ctx, func() {
+			if err := s.sink.Write(record); err != nil {
+				klog.FromContext(ctx).Error(err, "Unable to write audit record to the sink", "auditRecordID", record.ID)
+			}
+		})
+	}
+
+	return nil
+}
+
+func (s *sqlAuditRecordService) List(ctx context.Context, filter dao.AuditRecordFilter) (api.AuditRecordList, int64, *errors.ServiceError) {
+	records, total, err := s.auditRecordDao.List(ctx, filter)
+	if err != nil {
+		return nil, 0, errors.GeneralError("Unable to list audit records: %s", err)
+	}
+	return records, total, nil
+}
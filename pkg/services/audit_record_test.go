@@ -0,0 +1,95 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/audit"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	"github.com/openshift-online/maestro/pkg/db"
+	dbContext "github.com/openshift-online/maestro/pkg/db/db_context"
+	"github.com/openshift-online/maestro/pkg/db/transaction"
+)
+
+func TestConsumerMutationsAreAudited(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	buf := &bytes.Buffer{}
+	auditRecords := NewAuditRecordService(mocks.NewAuditRecordDao(), audit.NewJSONSink(buf))
+	consumerService := NewConsumerService(mocks.NewConsumerDao(), auditRecords)
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{User: "alice", Groups: []string{"admins"}, Source: audit.SourceREST})
+	consumer, err := consumerService.Create(ctx, &api.Consumer{Meta: api.Meta{ID: "c1"}, Name: "cluster1"})
+	gm.Expect(err).To(gm.BeNil())
+
+	consumer.Labels = &db.StringMap{"env": "test"}
+	_, err = consumerService.Replace(ctx, consumer)
+	gm.Expect(err).To(gm.BeNil())
+
+	// the deletion is made by maestro itself
+	gm.Expect(consumerService.Delete(context.Background(), consumer.ID)).To(gm.BeNil())
+
+	records, total, err := auditRecords.List(context.Background(), dao.AuditRecordFilter{})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(total).To(gm.Equal(int64(3)))
+
+	gm.Expect(records[0].Action).To(gm.Equal(api.AuditActionCreate))
+	gm.Expect(records[0].Actor).To(gm.Equal("alice"))
+	gm.Expect([]string(records[0].Groups)).To(gm.Equal([]string{"admins"}))
+	gm.Expect(records[0].Source).To(gm.Equal(audit.SourceREST))
+	gm.Expect(records[0].ResourceType).To(gm.Equal("Consumer"))
+	gm.Expect(records[0].ResourceID).To(gm.Equal("c1"))
+	gm.Expect(records[0].PayloadHash).NotTo(gm.BeEmpty())
+
+	gm.Expect(records[1].Action).To(gm.Equal(api.AuditActionUpdate))
+	gm.Expect(records[1].PayloadHash).NotTo(gm.Equal(records[0].PayloadHash))
+
+	gm.Expect(records[2].Action).To(gm.Equal(api.AuditActionDelete))
+	gm.Expect(records[2].Actor).To(gm.Equal(audit.MaestroUser))
+	gm.Expect(records[2].Source).To(gm.Equal(audit.SourceMaestro))
+	gm.Expect(records[2].PayloadHash).To(gm.BeEmpty())
+
+	// filter by the actor
+	records, total, err = auditRecords.List(context.Background(), dao.AuditRecordFilter{Actor: "alice", Limit: 1})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(total).To(gm.Equal(int64(2)))
+	gm.Expect(records).To(gm.HaveLen(1))
+
+	// every record is written to the sink as a JSON line
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	gm.Expect(lines).To(gm.HaveLen(3))
+	record := api.AuditRecord{}
+	gm.Expect(json.Unmarshal(lines[0], &record)).To(gm.Succeed())
+	gm.Expect(record.Actor).To(gm.Equal("alice"))
+	gm.Expect(record.Action).To(gm.Equal(api.AuditActionCreate))
+}
+
+func TestAuditSinkIsWrittenAfterCommit(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	buf := &bytes.Buffer{}
+	auditRecords := NewAuditRecordService(mocks.NewAuditRecordDao(), audit.NewJSONSink(buf))
+	consumerService := NewConsumerService(mocks.NewConsumerDao(), auditRecords)
+
+	// the mutation of a request is recorded in the database, but it is not written to the sink
+	// until the transaction of the request is committed
+	tx := transaction.Build(nil, 1, false)
+	ctx := dbContext.WithTransaction(context.Background(), tx)
+	_, err := consumerService.Create(ctx, &api.Consumer{Meta: api.Meta{ID: "c1"}, Name: "cluster1"})
+	gm.Expect(err).To(gm.BeNil())
+
+	_, total, err := auditRecords.List(context.Background(), dao.AuditRecordFilter{})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(total).To(gm.Equal(int64(1)))
+	gm.Expect(buf.Len()).To(gm.BeZero())
+
+	// the transaction fails to commit, the sink is never written
+	gm.Expect(tx.Commit()).NotTo(gm.Succeed())
+	gm.Expect(buf.Len()).To(gm.BeZero())
+}
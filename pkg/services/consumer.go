@@ -19,16 +19,18 @@ type ConsumerService interface {
 	FindByNames(ctx context.Context, names []string) (api.ConsumerList, *errors.ServiceError)
 }
 
-func NewConsumerService(consumerDao dao.ConsumerDao) ConsumerService {
+func NewConsumerService(consumerDao dao.ConsumerDao, auditRecords AuditRecordService) ConsumerService {
 	return &sqlConsumerService{
-		consumerDao: consumerDao,
+		consumerDao:  consumerDao,
+		auditRecords: auditRecords,
 	}
 }
 
 var _ ConsumerService = &sqlConsumerService{}
 
 type sqlConsumerService struct {
-	consumerDao dao.ConsumerDao
+	consumerDao  dao.ConsumerDao
+	auditRecords AuditRecordService
 }
 
 func (s *sqlConsumerService) Get(ctx context.Context, id string) (*api.Consumer, *errors.ServiceError) {
@@ -50,6 +52,10 @@ func (s *sqlConsumerService) Create(ctx context.Context, consumer *api.Consumer)
 	if err != nil {
 		return nil, handleCreateError("Consumer", err)
 	}
+
+	if err := s.auditRecords.Record(ctx, api.AuditActionCreate, "Consumer", consumer.ID, 0, consumerAuditPayload(consumer)); err != nil {
+		return nil, err
+	}
 	return consumer, nil
 }
 
@@ -58,6 +64,10 @@ func (s *sqlConsumerService) Replace(ctx context.Context, consumer *api.Consumer
 	if err != nil {
 		return nil, handleUpdateError("Consumer", err)
 	}
+
+	if err := s.auditRecords.Record(ctx, api.AuditActionUpdate, "Consumer", consumer.ID, 0, consumerAuditPayload(consumer)); err != nil {
+		return nil, err
+	}
 	return consumer, nil
 }
 
@@ -69,7 +79,7 @@ func (s *sqlConsumerService) Delete(ctx context.Context, id string) *errors.Serv
 	if err := s.consumerDao.Delete(ctx, id, true); err != nil {
 		return handleDeleteError("Consumer", err)
 	}
-	return nil
+	return s.auditRecords.Record(ctx, api.AuditActionDelete, "Consumer", id, 0, nil)
 }
 
 func (s *sqlConsumerService) FindByIDs(ctx context.Context, ids []string) (api.ConsumerList, *errors.ServiceError) {
@@ -95,3 +105,11 @@ func (s *sqlConsumerService) All(ctx context.Context) (api.ConsumerList, *errors
 	}
 	return consumers, nil
 }
+
+// consumerAuditPayload returns the mutable fields of a consumer, their hash is recorded in the audit log.
+func consumerAuditPayload(consumer *api.Consumer) map[string]interface{} {
+	return map[string]interface{}{
+		"name":   consumer.Name,
+		"labels": consumer.Labels.ToMap(),
+	}
+}
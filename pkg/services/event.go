@@ -7,6 +7,7 @@ import (
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 type EventService interface {
@@ -45,6 +46,11 @@ func (s *sqlEventService) Get(ctx context.Context, id string) (*api.Event, *erro
 }
 
 func (s *sqlEventService) Create(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError) {
+	// persist the trace context of the request, so the trace can be resumed when the event is processed
+	if event.TraceParent == "" {
+		event.TraceParent, event.TraceState = tracing.TraceContextFromContext(ctx)
+	}
+
 	event, err := s.eventDao.Create(ctx, event)
 	if err != nil {
 		return nil, handleCreateError("Event", err)
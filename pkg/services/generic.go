@@ -5,6 +5,7 @@ import (
 	e "errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/Masterminds/squirrel"
@@ -91,6 +92,9 @@ func (s *sqlGenericService) List(ctx context.Context, username string, args *Lis
 		// add "ORDER BY"
 		s.buildOrderBy,
 
+		// add the "WHERE"(s) of the filters, they are combined with the search by AND
+		s.buildFilters,
+
 		// translate "search" into "WHERE"(s), and "JOIN"(s) if related resource is searched.
 		s.buildSearch,
 
@@ -145,6 +149,19 @@ func (s *sqlGenericService) buildOrderBy(listCtx *listContext, d *dao.GenericDao
 	return false, nil
 }
 
+func (s *sqlGenericService) buildFilters(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	columns := make([]string, 0, len(listCtx.args.Filters))
+	for column := range listCtx.args.Filters {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		(*d).Where(fmt.Sprintf("%s.%s = ?", (*d).GetTableName(), column), []interface{}{listCtx.args.Filters[column]})
+	}
+	return false, nil
+}
+
 func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
 	if listCtx.args.Search == "" {
 		s.addJoins(listCtx, d)
@@ -157,7 +174,8 @@ func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao)
 		if err != nil {
 			return false, errors.BadRequest("failed to parse the search query: %v", err)
 		}
-		(*d).Where(sql, values.([]interface{}))
+		// the search is parenthesized so that its OR cannot widen the conditions of the filters
+		(*d).Where("("+sql+")", values.([]interface{}))
 		return true, nil
 	}
 
@@ -189,7 +207,7 @@ func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao)
 	if err != nil {
 		return false, errors.GeneralError("%s", err.Error())
 	}
-	(*d).Where(sql, values)
+	(*d).Where("("+sql+")", values)
 	return true, nil
 }
 
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	e "errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// idempotencyLease is how long a key is held by a request in progress, the key can be reserved by a retry
+// after the lease if the request never completes, e.g. the server crashes.
+const idempotencyLease = time.Minute
+
+type IdempotencyService interface {
+	// Begin reserves the idempotency key for the request. It returns nil if the request should be executed,
+	// or the completed record of the first request with the same key whose result should be replayed.
+	// A Conflict error is returned if the key is in use by a different request or a request in progress.
+	Begin(ctx context.Context, key string, request []byte) (*api.IdempotencyRecord, *errors.ServiceError)
+	// Complete stores the result of the request, the result is replayed for the retries until the key expires.
+	Complete(ctx context.Context, key string, result []byte) *errors.ServiceError
+	// Abort releases the idempotency key of a failed request, so that a retry executes the request again.
+	Abort(ctx context.Context, key string) *errors.ServiceError
+	// PurgeExpired deletes the expired idempotency records and returns the number of deleted records.
+	PurgeExpired(ctx context.Context) (int64, *errors.ServiceError)
+}
+
+// NewIdempotencyService returns an idempotency service that stores the results for the given ttl,
+// the idempotency keys are ignored if the ttl is 0.
+func NewIdempotencyService(idempotencyRecordDao dao.IdempotencyRecordDao, ttl time.Duration) IdempotencyService {
+	return &sqlIdempotencyService{
+		idempotencyRecordDao: idempotencyRecordDao,
+		ttl:                  ttl,
+	}
+}
+
+var _ IdempotencyService = &sqlIdempotencyService{}
+
+type sqlIdempotencyService struct {
+	idempotencyRecordDao dao.IdempotencyRecordDao
+	ttl                  time.Duration
+}
+
+func (s *sqlIdempotencyService) Begin(ctx context.Context, key string, request []byte) (*api.IdempotencyRecord, *errors.ServiceError) {
+	if s.ttl <= 0 || key == "" {
+		return nil, nil
+	}
+
+	requestHash := hashRequest(request)
+	now := time.Now()
+	reserved, err := s.idempotencyRecordDao.Reserve(ctx, &api.IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(idempotencyLease),
+	})
+	if err != nil {
+		return nil, errors.GeneralError("Unable to reserve the idempotency key %s: %s", key, err)
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	record, err := s.idempotencyRecordDao.Get(ctx, key)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			// the record is released by its request right now, let the client retry
+			return nil, errors.Conflict("A request with the idempotency key %s is in progress", key)
+		}
+		return nil, handleGetError("IdempotencyRecord", "key", key, err)
+	}
+	if record.RequestHash != requestHash {
+		return nil, errors.Conflict("The idempotency key %s is reused with a different request", key)
+	}
+	if !record.Completed {
+		return nil, errors.Conflict("A request with the idempotency key %s is in progress", key)
+	}
+	return record, nil
+}
+
+func (s *sqlIdempotencyService) Complete(ctx context.Context, key string, result []byte) *errors.ServiceError {
+	if s.ttl <= 0 || key == "" {
+		return nil
+	}
+
+	if err := s.idempotencyRecordDao.Complete(ctx, key, result, time.Now().Add(s.ttl)); err != nil {
+		return handleUpdateError("IdempotencyRecord", err)
+	}
+	return nil
+}
+
+func (s *sqlIdempotencyService) Abort(ctx context.Context, key string) *errors.ServiceError {
+	if s.ttl <= 0 || key == "" {
+		return nil
+	}
+
+	if err := s.idempotencyRecordDao.Delete(ctx, key); err != nil {
+		return handleDeleteError("IdempotencyRecord", err)
+	}
+	return nil
+}
+
+func (s *sqlIdempotencyService) PurgeExpired(ctx context.Context) (int64, *errors.ServiceError) {
+	count, err := s.idempotencyRecordDao.DeleteExpired(ctx)
+	if err != nil {
+		return 0, handleDeleteError("IdempotencyRecord", err)
+	}
+	return count, nil
+}
+
+func hashRequest(request []byte) string {
+	sum := sha256.Sum256(bytes.TrimSpace(request))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+)
+
+func TestIdempotencyService(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	idempotencyService := NewIdempotencyService(mocks.NewIdempotencyRecordDao(), time.Hour)
+
+	// the first request is executed
+	record, err := idempotencyService.Begin(ctx, "k1", []byte(`{"name":"cluster1"}`))
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(record).To(gm.BeNil())
+
+	// a retry while the first request is in progress
+	_, err = idempotencyService.Begin(ctx, "k1", []byte(`{"name":"cluster1"}`))
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.IsConflict()).To(gm.BeTrue())
+
+	gm.Expect(idempotencyService.Complete(ctx, "k1", []byte(`{"id":"c1"}`))).To(gm.BeNil())
+
+	// a retry replays the result of the first request
+	record, err = idempotencyService.Begin(ctx, "k1", []byte(`{"name":"cluster1"}`))
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(record).NotTo(gm.BeNil())
+	gm.Expect(string(record.Result)).To(gm.Equal(`{"id":"c1"}`))
+
+	// the key is reused with a different request
+	_, err = idempotencyService.Begin(ctx, "k1", []byte(`{"name":"cluster2"}`))
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.IsConflict()).To(gm.BeTrue())
+
+	// a failed request is executed again on retry
+	_, err = idempotencyService.Begin(ctx, "k2", []byte(`{"name":"cluster2"}`))
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(idempotencyService.Abort(ctx, "k2")).To(gm.BeNil())
+	record, err = idempotencyService.Begin(ctx, "k2", []byte(`{"name":"cluster2"}`))
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(record).To(gm.BeNil())
+}
+
+func TestIdempotencyServiceDisabled(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	idempotencyService := NewIdempotencyService(mocks.NewIdempotencyRecordDao(), 0)
+
+	for i := 0; i < 2; i++ {
+		record, err := idempotencyService.Begin(ctx, "k1", []byte(`{"name":"cluster1"}`))
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(record).To(gm.BeNil())
+		gm.Expect(idempotencyService.Complete(ctx, "k1", []byte(`{"id":"c1"}`))).To(gm.BeNil())
+	}
+}
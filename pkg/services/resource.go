@@ -28,6 +28,11 @@ type ResourceService interface {
 	Update(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError)
 	UpdateStatus(ctx context.Context, resource *api.Resource) (*api.Resource, bool, *errors.ServiceError)
 	MarkAsDeleting(ctx context.Context, id string) *errors.ServiceError
+	// DryRunCreate, DryRunUpdate and DryRunMarkAsDeleting run the checks of Create, Update and MarkAsDeleting
+	// without writing the resource, its events or audit records, they return the would-be result.
+	DryRunCreate(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError)
+	DryRunUpdate(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError)
+	DryRunMarkAsDeleting(ctx context.Context, id string) *errors.ServiceError
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	All(ctx context.Context) (api.ResourceList, *errors.ServiceError)
 
@@ -38,22 +43,67 @@ type ResourceService interface {
 	ListWithArgs(ctx context.Context, username string, args *ListArguments, resources *[]api.Resource) (*api.PagingMeta, *errors.ServiceError)
 }
 
-func NewResourceService(lockFactory db.LockFactory, resourceDao dao.ResourceDao, events EventService, generic GenericService) ResourceService {
+func NewResourceService(lockFactory db.LockFactory, resourceDao dao.ResourceDao, consumerDao dao.ConsumerDao, events EventService,
+	generic GenericService, auditRecords AuditRecordService) ResourceService {
 	return &sqlResourceService{
-		lockFactory: lockFactory,
-		resourceDao: resourceDao,
-		events:      events,
-		generic:     generic,
+		lockFactory:  lockFactory,
+		resourceDao:  resourceDao,
+		consumerDao:  consumerDao,
+		events:       events,
+		generic:      generic,
+		auditRecords: auditRecords,
 	}
 }
 
 var _ ResourceService = &sqlResourceService{}
 
+// sqlResourceService records the spec mutations (create, update and delete) of the resources in the audit log,
+// the status updates are reported by the agents, so they are not recorded.
 type sqlResourceService struct {
-	lockFactory db.LockFactory
-	resourceDao dao.ResourceDao
-	events      EventService
-	generic     GenericService
+	lockFactory  db.LockFactory
+	resourceDao  dao.ResourceDao
+	consumerDao  dao.ConsumerDao
+	events       EventService
+	generic      GenericService
+	auditRecords AuditRecordService
+}
+
+// validateNewResource validates the name and manifest bundle of a resource to be created.
+func validateNewResource(resource *api.Resource) *errors.ServiceError {
+	if resource.Name != "" {
+		if err := ValidateResourceName(resource); err != nil {
+			return errors.Validation("the name in the resource is invalid, %v", err)
+		}
+	}
+	if err := ValidateManifestBundle(resource.Payload); err != nil {
+		return errors.Validation("the manifest bundle in the resource is invalid, %v", err)
+	}
+	return nil
+}
+
+// applyResourceUpdate applies the requested manifest bundle to the found resource, it returns false
+// if the manifest bundle is not changed.
+func applyResourceUpdate(found, resource *api.Resource) (bool, *errors.ServiceError) {
+	// Make sure the requested resource version is consistent with its database version.
+	if found.Version != resource.Version {
+		return false, errors.Conflict("the resource version is not the latest, the latest version: %d", found.Version)
+	}
+
+	// New manifest is not changed, the update action is not needed.
+	if reflect.DeepEqual(resource.Payload, found.Payload) {
+		return false, nil
+	}
+
+	if err := ValidateManifestBundle(resource.Payload); err != nil {
+		return false, errors.Validation("the new manifest bundle in the resource is invalid, %v", err)
+	}
+
+	// Increase the current resource version and update its manifest.
+	// Note: Maestro agent sets work metadata generation from the current resource version,
+	// ignoring the `generation` and `resourceVersion` from the CloudEvents metadata extension.
+	found.Version = found.Version + 1
+	found.Payload = resource.Payload
+	return true, nil
 }
 
 func (s *sqlResourceService) Get(ctx context.Context, id string) (*api.Resource, *errors.ServiceError) {
@@ -69,13 +119,8 @@ func (s *sqlResourceService) Get(ctx context.Context, id string) (*api.Resource,
 }
 
 func (s *sqlResourceService) Create(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
-	if resource.Name != "" {
-		if err := ValidateResourceName(resource); err != nil {
-			return nil, errors.Validation("the name in the resource is invalid, %v", err)
-		}
-	}
-	if err := ValidateManifestBundle(resource.Payload); err != nil {
-		return nil, errors.Validation("the manifest bundle in the resource is invalid, %v", err)
+	if err := validateNewResource(resource); err != nil {
+		return nil, err
 	}
 
 	resource, err := s.resourceDao.Create(ctx, resource)
@@ -92,6 +137,10 @@ func (s *sqlResourceService) Create(ctx context.Context, resource *api.Resource)
 		return nil, handleCreateError("Resource", eErr)
 	}
 
+	if err := s.auditRecords.Record(ctx, api.AuditActionCreate, "Resource", resource.ID, resource.Version, resource.Payload); err != nil {
+		return nil, err
+	}
+
 	return resource, nil
 }
 
@@ -115,26 +164,14 @@ func (s *sqlResourceService) Update(ctx context.Context, resource *api.Resource)
 		return nil, errors.Conflict("the resource is under deletion, id: %s", resource.ID)
 	}
 
-	// Make sure the requested resource version is consistent with its database version.
-	if found.Version != resource.Version {
-		return nil, errors.Conflict("the resource version is not the latest, the latest version: %d", found.Version)
+	changed, svcErr := applyResourceUpdate(found, resource)
+	if svcErr != nil {
+		return nil, svcErr
 	}
-
-	// New manifest is not changed, the update action is not needed.
-	if reflect.DeepEqual(resource.Payload, found.Payload) {
+	if !changed {
 		return found, nil
 	}
 
-	if err := ValidateManifestBundle(resource.Payload); err != nil {
-		return nil, errors.Validation("the new manifest bundle in the resource is invalid, %v", err)
-	}
-
-	// Increase the current resource version and update its manifest.
-	// Note: Maestro agent sets work metadata generation from the current resource version,
-	// ignoring the `generation` and `resourceVersion` from the CloudEvents metadata extension.
-	found.Version = found.Version + 1
-	found.Payload = resource.Payload
-
 	updated, err := s.resourceDao.Update(ctx, found)
 	if err != nil {
 		return nil, handleUpdateError("Resource", err)
@@ -148,6 +185,10 @@ func (s *sqlResourceService) Update(ctx context.Context, resource *api.Resource)
 		return nil, handleUpdateError("Resource", err)
 	}
 
+	if err := s.auditRecords.Record(ctx, api.AuditActionUpdate, "Resource", updated.ID, updated.Version, updated.Payload); err != nil {
+		return nil, err
+	}
+
 	// Create the set of labels that we will add to all the resource process:
 	labels := prometheus.Labels{
 		metricsIDLabel:     updated.ID,
@@ -307,6 +348,65 @@ func (s *sqlResourceService) MarkAsDeleting(ctx context.Context, id string) *err
 		return handleDeleteError("Resource", err)
 	}
 
+	return s.auditRecords.Record(ctx, api.AuditActionDelete, "Resource", id, existing.Version, nil)
+}
+
+func (s *sqlResourceService) DryRunCreate(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	if err := validateNewResource(resource); err != nil {
+		return nil, err
+	}
+
+	if resource.ID != "" {
+		_, err := s.resourceDao.Get(ctx, resource.ID)
+		if err == nil {
+			return nil, errors.Conflict("This Resource already exists")
+		}
+		if svcErr := handleGetError("Resource", "id", resource.ID, err); !svcErr.Is404() {
+			return nil, svcErr
+		}
+	}
+
+	// the consumer is referenced by a foreign key, the creation fails if the consumer does not exist
+	consumers, err := s.consumerDao.FindByNames(ctx, []string{resource.ConsumerName})
+	if err != nil {
+		return nil, errors.GeneralError("Unable to find Consumer with name='%s': %s", resource.ConsumerName, err)
+	}
+	if len(consumers) == 0 {
+		return nil, errors.NotFound("Consumer with name='%s' not found", resource.ConsumerName)
+	}
+
+	// set the defaults of the database record, e.g. ID, name and version
+	created := *resource
+	_ = created.BeforeCreate(nil)
+	created.CreatedAt = time.Now()
+	created.UpdatedAt = created.CreatedAt
+	return &created, nil
+}
+
+func (s *sqlResourceService) DryRunUpdate(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	found, err := s.resourceDao.Get(ctx, resource.ID)
+	if err != nil {
+		return nil, handleGetError("Resource", "id", resource.ID, err)
+	}
+
+	if !found.DeletedAt.Time.IsZero() {
+		return nil, errors.Conflict("the resource is under deletion, id: %s", resource.ID)
+	}
+
+	updated := *found
+	if _, svcErr := applyResourceUpdate(&updated, resource); svcErr != nil {
+		return nil, svcErr
+	}
+	return &updated, nil
+}
+
+func (s *sqlResourceService) DryRunMarkAsDeleting(ctx context.Context, id string) *errors.ServiceError {
+	// the deletion is idempotent, only the failure of finding the resource fails it
+	if _, err := s.resourceDao.Get(ctx, id); err != nil {
+		if svcErr := handleGetError("Resource", "id", id, err); !svcErr.Is404() {
+			return svcErr
+		}
+	}
 	return nil
 }
 
@@ -315,7 +415,7 @@ func (s *sqlResourceService) Delete(ctx context.Context, id string) *errors.Serv
 		return handleDeleteError("Resource", errors.GeneralError("Unable to delete resource: %s", err))
 	}
 
-	return nil
+	return s.auditRecords.Record(ctx, api.AuditActionPurge, "Resource", id, 0, nil)
 }
 
 func (s *sqlResourceService) FindByIDs(ctx context.Context, ids []string) (api.ResourceList, *errors.ServiceError) {
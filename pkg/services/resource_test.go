@@ -9,6 +9,7 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 
 	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/dao/mocks"
 	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
 )
@@ -25,7 +26,7 @@ func TestResourceFindByConsumerID(t *testing.T) {
 	resourceDAO := mocks.NewResourceDao()
 	events := NewEventService(mocks.NewEventDao())
 
-	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, events, nil)
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, mocks.NewConsumerDao(), events, nil, NewAuditRecordService(mocks.NewAuditRecordDao(), nil))
 
 	resources := api.ResourceList{
 		&api.Resource{ConsumerName: Fukuisaurus, Payload: newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}},{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"},\"spec\":{\"replicas\":1,\"selector\":{\"matchLabels\":{\"app\":\"nginx\"}},\"template\":{\"spec\":{\"containers\":[{\"name\":\"nginx\",\"image\":\"quay.io/nginx/nginx-unprivileged:latest\"}]},\"metadata\":{\"labels\":{\"app\":\"nginx\"}}}}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"ServerSideApply\"},\"resourceIdentifier\":{\"name\":\"nginx\",\"group\":\"apps\",\"resource\":\"deployments\",\"namespace\":\"default\"}}]}}")},
@@ -57,7 +58,7 @@ func TestCreateInvalidResource(t *testing.T) {
 
 	resourceDAO := mocks.NewResourceDao()
 	events := NewEventService(mocks.NewEventDao())
-	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, events, nil)
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, mocks.NewConsumerDao(), events, nil, NewAuditRecordService(mocks.NewAuditRecordDao(), nil))
 
 	resource := &api.Resource{ConsumerName: "invalidation", Payload: newPayload(t, "{}")}
 
@@ -80,7 +81,7 @@ func TestMarkAsDeletingIsIdempotent(t *testing.T) {
 	ctx := context.Background()
 	resourceDAO := mocks.NewResourceDao()
 	events := NewEventService(mocks.NewEventDao())
-	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, events, nil)
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, mocks.NewConsumerDao(), events, nil, NewAuditRecordService(mocks.NewAuditRecordDao(), nil))
 
 	resource, svcErr := resourceService.Create(ctx, &api.Resource{
 		ConsumerName: Fukuisaurus,
@@ -116,7 +117,7 @@ func TestResourceList(t *testing.T) {
 	resourceDAO := mocks.NewResourceDao()
 	events := NewEventService(mocks.NewEventDao())
 
-	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, events, nil)
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, mocks.NewConsumerDao(), events, nil, NewAuditRecordService(mocks.NewAuditRecordDao(), nil))
 	resources := api.ResourceList{
 		&api.Resource{ConsumerName: Fukuisaurus, Payload: newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}},{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"},\"spec\":{\"replicas\":1,\"selector\":{\"matchLabels\":{\"app\":\"nginx\"}},\"template\":{\"spec\":{\"containers\":[{\"name\":\"nginx\",\"image\":\"quay.io/nginx/nginx-unprivileged:latest\"}]},\"metadata\":{\"labels\":{\"app\":\"nginx\"}}}}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"ServerSideApply\"},\"resourceIdentifier\":{\"name\":\"nginx\",\"group\":\"apps\",\"resource\":\"deployments\",\"namespace\":\"default\"}}]}}")},
 		&api.Resource{ConsumerName: Fukuisaurus, Payload: newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}},{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"},\"spec\":{\"replicas\":1,\"selector\":{\"matchLabels\":{\"app\":\"nginx\"}},\"template\":{\"spec\":{\"containers\":[{\"name\":\"nginx\",\"image\":\"quay.io/nginx/nginx-unprivileged:latest\"}]},\"metadata\":{\"labels\":{\"app\":\"nginx\"}}}}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"ServerSideApply\"},\"resourceIdentifier\":{\"name\":\"nginx\",\"group\":\"apps\",\"resource\":\"deployments\",\"namespace\":\"default\"}}]}}")},
@@ -147,3 +148,72 @@ func TestResourceList(t *testing.T) {
 	gm.Expect(err).To(gm.BeNil())
 	gm.Expect(len(resources)).To(gm.Equal(1))
 }
+
+func TestDryRunDoesNotPersist(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	consumerDAO := mocks.NewConsumerDao()
+	eventDAO := mocks.NewEventDao()
+	auditRecordDAO := mocks.NewAuditRecordDao()
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, consumerDAO, NewEventService(eventDAO), nil, NewAuditRecordService(auditRecordDAO, nil))
+
+	newResource := func() *api.Resource {
+		return &api.Resource{
+			Meta:         api.Meta{ID: Breviceratops},
+			ConsumerName: Fukuisaurus,
+			Payload:      newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}]}}"),
+		}
+	}
+
+	// the consumer does not exist, the mock dao fails to find it by its name instead of returning no consumers
+	_, svcErr := resourceService.DryRunCreate(ctx, newResource())
+	gm.Expect(svcErr).ShouldNot(gm.BeNil())
+
+	_, err := consumerDAO.Create(ctx, &api.Consumer{Meta: api.Meta{ID: Fukuisaurus}, Name: Fukuisaurus})
+	gm.Expect(err).To(gm.BeNil())
+
+	created, svcErr := resourceService.DryRunCreate(ctx, newResource())
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(created.ID).To(gm.Equal(Breviceratops))
+	gm.Expect(created.Name).To(gm.Equal(Breviceratops))
+	gm.Expect(created.Version).To(gm.Equal(int32(1)))
+
+	// the dry-run wrote nothing
+	_, svcErr = resourceService.Get(ctx, Breviceratops)
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+	events, err := eventDAO.All(ctx)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(events)).To(gm.Equal(0))
+	_, total, err := auditRecordDAO.List(ctx, dao.AuditRecordFilter{})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(total).To(gm.Equal(int64(0)))
+
+	created, svcErr = resourceService.Create(ctx, newResource())
+	gm.Expect(svcErr).To(gm.BeNil())
+	// the mock dao does not run the gorm hooks that start the version from 1
+	created.Version = 1
+
+	// the resource exists
+	_, svcErr = resourceService.DryRunCreate(ctx, newResource())
+	gm.Expect(svcErr).ShouldNot(gm.BeNil())
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	// the version is not the latest
+	update := newResource()
+	update.Version = 2
+	_, svcErr = resourceService.DryRunUpdate(ctx, update)
+	gm.Expect(svcErr).ShouldNot(gm.BeNil())
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	update.Version = 1
+	update.Payload["id"] = "d9bb6a2a-1c0b-4a3d-9b8b-7e0a2a6a3b4f"
+	updated, svcErr := resourceService.DryRunUpdate(ctx, update)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(updated.Version).To(gm.Equal(int32(2)))
+
+	found, svcErr := resourceService.Get(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(found.Version).To(gm.Equal(int32(1)))
+}
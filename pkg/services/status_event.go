@@ -2,10 +2,13 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/tracing"
 )
 
 type StatusEventService interface {
@@ -19,6 +22,10 @@ type StatusEventService interface {
 	FindAllUnreconciledEvents(ctx context.Context) (api.StatusEventList, *errors.ServiceError)
 	DeleteAllReconciledEvents(ctx context.Context) *errors.ServiceError
 	DeleteAllEvents(ctx context.Context, eventIDs []string) *errors.ServiceError
+	DeleteEventsCreatedBefore(ctx context.Context, before time.Duration) (int64, *errors.ServiceError)
+	FindBySourceAfterSequence(ctx context.Context, source string, sequence int64, limit int) (api.StatusEventList, *errors.ServiceError)
+	FirstSequenceCreatedSince(ctx context.Context, source string, since time.Time) (int64, *errors.ServiceError)
+	GetBySequence(ctx context.Context, sequence int64) (*api.StatusEvent, *errors.ServiceError)
 	GetNotificationQueueUsage(ctx context.Context) (*float64, *errors.ServiceError)
 }
 
@@ -43,6 +50,11 @@ func (s *sqlStatusEventService) Get(ctx context.Context, id string) (*api.Status
 }
 
 func (s *sqlStatusEventService) Create(ctx context.Context, statusEvent *api.StatusEvent) (*api.StatusEvent, *errors.ServiceError) {
+	// persist the trace context of the status update, so the trace can be resumed when the event is broadcast
+	if statusEvent.TraceParent == "" {
+		statusEvent.TraceParent, statusEvent.TraceState = tracing.TraceContextFromContext(ctx)
+	}
+
 	event, err := s.statusEventDao.Create(ctx, statusEvent)
 	if err != nil {
 		return nil, handleCreateError("StatusEvent", err)
@@ -103,6 +115,38 @@ func (s *sqlStatusEventService) DeleteAllEvents(ctx context.Context, eventIDs []
 	return nil
 }
 
+func (s *sqlStatusEventService) DeleteEventsCreatedBefore(ctx context.Context, before time.Duration) (int64, *errors.ServiceError) {
+	count, err := s.statusEventDao.DeleteEventsCreatedBefore(ctx, before)
+	if err != nil {
+		return 0, handleDeleteError("StatusEvent", errors.GeneralError("Unable to delete events created %s ago: %s", before, err))
+	}
+	return count, nil
+}
+
+func (s *sqlStatusEventService) FindBySourceAfterSequence(ctx context.Context, source string, sequence int64, limit int) (api.StatusEventList, *errors.ServiceError) {
+	statusEvents, err := s.statusEventDao.FindBySourceAfterSequence(ctx, source, sequence, limit)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get status events of source %s after %d: %s", source, sequence, err)
+	}
+	return statusEvents, nil
+}
+
+func (s *sqlStatusEventService) FirstSequenceCreatedSince(ctx context.Context, source string, since time.Time) (int64, *errors.ServiceError) {
+	sequence, err := s.statusEventDao.FirstSequenceCreatedSince(ctx, source, since)
+	if err != nil {
+		return 0, handleGetError("StatusEvent", "created_at", since.Format(time.RFC3339), err)
+	}
+	return sequence, nil
+}
+
+func (s *sqlStatusEventService) GetBySequence(ctx context.Context, sequence int64) (*api.StatusEvent, *errors.ServiceError) {
+	event, err := s.statusEventDao.GetBySequence(ctx, sequence)
+	if err != nil {
+		return nil, handleGetError("StatusEvent", "sequence_number", fmt.Sprintf("%d", sequence), err)
+	}
+	return event, nil
+}
+
 func (s *sqlStatusEventService) GetNotificationQueueUsage(ctx context.Context) (*float64, *errors.ServiceError) {
 	usage, err := s.statusEventDao.GetNotificationQueueUsage(ctx)
 	if err != nil {
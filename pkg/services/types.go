@@ -15,6 +15,10 @@ type ListArguments struct {
 	Search   string
 	OrderBy  []string
 	Fields   []string
+	// Filters restrict the list to the resources whose columns have the given values. They are added as separate
+	// conditions that are combined with the search by AND, so a search cannot widen them. The keys are column names
+	// set by the server, never by the caller.
+	Filters map[string]interface{}
 }
 
 // ~65500 is the maximum number of parameters that can be provided to a postgres WHERE IN clause
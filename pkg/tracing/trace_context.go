@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/datatypes"
+)
+
+// The CloudEvents distributed tracing extension attributes, they carry the W3C trace context of an event.
+// See https://github.com/cloudevents/spec/blob/main/cloudevents/extensions/distributed-tracing.md
+const (
+	ExtensionTraceParent = "traceparent"
+	ExtensionTraceState  = "tracestate"
+)
+
+const tracerName = "github.com/openshift-online/maestro"
+
+// the W3C trace context propagator is always used to persist and restore the trace context, regardless of
+// the global propagator, because the traceparent and tracestate are the only formats stored in the database.
+var traceContextPropagator = propagation.TraceContext{}
+
+// TraceContextFromContext returns the W3C traceparent and tracestate of the span in the given context.
+// Both values are empty if the context does not carry a valid span context.
+func TraceContextFromContext(ctx context.Context) (traceParent, traceState string) {
+	carrier := propagation.MapCarrier{}
+	traceContextPropagator.Inject(ctx, carrier)
+	return carrier.Get(ExtensionTraceParent), carrier.Get(ExtensionTraceState)
+}
+
+// ContextWithTraceContext returns a copy of the given context that carries the remote span context
+// described by the traceparent and tracestate. The context is returned unchanged if the traceparent is empty.
+func ContextWithTraceContext(ctx context.Context, traceParent, traceState string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+
+	carrier := propagation.MapCarrier{ExtensionTraceParent: traceParent}
+	if traceState != "" {
+		carrier[ExtensionTraceState] = traceState
+	}
+	return traceContextPropagator.Extract(ctx, carrier)
+}
+
+// StartSpan resumes the trace described by the traceparent and tracestate and starts a new span in it.
+// If the traceparent is empty, the span is started from the span in the given context (if any).
+func StartSpan(ctx context.Context, name, traceParent, traceState string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = ContextWithTraceContext(ctx, traceParent, traceState)
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartSpanFromCloudEvent starts a new span in the trace carried by the CloudEvent tracing extensions.
+func StartSpanFromCloudEvent(ctx context.Context, name string, evt *cloudevents.Event, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	traceParent, traceState := TraceContextFromCloudEvent(evt)
+	return StartSpan(ctx, name, traceParent, traceState, attrs...)
+}
+
+// TraceContextFromCloudEvent returns the traceparent and tracestate extensions of the given CloudEvent.
+func TraceContextFromCloudEvent(evt *cloudevents.Event) (traceParent, traceState string) {
+	if evt == nil {
+		return "", ""
+	}
+
+	extensions := evt.Extensions()
+	if value, ok := extensions[ExtensionTraceParent]; ok {
+		traceParent, _ = cloudeventstypes.ToString(value)
+	}
+	if value, ok := extensions[ExtensionTraceState]; ok {
+		traceState, _ = cloudeventstypes.ToString(value)
+	}
+	return traceParent, traceState
+}
+
+// SetCloudEventTraceContext sets the trace context of the span in the given context to the CloudEvent
+// tracing extensions, the CloudEvent is unchanged if the context does not carry a valid span context.
+func SetCloudEventTraceContext(ctx context.Context, evt *cloudevents.Event) {
+	traceParent, traceState := TraceContextFromContext(ctx)
+	if traceParent == "" {
+		return
+	}
+
+	evt.SetExtension(ExtensionTraceParent, traceParent)
+	if traceState != "" {
+		evt.SetExtension(ExtensionTraceState, traceState)
+	}
+}
+
+// RemoveCloudEventTraceContext removes the tracing extensions from the given CloudEvent.
+// The trace context is per request, so it must be removed before the event is persisted as the resource
+// spec or status, otherwise every request would be treated as a change.
+func RemoveCloudEventTraceContext(evt *cloudevents.Event) {
+	evt.SetExtension(ExtensionTraceParent, nil)
+	evt.SetExtension(ExtensionTraceState, nil)
+}
+
+// SetJSONMapTraceContext sets the trace context of the span in the given context to a CloudEvent
+// JSONMap (resource spec or status) as the tracing extensions.
+func SetJSONMapTraceContext(ctx context.Context, jsonmap datatypes.JSONMap) {
+	if jsonmap == nil {
+		return
+	}
+
+	traceParent, traceState := TraceContextFromContext(ctx)
+	if traceParent == "" {
+		return
+	}
+
+	jsonmap[ExtensionTraceParent] = traceParent
+	if traceState != "" {
+		jsonmap[ExtensionTraceState] = traceState
+	}
+}
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"gorm.io/datatypes"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTraceContextRoundTrip(t *testing.T) {
+	cases := []struct {
+		name        string
+		traceParent string
+		traceState  string
+	}{
+		{
+			name: "no trace context",
+		},
+		{
+			name:        "trace parent only",
+			traceParent: testTraceParent,
+		},
+		{
+			name:        "trace parent and trace state",
+			traceParent: testTraceParent,
+			traceState:  "congo=t61rcWkgMzE",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := ContextWithTraceContext(context.Background(), c.traceParent, c.traceState)
+			traceParent, traceState := TraceContextFromContext(ctx)
+			if traceParent != c.traceParent {
+				t.Errorf("expected traceparent %q, but got %q", c.traceParent, traceParent)
+			}
+			if traceState != c.traceState {
+				t.Errorf("expected tracestate %q, but got %q", c.traceState, traceState)
+			}
+		})
+	}
+}
+
+func TestStartSpanKeepsTraceID(t *testing.T) {
+	// without a tracer provider, the started span is a non-recording span that keeps the remote span context
+	ctx, span := StartSpan(context.Background(), "test", testTraceParent, "")
+	defer span.End()
+
+	if traceID := span.SpanContext().TraceID().String(); traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected trace id %s", traceID)
+	}
+
+	traceParent, _ := TraceContextFromContext(ctx)
+	if traceParent != testTraceParent {
+		t.Errorf("expected traceparent %q, but got %q", testTraceParent, traceParent)
+	}
+}
+
+func TestCloudEventTraceContext(t *testing.T) {
+	ctx := ContextWithTraceContext(context.Background(), testTraceParent, "congo=t61rcWkgMzE")
+
+	evt := cloudevents.NewEvent()
+	SetCloudEventTraceContext(ctx, &evt)
+	traceParent, traceState := TraceContextFromCloudEvent(&evt)
+	if traceParent != testTraceParent || traceState != "congo=t61rcWkgMzE" {
+		t.Errorf("unexpected trace context %q, %q", traceParent, traceState)
+	}
+
+	RemoveCloudEventTraceContext(&evt)
+	if len(evt.Extensions()) != 0 {
+		t.Errorf("expected no extensions, but got %v", evt.Extensions())
+	}
+
+	// the event is unchanged if there is no trace context
+	SetCloudEventTraceContext(context.Background(), &evt)
+	if len(evt.Extensions()) != 0 {
+		t.Errorf("expected no extensions, but got %v", evt.Extensions())
+	}
+}
+
+func TestSetJSONMapTraceContext(t *testing.T) {
+	jsonmap := datatypes.JSONMap{"specversion": "1.0"}
+	SetJSONMapTraceContext(context.Background(), jsonmap)
+	if _, ok := jsonmap[ExtensionTraceParent]; ok {
+		t.Errorf("expected no traceparent, but got %v", jsonmap)
+	}
+
+	SetJSONMapTraceContext(ContextWithTraceContext(context.Background(), testTraceParent, ""), jsonmap)
+	if jsonmap[ExtensionTraceParent] != testTraceParent {
+		t.Errorf("expected traceparent %q, but got %v", testTraceParent, jsonmap[ExtensionTraceParent])
+	}
+	if _, ok := jsonmap[ExtensionTraceState]; ok {
+		t.Errorf("expected no tracestate, but got %v", jsonmap)
+	}
+}
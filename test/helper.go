@@ -116,7 +116,6 @@ func NewHelper(t *testing.T) *Helper {
 
 		// Set the healthcheck interval to 1 second for testing
 		helper.Env().Config.HealthCheck.HeartbeartInterval = 1
-		helper.HealthCheckServer = server.NewHealthCheckServer(ctx)
 		// Disable TLS for testing
 		helper.Env().Config.GRPCServer.DisableTLS = true
 
@@ -139,9 +138,10 @@ func NewHelper(t *testing.T) *Helper {
 			helper.EventServer = server.NewMessageQueueEventServer(helper.EventBroadcaster, helper.StatusDispatcher)
 			helper.EventFilter = controllers.NewLockBasedEventFilter(db.NewAdvisoryLockFactory(helper.Env().Database.SessionFactory))
 		default:
-			helper.EventServer = server.NewGRPCBroker(ctx, helper.EventBroadcaster)
+			helper.EventServer = server.NewGRPCBroker(ctx, helper.EventBroadcaster, helper.Env().Clients.GRPCAuthorizer)
 			helper.EventFilter = controllers.NewPredicatedEventFilter(helper.EventServer.PredicateEvent)
 		}
+		helper.HealthCheckServer = server.NewHealthCheckServer(ctx, helper.EventServer)
 
 		helper.teardowns = []func() error{
 			helper.sendShutdownSignal,
@@ -260,6 +260,7 @@ func (helper *Helper) StartControllerManager(ctx context.Context) {
 			helper.Env().Services.StatusEvents(),
 			dao.NewInstanceDao(&helper.Env().Database.SessionFactory),
 			dao.NewEventInstanceDao(&helper.Env().Database.SessionFactory),
+			time.Duration(helper.Env().Config.EventServer.StatusEventRetention)*time.Second,
 		),
 	}
 
@@ -518,6 +519,8 @@ func (helper *Helper) CleanDB() error {
 		"resources",
 		"consumers",
 		"server_instances",
+		"audit_records",
+		"idempotency_records",
 	} {
 		if g2.Migrator().HasTable(table) {
 			// remove table contents instead of dropping table
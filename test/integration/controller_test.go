@@ -109,6 +109,7 @@ func TestControllerRacing(t *testing.T) {
 					h.Env().Services.StatusEvents(),
 					dao.NewInstanceDao(&h.Env().Database.SessionFactory),
 					dao.NewEventInstanceDao(&h.Env().Database.SessionFactory),
+					0,
 				),
 			}
 
@@ -210,6 +211,7 @@ func TestControllerReconcile(t *testing.T) {
 				h.Env().Services.StatusEvents(),
 				dao.NewInstanceDao(&h.Env().Database.SessionFactory),
 				dao.NewEventInstanceDao(&h.Env().Database.SessionFactory),
+				0,
 			),
 		}
 
@@ -369,6 +371,7 @@ func TestControllerSync(t *testing.T) {
 				h.Env().Services.StatusEvents(),
 				dao.NewInstanceDao(&h.Env().Database.SessionFactory),
 				dao.NewEventInstanceDao(&h.Env().Database.SessionFactory),
+				0,
 			),
 		}
 
@@ -514,6 +517,7 @@ func TestStatusControllerSync(t *testing.T) {
 				h.Env().Services.StatusEvents(),
 				dao.NewInstanceDao(&h.Env().Database.SessionFactory),
 				dao.NewEventInstanceDao(&h.Env().Database.SessionFactory),
+				0,
 			),
 		}
 
@@ -593,6 +597,7 @@ func TestMultipleControllers(t *testing.T) {
 		h.Env().Services.StatusEvents(),
 		dao.NewInstanceDao(&h.Env().Database.SessionFactory),
 		dao.NewEventInstanceDao(&h.Env().Database.SessionFactory),
+		0,
 	)
 	statusCtrl.Add(map[api.StatusEventType][]controllers.StatusHandlerFunc{
 		api.StatusUpdateEventType: {func(ctx context.Context, eventID, sourceID string) error { return nil }},
@@ -718,6 +723,7 @@ func TestSpecEventAgeMetric(t *testing.T) {
 				h.Env().Services.StatusEvents(),
 				dao.NewInstanceDao(&h.Env().Database.SessionFactory),
 				dao.NewEventInstanceDao(&h.Env().Database.SessionFactory),
+				0,
 			),
 		}
 		s.Start(ctx)
@@ -802,6 +808,7 @@ func TestNotificationQueueUsageMetric(t *testing.T) {
 				h.Env().Services.StatusEvents(),
 				dao.NewInstanceDao(&h.Env().Database.SessionFactory),
 				dao.NewEventInstanceDao(&h.Env().Database.SessionFactory),
+				0,
 			),
 		}
 		s.Start(ctx)